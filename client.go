@@ -25,6 +25,7 @@ import (
 
 const userAgent = "redfish/1.0"
 const applicationJSON = "application/json"
+const textEventStream = "text/event-stream"
 
 // APIClient represents a connection to a Redfish/Swordfish enabled service
 // or device.
@@ -194,6 +195,18 @@ func (c *APIClient) Get(ctx context.Context, url string) (*http.Response, error)
 	return c.runRequest(ctx, http.MethodGet, relativePath, nil)
 }
 
+// GetWithHeaders performs a GET request against the Redfish service, adding
+// the provided headers to the request. Headers set this way take precedence
+// over the ones set by default.
+func (c *APIClient) GetWithHeaders(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	relativePath := url
+	if relativePath == "" {
+		relativePath = common.DefaultServiceRoot
+	}
+
+	return c.runRequestWithHeaders(ctx, http.MethodGet, relativePath, nil, headers)
+}
+
 // Post performs a Post request against the Redfish service.
 func (c *APIClient) Post(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
 	return c.runRequest(ctx, http.MethodPost, url, payload)
@@ -223,6 +236,12 @@ func (c *APIClient) Delete(ctx context.Context, url string) (*http.Response, err
 
 // runRequest actually performs the REST calls.
 func (c *APIClient) runRequest(ctx context.Context, method string, url string, payload interface{}) (*http.Response, error) {
+	return c.runRequestWithHeaders(ctx, method, url, payload, nil)
+}
+
+// runRequestWithHeaders performs the REST calls, adding any custom headers to
+// the request.
+func (c *APIClient) runRequestWithHeaders(ctx context.Context, method string, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
 	if url == "" {
 		return nil, fmt.Errorf("unable to execute request, no target provided")
 	}
@@ -262,6 +281,9 @@ func (c *APIClient) runRequest(ctx context.Context, method string, url string, p
 			}
 		}
 	}
+	for key, value := range customHeaders {
+		req.Header.Set(key, value)
+	}
 	req.Close = true
 
 	// Dump request if needed.
@@ -283,9 +305,10 @@ func (c *APIClient) runRequest(ctx context.Context, method string, url string, p
 		return nil, err
 	}
 
-	// Dump response if needed.
+	// Dump response if needed. The body of an event stream is never
+	// complete, so only its headers are dumped.
 	if c.dumpWriter != nil {
-		d, err := httputil.DumpResponse(resp, true)
+		d, err := httputil.DumpResponse(resp, req.Header.Get("Accept") != textEventStream)
 		if err != nil {
 			defer resp.Body.Close()
 			return nil, err
@@ -0,0 +1,227 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/jacobweinstock/gophish"
+	"github.com/jacobweinstock/gophish/common"
+	"github.com/jacobweinstock/gophish/redfish"
+)
+
+// healthStates are the values of the health enum metric.
+var healthStates = []common.Health{
+	common.OKHealth,
+	common.WarningHealth,
+	common.CriticalHealth,
+}
+
+// collector walks the resources of a service and records their metrics.
+type collector struct {
+	target string
+	set    *metricSet
+	// errors is the number of resources that could not be read.
+	errors int
+}
+
+// collect records the metrics of the chassis and systems of a service. Errors
+// reading a resource are logged and counted, and the walk goes on with the
+// other resources.
+func collect(ctx context.Context, target string, service *gophish.Service, set *metricSet) int {
+	c := &collector{target: target, set: set}
+
+	chassis, err := service.Chassis(ctx)
+	c.check(err, "chassis")
+	for _, chass := range chassis {
+		c.collectChassis(ctx, chass)
+	}
+
+	systems, err := service.Systems(ctx)
+	c.check(err, "systems")
+	for _, system := range systems {
+		c.collectSystem(ctx, system)
+	}
+
+	return c.errors
+}
+
+// collectChassis records the sensor readings, power and health of a chassis.
+func (c *collector) collectChassis(ctx context.Context, chassis *redfish.Chassis) {
+	c.health("chassis", chassis.ODataID, chassis.Status, "chassis", chassis.ID)
+
+	readings, err := chassis.SensorReadings(ctx)
+	c.check(err, chassis.ODataID+" sensors")
+	for i, reading := range readings {
+		if reading.Reading == nil {
+			continue
+		}
+		// Sensors may share a name and physical context, their URI tells them
+		// apart, or else their position in the readings of the chassis.
+		sensorID := reading.URI
+		if sensorID == "" {
+			sensorID = strconv.Itoa(i)
+		}
+		labels := []string{"chassis", chassis.ID, "sensor", reading.Name, "physical_context", string(reading.PhysicalContext),
+			"sensor_id", sensorID}
+		switch reading.ReadingType {
+		case redfish.TemperatureReadingType:
+			c.gauge("redfish_temperature_celsius", "Temperature reading in degrees Celsius.", *reading.Reading, labels...)
+		case redfish.RotationalReadingType:
+			c.gauge("redfish_fan_speed_rpm", "Fan speed in revolutions per minute.", *reading.Reading, labels...)
+		case redfish.VoltageReadingType:
+			c.gauge("redfish_voltage_volts", "Voltage reading in volts.", *reading.Reading, labels...)
+		case redfish.PowerReadingType:
+			c.gauge("redfish_power_watts", "Power reading in watts.", *reading.Reading, labels...)
+		}
+	}
+
+	// The power supplies come from the PowerSubsystem resource, or from the
+	// deprecated Power resource on services that do not provide it.
+	powersubsystem, err := chassis.PowerSubsystem(ctx)
+	c.check(err, chassis.ODataID+" power subsystem")
+	if powersubsystem != nil {
+		c.collectPowerSubsystem(ctx, chassis, powersubsystem)
+		return
+	}
+
+	power, err := chassis.Power(ctx)
+	c.check(err, chassis.ODataID+" power")
+	if power == nil {
+		return
+	}
+
+	c.gauge("redfish_chassis_power_consumed_watts", "Power consumed by the chassis in watts.",
+		power.ConsumedWatts(), "chassis", chassis.ID)
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		// MemberId is often left empty, the index tells the supplies apart.
+		labels := []string{"chassis", chassis.ID, "power_supply", supply.MemberID, "index", strconv.Itoa(i)}
+		c.gauge("redfish_power_supply_input_watts", "Input power of the power supply in watts.",
+			supply.PowerInputWatts, labels...)
+		c.gauge("redfish_power_supply_output_watts", "Last output power of the power supply in watts.",
+			supply.LastPowerOutputWatts, labels...)
+		c.gauge("redfish_power_supply_capacity_watts", "Power capacity of the power supply in watts.",
+			supply.PowerCapacityWatts, labels...)
+		c.health("power_supply", supply.ODataID, supply.Status, labels...)
+	}
+}
+
+// collectPowerSubsystem records the power and health of the power supplies of
+// a power subsystem. The power consumed by the chassis is the sum of the input
+// power of the supplies, or of their output power for supplies that do not
+// report their input.
+func (c *collector) collectPowerSubsystem(ctx context.Context, chassis *redfish.Chassis,
+	powersubsystem *redfish.PowerSubsystem) {
+	supplies, err := powersubsystem.PowerSupplies(ctx)
+	c.check(err, powersubsystem.ODataID+" power supplies")
+
+	var consumed float32
+	reported := false
+	for i, supply := range supplies {
+		labels := []string{"chassis", chassis.ID, "power_supply", supply.ID, "index", strconv.Itoa(i)}
+		c.gauge("redfish_power_supply_capacity_watts", "Power capacity of the power supply in watts.",
+			supply.PowerCapacityWatts, labels...)
+		c.health("power_supply", supply.ODataID, supply.Status, labels...)
+
+		metrics, err := supply.Metrics(ctx)
+		c.check(err, supply.ODataID+" metrics")
+		if metrics == nil {
+			continue
+		}
+		input, output := metrics.InputPowerWatts.Reading, metrics.OutputPowerWatts.Reading
+		if input != nil {
+			c.gauge("redfish_power_supply_input_watts", "Input power of the power supply in watts.",
+				*input, labels...)
+		}
+		if output != nil {
+			c.gauge("redfish_power_supply_output_watts", "Last output power of the power supply in watts.",
+				*output, labels...)
+		}
+		switch {
+		case input != nil:
+			consumed += *input
+			reported = true
+		case output != nil:
+			consumed += *output
+			reported = true
+		}
+	}
+
+	if reported {
+		c.gauge("redfish_chassis_power_consumed_watts", "Power consumed by the chassis in watts.",
+			consumed, "chassis", chassis.ID)
+	}
+}
+
+// collectSystem records the health of a system and of its processors, memory
+// and storage, and the life left of its drives.
+func (c *collector) collectSystem(ctx context.Context, system *redfish.ComputerSystem) {
+	c.health("system", system.ODataID, system.Status, "system", system.ID)
+
+	processors, err := system.Processors(ctx)
+	c.check(err, system.ODataID+" processors")
+	for _, processor := range processors {
+		c.health("processor", processor.ODataID, processor.Status, "system", system.ID, "processor", processor.ID)
+	}
+
+	memory, err := system.Memory(ctx)
+	c.check(err, system.ODataID+" memory")
+	for _, module := range memory {
+		c.health("memory", module.ODataID, module.Status, "system", system.ID, "memory", module.ID)
+	}
+
+	storage, err := system.Storage(ctx)
+	c.check(err, system.ODataID+" storage")
+	for _, subsystem := range storage {
+		c.health("storage", subsystem.ODataID, subsystem.Status, "system", system.ID, "storage", subsystem.ID)
+
+		drives, err := subsystem.Drives(ctx)
+		c.check(err, subsystem.ODataID+" drives")
+		for _, drive := range drives {
+			labels := []string{"system", system.ID, "storage", subsystem.ID, "drive", drive.ID}
+			c.health("drive", drive.ODataID, drive.Status, labels...)
+			// Only solid state drives wear out, other drives do not report
+			// the life left.
+			if drive.MediaType == redfish.SSDMediaType || drive.PredictedMediaLifeLeftPercent > 0 {
+				c.gauge("redfish_drive_predicted_media_life_left_percent", "Predicted life left of the drive media in percent.",
+					drive.PredictedMediaLifeLeftPercent, labels...)
+			}
+		}
+	}
+}
+
+// gauge records a sample for the target.
+func (c *collector) gauge(name, help string, value float32, labels ...string) {
+	c.set.add(name, help, float64(value), append([]string{"target", c.target}, labels...)...)
+}
+
+// health records the health of a resource as an enum, with a sample per
+// state set to 1 for the current state. Resources without health are skipped.
+func (c *collector) health(kind, uri string, status common.Status, labels ...string) {
+	if status.Health == "" {
+		return
+	}
+
+	for _, state := range healthStates {
+		value := float32(0)
+		if status.Health == state {
+			value = 1
+		}
+		stateLabels := append([]string{"type", kind, "resource", uri}, labels...)
+		c.gauge("redfish_health", "Health of the resource, 1 for the current state.", value,
+			append(stateLabels, "state", string(state))...)
+	}
+}
+
+// check logs and counts the error of a request.
+func (c *collector) check(err error, what string) {
+	if err != nil {
+		log.Printf("%s: failed to read %s: %s", c.target, what, err)
+		c.errors++
+	}
+}
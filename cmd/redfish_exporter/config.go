@@ -0,0 +1,115 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultCacheTTL is how long a scrape of a target is reused.
+	defaultCacheTTL = time.Minute
+	// defaultTimeout is how long a scrape of a target may take.
+	defaultTimeout = 30 * time.Second
+	// logoutTimeout is how long closing the session of a scrape may take.
+	logoutTimeout = 10 * time.Second
+)
+
+// duration is a time.Duration read from a string such as "30s".
+type duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var value string
+	err := json.Unmarshal(b, &value)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	*d = duration(parsed)
+	return nil
+}
+
+// targetConfig holds the connection settings of a Redfish service.
+type targetConfig struct {
+	// Name identifies the target in the metrics and the target query
+	// parameter.
+	Name string `json:"name"`
+	// Endpoint is the URL of the service, such as https://10.0.0.1.
+	Endpoint string `json:"endpoint"`
+	// Username and Password are the credentials of the service.
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// PasswordFile is a file holding the password, used instead of Password
+	// to keep the password out of the configuration.
+	PasswordFile string `json:"password_file"`
+	// Insecure disables the verification of the certificate of the service.
+	Insecure bool `json:"insecure"`
+	// BasicAuth uses HTTP basic authentication rather than a session.
+	BasicAuth bool `json:"basic_auth"`
+}
+
+// config is the configuration of the exporter.
+type config struct {
+	// CacheTTL is how long a scrape of a target is reused, to protect slow
+	// services from frequent or concurrent scrapes.
+	CacheTTL duration `json:"cache_ttl"`
+	// Timeout is how long a scrape of a target may take.
+	Timeout duration `json:"timeout"`
+	// Targets are the services to scrape.
+	Targets []targetConfig `json:"targets"`
+}
+
+// loadConfig reads the configuration file of the exporter.
+func loadConfig(path string) (*config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c config
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration %s: %s", path, err)
+	}
+
+	if c.CacheTTL == 0 {
+		c.CacheTTL = duration(defaultCacheTTL)
+	}
+	if c.Timeout == 0 {
+		c.Timeout = duration(defaultTimeout)
+	}
+
+	names := make(map[string]bool)
+	for i := range c.Targets {
+		target := &c.Targets[i]
+		if target.Name == "" || target.Endpoint == "" {
+			return nil, fmt.Errorf("target %d: name and endpoint are required", i)
+		}
+		if names[target.Name] {
+			return nil, fmt.Errorf("target %s is defined more than once", target.Name)
+		}
+		names[target.Name] = true
+
+		if target.PasswordFile != "" {
+			password, err := ioutil.ReadFile(target.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("target %s: %s", target.Name, err)
+			}
+			target.Password = strings.TrimSpace(string(password))
+		}
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,66 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestLoadConfig tests reading the configuration file.
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "redfish_exporter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	passwordFile := filepath.Join(dir, "password")
+	err = ioutil.WriteFile(passwordFile, []byte("secret\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configFile := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(configFile, []byte(`{
+		"cache_ttl": "2m",
+		"targets": [
+			{"name": "bmc1", "endpoint": "https://10.0.0.1", "username": "monitor", "password_file": "`+passwordFile+`"},
+			{"name": "bmc2", "endpoint": "https://10.0.0.2", "username": "monitor", "password": "other", "insecure": true}
+		]
+	}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := loadConfig(configFile)
+	if err != nil {
+		t.Fatalf("Error loading config: %s", err)
+	}
+
+	if time.Duration(c.CacheTTL) != 2*time.Minute || time.Duration(c.Timeout) != defaultTimeout {
+		t.Errorf("Unexpected durations: %v %v", c.CacheTTL, c.Timeout)
+	}
+
+	if len(c.Targets) != 2 || c.Targets[0].Password != "secret" || !c.Targets[1].Insecure {
+		t.Errorf("Unexpected targets: %+v", c.Targets)
+	}
+
+	err = ioutil.WriteFile(configFile, []byte(`{"targets": [
+		{"name": "bmc1", "endpoint": "https://10.0.0.1"},
+		{"name": "bmc1", "endpoint": "https://10.0.0.2"}
+	]}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = loadConfig(configFile)
+	if err == nil {
+		t.Error("Duplicate targets should be rejected")
+	}
+}
@@ -0,0 +1,143 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jacobweinstock/gophish"
+)
+
+// cachedScrape holds the last scrape of a target. Its mutex serializes the
+// scrapes of the target, so concurrent requests wait for a single scrape.
+type cachedScrape struct {
+	mutex sync.Mutex
+	set   *metricSet
+	time  time.Time
+}
+
+// exporter serves the metrics of the configured targets.
+type exporter struct {
+	config *config
+
+	mutex sync.Mutex
+	cache map[string]*cachedScrape
+}
+
+// newExporter creates an exporter for the configured targets.
+func newExporter(c *config) *exporter {
+	return &exporter{
+		config: c,
+		cache:  make(map[string]*cachedScrape),
+	}
+}
+
+// ServeHTTP writes the metrics of all the targets, or of the one named by the
+// target query parameter. The targets are scraped concurrently.
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	targets := e.config.Targets
+	if name := r.URL.Query().Get("target"); name != "" {
+		targets = nil
+		for _, target := range e.config.Targets {
+			if target.Name == name {
+				targets = []targetConfig{target}
+				break
+			}
+		}
+		if len(targets) == 0 {
+			http.Error(w, "unknown target", http.StatusNotFound)
+			return
+		}
+	}
+
+	results := make([]*metricSet, len(targets))
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = e.scrape(targets[i])
+		}(i)
+	}
+	wg.Wait()
+
+	set := newMetricSet()
+	for _, result := range results {
+		set.merge(result)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := set.write(w); err != nil {
+		log.Printf("failed to write metrics: %s", err)
+	}
+}
+
+// scrape returns the metrics of a target, reusing the last scrape if it is
+// recent enough. A scrape is not canceled when the request is, so that its
+// result can be reused by the next request.
+func (e *exporter) scrape(target targetConfig) *metricSet {
+	e.mutex.Lock()
+	entry, ok := e.cache[target.Name]
+	if !ok {
+		entry = &cachedScrape{}
+		e.cache[target.Name] = entry
+	}
+	e.mutex.Unlock()
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.set != nil && time.Since(entry.time) < time.Duration(e.config.CacheTTL) {
+		return entry.set
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(e.config.Timeout))
+	defer cancel()
+
+	entry.set = scrapeTarget(ctx, target)
+	entry.time = time.Now()
+	return entry.set
+}
+
+// scrapeTarget connects to a target and collects its metrics.
+func scrapeTarget(ctx context.Context, target targetConfig) *metricSet {
+	start := time.Now()
+	set := newMetricSet()
+
+	up := 0
+	errors := 0
+	client, err := gophish.Connect(ctx, gophish.ClientConfig{
+		Endpoint:  target.Endpoint,
+		Username:  target.Username,
+		Password:  target.Password,
+		Insecure:  target.Insecure,
+		BasicAuth: target.BasicAuth,
+	})
+	if err != nil {
+		log.Printf("%s: failed to connect: %s", target.Name, err)
+	} else {
+		up = 1
+		errors = collect(ctx, target.Name, client.Service, set)
+		// The scrape context may be expired by now, the session would be
+		// left open on the service.
+		logoutCtx, cancel := context.WithTimeout(context.Background(), logoutTimeout)
+		client.Logout(logoutCtx)
+		cancel()
+	}
+
+	set.add("redfish_up", "Whether the service could be reached.", float64(up), "target", target.Name)
+	set.add("redfish_scrape_errors", "Number of resources that could not be read during the scrape.",
+		float64(errors), "target", target.Name)
+	set.add("redfish_scrape_duration_seconds", "Duration of the scrape in seconds.",
+		time.Since(start).Seconds(), "target", target.Name)
+	set.add("redfish_scrape_timestamp_seconds", "Time of the scrape, as cached scrapes are reused.",
+		float64(start.Unix()), "target", target.Name)
+
+	return set
+}
@@ -0,0 +1,226 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// bmcResources are the resources served by the fake service.
+var bmcResources = map[string]string{
+	"/redfish/v1/": `{
+		"@odata.id": "/redfish/v1/",
+		"Chassis": {"@odata.id": "/redfish/v1/Chassis"},
+		"Systems": {"@odata.id": "/redfish/v1/Systems"}
+	}`,
+	"/redfish/v1/Chassis": `{
+		"Members": [{"@odata.id": "/redfish/v1/Chassis/1"}, {"@odata.id": "/redfish/v1/Chassis/2"}],
+		"Members@odata.count": 2
+	}`,
+	"/redfish/v1/Chassis/1": `{
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id": "1",
+		"Status": {"State": "Enabled", "Health": "Warning"},
+		"Thermal": {"@odata.id": "/redfish/v1/Chassis/1/Thermal"},
+		"Power": {"@odata.id": "/redfish/v1/Chassis/1/Power"}
+	}`,
+	"/redfish/v1/Chassis/1/Thermal": `{
+		"@odata.id": "/redfish/v1/Chassis/1/Thermal",
+		"Temperatures": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Thermal#/Temperatures/0", "MemberId": "0", "Name": "Inlet Temp",
+				"ReadingCelsius": 24, "PhysicalContext": "Intake"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Thermal#/Temperatures/1", "MemberId": "1", "Name": "Inlet Temp",
+				"ReadingCelsius": 26, "PhysicalContext": "Intake"}
+		],
+		"Fans": [{"MemberId": "0", "Name": "Fan 1", "Reading": 5400, "ReadingUnits": "RPM"}]
+	}`,
+	"/redfish/v1/Chassis/1/Power": `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerControl": [{"MemberId": "0", "Name": "System Power Control", "PowerConsumedWatts": 344}],
+		"PowerSupplies": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0",
+			"MemberId": "0",
+			"PowerInputWatts": 180,
+			"LastPowerOutputWatts": 170,
+			"PowerCapacityWatts": 800,
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}, {
+			"PowerInputWatts": 175,
+			"PowerCapacityWatts": 800
+		}, {
+			"PowerInputWatts": 0,
+			"PowerCapacityWatts": 800
+		}]
+	}`,
+	"/redfish/v1/Chassis/2": `{
+		"@odata.id": "/redfish/v1/Chassis/2",
+		"Id": "2",
+		"PowerSubsystem": {"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem"}
+	}`,
+	"/redfish/v1/Chassis/2/PowerSubsystem": `{
+		"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem",
+		"PowerSupplies": {"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies"}
+	}`,
+	"/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies": `{
+		"Members": [
+			{"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/0"},
+			{"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/1"}
+		],
+		"Members@odata.count": 2
+	}`,
+	"/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/0": `{
+		"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/0",
+		"Id": "0",
+		"Name": "PSU 0",
+		"PowerCapacityWatts": 1200,
+		"Status": {"State": "Enabled", "Health": "OK"},
+		"Metrics": {"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/0/Metrics"}
+	}`,
+	"/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/0/Metrics": `{
+		"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/0/Metrics",
+		"InputPowerWatts": {"Reading": 210},
+		"OutputPowerWatts": {"Reading": 195}
+	}`,
+	"/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/1": `{
+		"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/1",
+		"Id": "1",
+		"Name": "PSU 1",
+		"PowerCapacityWatts": 1200,
+		"Metrics": {"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/1/Metrics"}
+	}`,
+	"/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/1/Metrics": `{
+		"@odata.id": "/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/1/Metrics",
+		"OutputPowerWatts": {"Reading": 90}
+	}`,
+	"/redfish/v1/Systems": `{
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Systems/1": `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Status": {"State": "Enabled", "Health": "OK"},
+		"Storage": {"@odata.id": "/redfish/v1/Systems/1/Storage"}
+	}`,
+	"/redfish/v1/Systems/1/Storage": `{
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1/Storage/1"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Systems/1/Storage/1": `{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1",
+		"Id": "1",
+		"Drives": [{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/0"}],
+		"Drives@odata.count": 1
+	}`,
+	"/redfish/v1/Systems/1/Storage/1/Drives/0": `{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/0",
+		"Id": "0",
+		"MediaType": "SSD",
+		"PredictedMediaLifeLeftPercent": 97,
+		"Status": {"State": "Enabled", "Health": "OK"}
+	}`,
+}
+
+// TestExporter tests scraping a service, caching the scrape and reporting
+// unreachable services.
+func TestExporter(t *testing.T) {
+	var requests int32
+	bmc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		body, ok := bmcResources[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer bmc.Close()
+
+	e := newExporter(&config{
+		CacheTTL: duration(time.Minute),
+		Timeout:  duration(5 * time.Second),
+		Targets: []targetConfig{
+			{Name: "bmc1", Endpoint: bmc.URL},
+			{Name: "bmc2", Endpoint: "http://127.0.0.1:1"},
+		},
+	})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	metrics := getMetrics(t, server.URL+"/metrics?target=bmc1")
+	expected := []string{
+		`redfish_up{target="bmc1"} 1`,
+		`redfish_scrape_errors{target="bmc1"} 0`,
+		`redfish_temperature_celsius{target="bmc1",chassis="1",sensor="Inlet Temp",physical_context="Intake",` +
+			`sensor_id="/redfish/v1/Chassis/1/Thermal#/Temperatures/0"} 24`,
+		`redfish_temperature_celsius{target="bmc1",chassis="1",sensor="Inlet Temp",physical_context="Intake",` +
+			`sensor_id="/redfish/v1/Chassis/1/Thermal#/Temperatures/1"} 26`,
+		`redfish_fan_speed_rpm{target="bmc1",chassis="1",sensor="Fan 1",physical_context="",sensor_id="2"} 5400`,
+		`redfish_power_watts{target="bmc1",chassis="1",sensor="System Power Control",physical_context="",sensor_id="3"} 344`,
+		`redfish_chassis_power_consumed_watts{target="bmc1",chassis="1"} 344`,
+		`redfish_power_supply_input_watts{target="bmc1",chassis="1",power_supply="0",index="0"} 180`,
+		`redfish_power_supply_input_watts{target="bmc1",chassis="1",power_supply="",index="1"} 175`,
+		`redfish_power_supply_input_watts{target="bmc1",chassis="1",power_supply="",index="2"} 0`,
+		`redfish_power_watts{target="bmc1",chassis="2",sensor="PSU 0 Input Power",physical_context="PowerSupply",` +
+			`sensor_id="/redfish/v1/Chassis/2/PowerSubsystem/PowerSupplies/0/Metrics#/InputPowerWatts"} 210`,
+		`redfish_chassis_power_consumed_watts{target="bmc1",chassis="2"} 300`,
+		`redfish_power_supply_input_watts{target="bmc1",chassis="2",power_supply="0",index="0"} 210`,
+		`redfish_power_supply_output_watts{target="bmc1",chassis="2",power_supply="1",index="1"} 90`,
+		`redfish_power_supply_capacity_watts{target="bmc1",chassis="2",power_supply="1",index="1"} 1200`,
+		`redfish_health{target="bmc1",type="chassis",resource="/redfish/v1/Chassis/1",chassis="1",state="Warning"} 1`,
+		`redfish_health{target="bmc1",type="chassis",resource="/redfish/v1/Chassis/1",chassis="1",state="OK"} 0`,
+		`redfish_drive_predicted_media_life_left_percent{target="bmc1",system="1",storage="1",drive="0"} 97`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(metrics, line+"\n") {
+			t.Errorf("Missing metric %s in:\n%s", line, metrics)
+		}
+	}
+	if strings.Contains(metrics, `redfish_power_supply_input_watts{target="bmc1",chassis="2",power_supply="1"`) {
+		t.Errorf("Unreported input power should be left out:\n%s", metrics)
+	}
+
+	// The second scrape is served from the cache.
+	served := atomic.LoadInt32(&requests)
+	getMetrics(t, server.URL+"/metrics?target=bmc1")
+	if atomic.LoadInt32(&requests) != served {
+		t.Errorf("Cached scrape should not reach the service")
+	}
+
+	metrics = getMetrics(t, server.URL+"/metrics")
+	if !strings.Contains(metrics, `redfish_up{target="bmc2"} 0`) {
+		t.Errorf("Unreachable target should be down:\n%s", metrics)
+	}
+
+	resp, err := http.Get(server.URL + "/metrics?target=unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Unexpected status for an unknown target: %d", resp.StatusCode)
+	}
+}
+
+func getMetrics(t *testing.T, url string) string {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
@@ -0,0 +1,45 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Command redfish_exporter exposes the sensor readings, power consumption and
+// health of Redfish services as Prometheus metrics.
+//
+// The services are listed in a JSON configuration file:
+//
+//	{
+//	  "cache_ttl": "60s",
+//	  "timeout": "30s",
+//	  "targets": [{
+//	    "name": "bmc1",
+//	    "endpoint": "https://10.0.0.1",
+//	    "username": "monitor",
+//	    "password_file": "/etc/redfish_exporter/bmc1.password",
+//	    "insecure": true
+//	  }]
+//	}
+//
+// The metrics of all the targets are served on /metrics, and the metrics of
+// a single target on /metrics?target=name.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+)
+
+func main() {
+	configFile := flag.String("config", "redfish_exporter.json", "Path to the configuration file.")
+	address := flag.String("listen", ":9610", "Address to serve the metrics on.")
+	flag.Parse()
+
+	c, err := loadConfig(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	http.Handle("/metrics", newExporter(c))
+	log.Printf("serving the metrics of %d targets on %s", len(c.Targets), *address)
+	log.Fatal(http.ListenAndServe(*address, nil))
+}
@@ -0,0 +1,124 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sample is a single value of a metric.
+type sample struct {
+	// labels are the label names and values, in pairs.
+	labels []string
+	value  float64
+}
+
+// family holds the samples of a metric.
+type family struct {
+	name    string
+	help    string
+	samples []sample
+}
+
+// metricSet holds the gauges collected from one or more targets, ready to be
+// written in the Prometheus text exposition format.
+type metricSet struct {
+	families map[string]*family
+}
+
+// newMetricSet creates an empty metricSet.
+func newMetricSet() *metricSet {
+	return &metricSet{families: make(map[string]*family)}
+}
+
+// add records a sample of a gauge. Labels are provided as name and value
+// pairs.
+func (set *metricSet) add(name, help string, value float64, labels ...string) {
+	f, ok := set.families[name]
+	if !ok {
+		f = &family{name: name, help: help}
+		set.families[name] = f
+	}
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// merge adds the samples of another set.
+func (set *metricSet) merge(other *metricSet) {
+	for _, f := range other.families {
+		for _, s := range f.samples {
+			set.add(f.name, f.help, s.value, s.labels...)
+		}
+	}
+}
+
+// write writes the metrics in the Prometheus text exposition format, sorted
+// by name so the output is stable.
+func (set *metricSet) write(w io.Writer) error {
+	names := make([]string, 0, len(set.families))
+	for name := range set.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := set.families[name]
+		_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, escapeHelp(f.help), name)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range f.samples {
+			_, err = fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(s.labels), formatValue(s.value))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatLabels formats label pairs as {name="value",...}.
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], escapeLabelValue(labels[i+1])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatValue formats a sample value as expected by Prometheus.
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// escapeHelp escapes the backslashes and line feeds of a help text.
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// escapeLabelValue escapes the backslashes, double quotes and line feeds of a
+// label value.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,39 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestMetricSetWrite tests writing metrics in the text exposition format.
+func TestMetricSetWrite(t *testing.T) {
+	set := newMetricSet()
+	set.add("redfish_up", "Whether the service could be reached.", 1, "target", "bmc1")
+	set.add("redfish_temperature_celsius", "Temperature reading.", 24.5, "target", "bmc1", "sensor", `Inlet "front"`)
+
+	other := newMetricSet()
+	other.add("redfish_up", "Whether the service could be reached.", 0, "target", "bmc2")
+	set.merge(other)
+
+	var output bytes.Buffer
+	err := set.write(&output)
+	if err != nil {
+		t.Errorf("Error writing metrics: %s", err)
+	}
+
+	expected := `# HELP redfish_temperature_celsius Temperature reading.
+# TYPE redfish_temperature_celsius gauge
+redfish_temperature_celsius{target="bmc1",sensor="Inlet \"front\""} 24.5
+# HELP redfish_up Whether the service could be reached.
+# TYPE redfish_up gauge
+redfish_up{target="bmc1"} 1
+redfish_up{target="bmc2"} 0
+`
+	if output.String() != expected {
+		t.Errorf("Unexpected metrics:\n%s", output.String())
+	}
+}
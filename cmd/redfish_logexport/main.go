@@ -0,0 +1,151 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Command redfish_logexport writes the log entries of the managers and
+// systems of a Redfish service to the standard output, as JSON Lines, CSV or
+// RFC 5424 syslog records:
+//
+//	redfish_logexport -endpoint https://10.0.0.1 -username monitor \
+//		-password-file /etc/bmc.password -format syslog -since 24h
+//
+// The messages of Event entries the service does not include are resolved
+// from the message registries of the service.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jacobweinstock/gophish"
+	"github.com/jacobweinstock/gophish/logexport"
+	"github.com/jacobweinstock/gophish/redfish"
+)
+
+// options are the settings of an export.
+type options struct {
+	config   gophish.ClientConfig
+	bmc      string
+	format   logexport.Format
+	since    time.Duration
+	resolve  bool
+	language string
+}
+
+func main() {
+	var o options
+	var format, passwordFile string
+	flag.StringVar(&o.config.Endpoint, "endpoint", "", "URL of the Redfish service, such as https://10.0.0.1.")
+	flag.StringVar(&o.config.Username, "username", "", "Username of the service.")
+	flag.StringVar(&o.config.Password, "password", "", "Password of the service.")
+	flag.StringVar(&passwordFile, "password-file", "", "File holding the password of the service.")
+	flag.BoolVar(&o.config.Insecure, "insecure", false, "Do not verify the certificate of the service.")
+	flag.BoolVar(&o.config.BasicAuth, "basic-auth", false, "Use HTTP basic authentication rather than a session.")
+	flag.StringVar(&o.bmc, "bmc", "", "Identity of the service in the records, the host of the endpoint by default.")
+	flag.StringVar(&format, "format", "jsonl", "Output format: jsonl, csv or syslog.")
+	flag.DurationVar(&o.since, "since", 0, "Only export the entries created within this duration, such as 24h.")
+	flag.BoolVar(&o.resolve, "resolve", true, "Resolve the message IDs of the entries without message.")
+	flag.StringVar(&o.language, "language", "en", "Language of the resolved messages.")
+	flag.Parse()
+
+	var err error
+	o.format, err = logexport.ParseFormat(format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if passwordFile != "" {
+		password, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		o.config.Password = strings.TrimSpace(string(password))
+	}
+
+	err = run(context.Background(), o, os.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run exports the log entries of the service to w.
+func run(ctx context.Context, o options, w io.Writer) error {
+	if o.bmc == "" {
+		endpoint, err := url.Parse(o.config.Endpoint)
+		if err != nil {
+			return err
+		}
+		o.bmc = endpoint.Hostname()
+	}
+
+	client, err := gophish.Connect(ctx, o.config)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %s", err)
+	}
+	defer client.Logout(ctx)
+
+	var resolver logexport.Resolver
+	if o.resolve {
+		registries, err := client.Service.MessageRegistries(ctx, o.language)
+		if err != nil {
+			log.Printf("failed to read the message registries: %s", err)
+		}
+		resolver = registries
+	}
+
+	var logServices []*redfish.LogService
+	managers, err := client.Service.Managers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the managers: %s", err)
+	}
+	for _, manager := range managers {
+		services, err := manager.LogServices(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read the log services of %s: %s", manager.ODataID, err)
+		}
+		logServices = append(logServices, services...)
+	}
+
+	systems, err := client.Service.Systems(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the systems: %s", err)
+	}
+	for _, system := range systems {
+		services, err := system.LogServices(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read the log services of %s: %s", system.ODataID, err)
+		}
+		logServices = append(logServices, services...)
+	}
+
+	var filter redfish.LogEntryFilter
+	if o.since > 0 {
+		filter.Since = time.Now().Add(-o.since)
+	}
+
+	writer, err := logexport.NewWriter(w, o.format)
+	if err != nil {
+		return err
+	}
+	for _, logService := range logServices {
+		records, err := logexport.FromLogService(ctx, o.bmc, logService, filter, resolver)
+		if err != nil {
+			return fmt.Errorf("failed to read the entries of %s: %s", logService.ODataID, err)
+		}
+		for _, record := range records {
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,109 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish"
+	"github.com/jacobweinstock/gophish/logexport"
+)
+
+// bmcResources are the resources served by the fake service.
+var bmcResources = map[string]string{
+	"/redfish/v1/": `{
+		"@odata.id": "/redfish/v1/",
+		"Managers": {"@odata.id": "/redfish/v1/Managers"},
+		"Systems": {"@odata.id": "/redfish/v1/Systems"},
+		"Registries": {"@odata.id": "/redfish/v1/Registries"}
+	}`,
+	"/redfish/v1/Registries": `{
+		"Members": [{"@odata.id": "/redfish/v1/Registries/Base"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Registries/Base": `{
+		"@odata.id": "/redfish/v1/Registries/Base",
+		"Id": "Base",
+		"Registry": "Base.1.8",
+		"Location": [{"Language": "en", "Uri": "/redfish/v1/Registries/Base/Base.1.8.json"}]
+	}`,
+	"/redfish/v1/Registries/Base/Base.1.8.json": `{
+		"RegistryPrefix": "Base",
+		"RegistryVersion": "1.8.1",
+		"Messages": {"ServiceShuttingDown": {"Message": "The operation failed because the service is shutting down."}}
+	}`,
+	"/redfish/v1/Managers": `{
+		"Members": [{"@odata.id": "/redfish/v1/Managers/1"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Managers/1": `{
+		"@odata.id": "/redfish/v1/Managers/1",
+		"Id": "1",
+		"LogServices": {"@odata.id": "/redfish/v1/Managers/1/LogServices"}
+	}`,
+	"/redfish/v1/Managers/1/LogServices": `{
+		"Members": [{"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Managers/1/LogServices/SEL": `{
+		"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL",
+		"Id": "SEL",
+		"Entries": {"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL/Entries"}
+	}`,
+	"/redfish/v1/Managers/1/LogServices/SEL/Entries": `{
+		"Members": [{
+			"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL/Entries/1",
+			"Id": "1",
+			"Created": "2021-03-01T10:00:00Z",
+			"EntryType": "Event",
+			"Severity": "Warning",
+			"MessageId": "Base.1.8.ServiceShuttingDown"
+		}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Systems": `{
+		"Members": [],
+		"Members@odata.count": 0
+	}`,
+}
+
+// TestRun tests exporting the log entries of a service.
+func TestRun(t *testing.T) {
+	bmc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bmcResources[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer bmc.Close()
+
+	var output bytes.Buffer
+	err := run(context.Background(), options{
+		config:   gophish.ClientConfig{Endpoint: bmc.URL},
+		format:   logexport.CSVFormat,
+		resolve:  true,
+		language: "en",
+	}, &output)
+	if err != nil {
+		t.Fatalf("Error exporting: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected a header and a record:\n%s", output.String())
+	}
+	if !strings.HasPrefix(lines[1], "127.0.0.1,/redfish/v1/Managers/1/LogServices/SEL,1,") ||
+		!strings.Contains(lines[1], ",The operation failed because the service is shutting down.,") {
+		t.Errorf("Unexpected record: %s", lines[1])
+	}
+}
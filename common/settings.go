@@ -0,0 +1,227 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// settingsIgnoredProperties are the properties that identify the settings
+// resource itself rather than the settings it holds.
+var settingsIgnoredProperties = map[string]bool{
+	"Actions":     true,
+	"Description": true,
+	"Id":          true,
+	"Links":       true,
+	"Name":        true,
+}
+
+// SettingsChange describes a property whose pending value differs from its
+// current value.
+type SettingsChange struct {
+	// Property is the path of the property, with nested properties separated
+	// by a period (for example "Boot.BootSourceOverrideTarget").
+	Property string
+	// Current is the value currently in effect, or nil if the property is
+	// not present on the resource.
+	Current interface{}
+	// Pending is the value that will be applied.
+	Pending interface{}
+}
+
+// PendingSettings provides access to the future state of a resource that
+// follows the Redfish settings pattern, where changes are made to a separate
+// settings resource (advertised through @Redfish.Settings) and applied by the
+// service at a later time.
+type PendingSettings struct {
+	// resourceURI is the URI of the resource the settings apply to.
+	resourceURI string
+	// settings is the @Redfish.Settings annotation of the resource.
+	settings Settings
+	// current holds the serialized JSON of the resource's current state.
+	current []byte
+	// client is the client used to access the settings resource.
+	client Client
+}
+
+// NewPendingSettings creates a PendingSettings for the resource found at
+// resourceURI. settings is the resource's @Redfish.Settings annotation and
+// current is the raw JSON of the resource as last read from the service.
+func NewPendingSettings(c Client, resourceURI string, settings Settings, current []byte) *PendingSettings {
+	return &PendingSettings{
+		resourceURI: resourceURI,
+		settings:    settings,
+		current:     current,
+		client:      c,
+	}
+}
+
+// Supported returns whether the resource advertises a settings resource.
+func (pendingsettings *PendingSettings) Supported() bool {
+	return pendingsettings.settings.SettingsObject != ""
+}
+
+// SettingsObject returns the URI changes should be sent to. Resources that do
+// not advertise a settings resource are changed directly.
+func (pendingsettings *PendingSettings) SettingsObject() string {
+	if pendingsettings.settings.SettingsObject != "" {
+		return string(pendingsettings.settings.SettingsObject)
+	}
+	return pendingsettings.resourceURI
+}
+
+// SupportedApplyTimes returns the apply times the service supports for the
+// settings resource. An empty result means the service does not provide that
+// information.
+func (pendingsettings *PendingSettings) SupportedApplyTimes() []ApplyTime {
+	return pendingsettings.settings.SupportedApplyTimes
+}
+
+// Messages returns the messages reported by the service the last time the
+// settings were applied.
+func (pendingsettings *PendingSettings) Messages() []Message {
+	return pendingsettings.settings.Messages
+}
+
+// AppliedTime returns the time the settings were last applied to the
+// resource.
+func (pendingsettings *PendingSettings) AppliedTime() string {
+	return pendingsettings.settings.Time
+}
+
+// Pending reads the settings resource and returns its properties.
+func (pendingsettings *PendingSettings) Pending(ctx context.Context) (map[string]interface{}, error) {
+	if !pendingsettings.Supported() {
+		return nil, fmt.Errorf("resource %s does not support pending settings", pendingsettings.resourceURI)
+	}
+
+	resp, err := pendingsettings.client.Get(ctx, pendingsettings.SettingsObject())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Diff reads the settings resource and returns the properties whose pending
+// value differs from the current state of the resource, sorted by property.
+// Annotations and the properties identifying the settings resource itself
+// are ignored.
+func (pendingsettings *PendingSettings) Diff(ctx context.Context) ([]SettingsChange, error) {
+	pending, err := pendingsettings.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]interface{})
+	if len(pendingsettings.current) > 0 {
+		err = json.Unmarshal(pendingsettings.current, &current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result []SettingsChange
+	diffSettings("", current, pending, &result)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Property < result[j].Property
+	})
+
+	return result, nil
+}
+
+// diffSettings collects the pending properties that differ from the current
+// ones.
+func diffSettings(prefix string, current, pending map[string]interface{}, result *[]SettingsChange) {
+	for name, pendingValue := range pending {
+		if strings.Contains(name, "@") || (prefix == "" && settingsIgnoredProperties[name]) {
+			continue
+		}
+
+		property := name
+		if prefix != "" {
+			property = prefix + "." + name
+		}
+
+		currentValue, ok := current[name]
+		currentObject, currentIsObject := currentValue.(map[string]interface{})
+		pendingObject, pendingIsObject := pendingValue.(map[string]interface{})
+		if ok && currentIsObject && pendingIsObject {
+			diffSettings(property, currentObject, pendingObject, result)
+			continue
+		}
+
+		if !reflect.DeepEqual(currentValue, pendingValue) {
+			*result = append(*result, SettingsChange{
+				Property: property,
+				Current:  currentValue,
+				Pending:  pendingValue,
+			})
+		}
+	}
+}
+
+// Patch sends the provided properties to the settings resource. applyTime is
+// optional and, when provided, is sent as @Redfish.SettingsApplyTime to tell
+// the service when the settings should be applied.
+func (pendingsettings *PendingSettings) Patch(ctx context.Context, properties map[string]interface{}, applyTime *PreferredApplyTime) error {
+	if len(properties) == 0 {
+		return fmt.Errorf("no settings to update")
+	}
+
+	payload := make(map[string]interface{}, len(properties)+1)
+	for name, value := range properties {
+		payload[name] = value
+	}
+
+	if applyTime != nil {
+		if err := pendingsettings.validateApplyTime(applyTime.ApplyTime); err != nil {
+			return err
+		}
+
+		settingsApplyTime := map[string]interface{}{
+			"ApplyTime": applyTime.ApplyTime,
+		}
+		if applyTime.MaintenanceWindowStartTime != "" {
+			settingsApplyTime["MaintenanceWindowStartTime"] = applyTime.MaintenanceWindowStartTime
+		}
+		if applyTime.MaintenanceWindowDurationInSeconds > 0 {
+			settingsApplyTime["MaintenanceWindowDurationInSeconds"] = applyTime.MaintenanceWindowDurationInSeconds
+		}
+		payload["@Redfish.SettingsApplyTime"] = settingsApplyTime
+	}
+
+	_, err := pendingsettings.client.Patch(ctx, pendingsettings.SettingsObject(), payload)
+	return err
+}
+
+// validateApplyTime makes sure the apply time is supported by the settings
+// resource, if the service advertised the supported values.
+func (pendingsettings *PendingSettings) validateApplyTime(applyTime ApplyTime) error {
+	if len(pendingsettings.settings.SupportedApplyTimes) == 0 {
+		return nil
+	}
+
+	for _, allowed := range pendingsettings.settings.SupportedApplyTimes {
+		if applyTime == allowed {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("apply time '%s' is not supported by this resource", applyTime)
+}
@@ -0,0 +1,173 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var settingsResourceBody = `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Name": "System",
+		"AssetTag": "Asset-1",
+		"Boot": {
+			"BootSourceOverrideTarget": "Pxe",
+			"BootSourceOverrideEnabled": "Once"
+		},
+		"@Redfish.Settings": {
+			"@odata.type": "#Settings.v1_3_0.Settings",
+			"SettingsObject": {
+				"@odata.id": "/redfish/v1/Systems/1/Settings"
+			},
+			"Time": "2020-01-01T12:00:00Z",
+			"SupportedApplyTimes": [
+				"OnReset",
+				"AtMaintenanceWindowStart"
+			],
+			"Messages": [
+				{
+					"MessageId": "Base.1.0.PropertyNotWritable",
+					"RelatedProperties": ["#/AssetTag"]
+				}
+			]
+		}
+	}`
+
+var pendingSettingsBody = `{
+		"@odata.id": "/redfish/v1/Systems/1/Settings",
+		"Id": "Settings",
+		"Name": "System Pending Settings",
+		"AssetTag": "Asset-2",
+		"Boot": {
+			"BootSourceOverrideTarget": "Hdd",
+			"BootSourceOverrideEnabled": "Once"
+		},
+		"HostName": "web01"
+	}`
+
+// newTestPendingSettings creates a PendingSettings from the test resource.
+func newTestPendingSettings(t *testing.T, c Client) *PendingSettings {
+	var resource struct {
+		ODataID  string   `json:"@odata.id"`
+		Settings Settings `json:"@Redfish.Settings"`
+	}
+	err := json.Unmarshal([]byte(settingsResourceBody), &resource)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	return NewPendingSettings(c, resource.ODataID, resource.Settings, []byte(settingsResourceBody))
+}
+
+// TestPendingSettings tests the parsing of the settings annotation.
+func TestPendingSettings(t *testing.T) {
+	result := newTestPendingSettings(t, &TestClient{})
+
+	if !result.Supported() {
+		t.Error("Pending settings should be supported")
+	}
+
+	if result.SettingsObject() != "/redfish/v1/Systems/1/Settings" {
+		t.Errorf("Invalid settings object: %s", result.SettingsObject())
+	}
+
+	if len(result.SupportedApplyTimes()) != 2 {
+		t.Errorf("Invalid number of apply times: %d", len(result.SupportedApplyTimes()))
+	}
+
+	if len(result.Messages()) != 1 {
+		t.Errorf("Invalid number of messages: %d", len(result.Messages()))
+	}
+
+	if result.AppliedTime() != "2020-01-01T12:00:00Z" {
+		t.Errorf("Invalid applied time: %s", result.AppliedTime())
+	}
+}
+
+// TestPendingSettingsDiff tests the Diff call.
+func TestPendingSettingsDiff(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{
+					Status:     "200 OK",
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(pendingSettingsBody)),
+					Header:     make(http.Header),
+				},
+			},
+		},
+	}
+	result := newTestPendingSettings(t, testClient)
+
+	changes, err := result.Diff(context.Background())
+	if err != nil {
+		t.Errorf("Error making Diff call: %s", err)
+	}
+
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %d: %v", len(changes), changes)
+	}
+
+	if changes[0].Property != "AssetTag" || changes[0].Current != "Asset-1" || changes[0].Pending != "Asset-2" {
+		t.Errorf("Unexpected AssetTag change: %v", changes[0])
+	}
+
+	if changes[1].Property != "Boot.BootSourceOverrideTarget" || changes[1].Pending != "Hdd" {
+		t.Errorf("Unexpected Boot change: %v", changes[1])
+	}
+
+	if changes[2].Property != "HostName" || changes[2].Current != nil {
+		t.Errorf("Unexpected HostName change: %v", changes[2])
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Systems/1/Settings" {
+		t.Errorf("Unexpected settings URL: %s", calls[0].URL)
+	}
+}
+
+// TestPendingSettingsPatch tests the Patch call.
+func TestPendingSettingsPatch(t *testing.T) {
+	testClient := &TestClient{}
+	result := newTestPendingSettings(t, testClient)
+
+	properties := map[string]interface{}{"AssetTag": "Asset-3"}
+
+	err := result.Patch(context.Background(), properties, &PreferredApplyTime{ApplyTime: ImmediateApplyTime})
+	if err == nil {
+		t.Error("Expected error for unsupported apply time")
+	}
+
+	err = result.Patch(context.Background(), properties, &PreferredApplyTime{ApplyTime: OnResetApplyTime})
+	if err != nil {
+		t.Errorf("Error making Patch call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call, got %d", len(calls))
+	}
+
+	if calls[0].URL != "/redfish/v1/Systems/1/Settings" {
+		t.Errorf("Unexpected Patch URL: %s", calls[0].URL)
+	}
+
+	if !strings.Contains(calls[0].Payload, "@Redfish.SettingsApplyTime:map[ApplyTime:OnReset]") {
+		t.Errorf("Unexpected apply time payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "AssetTag:Asset-3") {
+		t.Errorf("Unexpected AssetTag payload: %s", calls[0].Payload)
+	}
+}
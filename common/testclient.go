@@ -32,7 +32,8 @@ type TestClient struct {
 	// http.MethodGet, http.MethodPost, http.MethodPut,
 	// http.MethodPatch and http.MethodDelete.
 	// For each key it is possible to define a list of
-	// returns (in the order they should be returned).
+	// returns (in the order they should be returned), either
+	// an *http.Response or an error.
 	CustomReturnForActions map[string][]interface{}
 }
 
@@ -115,52 +116,51 @@ func (c *TestClient) recordCall(action string, url string, payload interface{})
 	c.calls = append(c.calls, call)
 }
 
-// Get performs a GET request against the Redfish service.
-func (c *TestClient) Get(ctx context.Context, url string) (*http.Response, error) {
-	c.recordCall(http.MethodGet, url, nil)
-	customReturnForAction := c.getCustomReturnForAction(http.MethodGet)
+// customResponse returns a custom return for an action, which may be an
+// error.
+func customResponse(customReturnForAction interface{}) (*http.Response, error) {
 	if customReturnForAction == nil {
 		return nil, nil
 	}
+	if err, ok := customReturnForAction.(error); ok {
+		return nil, err
+	}
 	return customReturnForAction.(*http.Response), nil
 }
 
+// Get performs a GET request against the Redfish service.
+func (c *TestClient) Get(ctx context.Context, url string) (*http.Response, error) {
+	c.recordCall(http.MethodGet, url, nil)
+	return customResponse(c.getCustomReturnForAction(http.MethodGet))
+}
+
+// GetWithHeaders performs a GET request against the Redfish service. The
+// headers are recorded as the payload of the call.
+func (c *TestClient) GetWithHeaders(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	c.recordCall(http.MethodGet, url, headers)
+	return customResponse(c.getCustomReturnForAction(http.MethodGet))
+}
+
 // Post performs a Post request against the Redfish service.
 func (c *TestClient) Post(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
 	c.recordCall(http.MethodPost, url, payload)
-	customReturnForAction := c.getCustomReturnForAction(http.MethodPost)
-	if customReturnForAction == nil {
-		return nil, nil
-	}
-	return customReturnForAction.(*http.Response), nil
+	return customResponse(c.getCustomReturnForAction(http.MethodPost))
 }
 
 // Put performs a Put request against the Redfish service.
 func (c *TestClient) Put(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
 	c.recordCall(http.MethodPut, url, payload)
-	customReturnForAction := c.getCustomReturnForAction(http.MethodPut)
-	if customReturnForAction == nil {
-		return nil, nil
-	}
-	return customReturnForAction.(*http.Response), nil
+	return customResponse(c.getCustomReturnForAction(http.MethodPut))
 }
 
 // Patch performs a Patch request against the Redfish service.
 func (c *TestClient) Patch(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
 	c.recordCall(http.MethodPatch, url, payload)
-	customReturnForAction := c.getCustomReturnForAction(http.MethodPatch)
-	if customReturnForAction == nil {
-		return nil, nil
-	}
-	return customReturnForAction.(*http.Response), nil
+	return customResponse(c.getCustomReturnForAction(http.MethodPatch))
 }
 
 // Delete performs a Delete request against the Redfish service.
 func (c *TestClient) Delete(ctx context.Context, url string) (*http.Response, error) {
 	c.recordCall(http.MethodDelete, url, nil)
-	customReturnForAction := c.getCustomReturnForAction(http.MethodDelete)
-	if customReturnForAction == nil {
-		return nil, nil
-	}
-	return customReturnForAction.(*http.Response), nil
+	return customResponse(c.getCustomReturnForAction(http.MethodDelete))
 }
@@ -24,6 +24,13 @@ type Client interface {
 	Delete(ctx context.Context, url string) (*http.Response, error)
 }
 
+// HeaderClient is implemented by clients that are able to send additional
+// headers with a GET request, such as the Accept and Last-Event-ID headers
+// needed to consume a Server-Sent Events stream.
+type HeaderClient interface {
+	GetWithHeaders(ctx context.Context, url string, headers map[string]string) (*http.Response, error)
+}
+
 // Entity provides the common basis for all Redfish and Swordfish objects.
 type Entity struct {
 	// ODataID is the location of the resource.
@@ -137,6 +144,38 @@ func (l Links) ToStrings() []string {
 	return result
 }
 
+// ODataLink is the representation of a reference to another resource as it is
+// sent in a request payload.
+type ODataLink struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// ToODataLinks converts a list of URIs to the references expected in request
+// payloads.
+func ToODataLinks(uris []string) []ODataLink {
+	result := make([]ODataLink, 0, len(uris))
+	for _, uri := range uris {
+		result = append(result, ODataLink{ODataID: uri})
+	}
+	return result
+}
+
+// PatchArrayMember updates a member of an array property of a resource.
+// Array members are updated by position, so the members before this one are
+// sent as empty objects to leave them unchanged.
+func PatchArrayMember(ctx context.Context, c Client, uri, property string, index int, values interface{}) (*http.Response, error) {
+	members := make([]interface{}, index+1)
+	for i := range members {
+		members[i] = map[string]interface{}{}
+	}
+	members[index] = values
+
+	payload := map[string]interface{}{
+		property: members,
+	}
+	return c.Patch(ctx, uri, payload)
+}
+
 // LinksCollection contains links to other entities
 type LinksCollection struct {
 	Count   int   `json:"Members@odata.count"`
@@ -254,7 +293,10 @@ const (
 // Status describes the status and health of a resource and its children.
 type Status struct {
 	Health Health `json:"Health"`
-	State  State  `json:"State"`
+	// HealthRollup is the health of the resource and of its dependent
+	// resources.
+	HealthRollup Health `json:"HealthRollup,omitempty"`
+	State        State  `json:"State"`
 }
 
 // LocationType shall name the type of location in use.
@@ -718,12 +760,15 @@ func ConstructError(statusCode int, b []byte) error {
 		return fmt.Errorf("%d: %s", statusCode, string(b))
 	}
 	err.Error.rawData = b
+	err.Error.HTTPReturnedStatusCode = statusCode
 	return err.Error
 }
 
 // Error is redfish error response object for 400
 type Error struct {
 	rawData []byte
+	// HTTPReturnedStatusCode is the HTTP status code of the response.
+	HTTPReturnedStatusCode int `json:"-"`
 	// A string indicating a specific MessageId from the message registry.
 	Code string `json:"code"`
 	// A human readable error message corresponding to the message in the message registry.
@@ -0,0 +1,249 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package eventlistener provides an HTTPS endpoint receiving the events a
+// Redfish service pushes to its event destinations.
+package eventlistener
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/jacobweinstock/gophish/redfish"
+)
+
+// maxEventSize is the largest event payload accepted by the listener.
+const maxEventSize = 1024 * 1024
+
+// Handler is called for every event received by the listener.
+type Handler func(ctx context.Context, event *redfish.Event)
+
+// Config holds the settings of a Listener.
+type Config struct {
+	// Address is the TCP address to listen on, such as ":8443".
+	Address string
+	// CertFile and KeyFile are the certificate and matching key used to
+	// serve HTTPS. They are not needed if TLSConfig provides a certificate.
+	CertFile string
+	KeyFile  string
+	// TLSConfig is the optional TLS configuration of the server.
+	TLSConfig *tls.Config
+	// Destination is the URL the Redfish service sends the events to. It
+	// must reach this listener from the service.
+	Destination string
+	// Context is the client supplied context of the subscription. Events
+	// with a different context are rejected.
+	Context string
+	// Headers are the HTTP headers the service sends with every event, such
+	// as a shared secret. Events without these headers are rejected.
+	Headers map[string]string
+	// EventTypes are the types of events to subscribe to. All the event
+	// types are subscribed to if none are provided.
+	EventTypes []redfish.EventType
+}
+
+// Listener receives the events sent by a Redfish service and dispatches them
+// to the registered handlers. It can be used on its own as an http.Handler, or
+// started to serve HTTPS and manage its own event subscription.
+type Listener struct {
+	config Config
+
+	mutex    sync.RWMutex
+	handlers []Handler
+
+	server       *http.Server
+	serverError  chan error
+	address      net.Addr
+	eventService *redfish.EventService
+	subscription string
+}
+
+// New creates a Listener with the provided configuration.
+func New(config Config) (*Listener, error) {
+	if config.Context == "" {
+		return nil, fmt.Errorf("a subscription context is required")
+	}
+
+	return &Listener{config: config}, nil
+}
+
+// Handle registers a handler to be called for every event received.
+func (listener *Listener) Handle(handler Handler) {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+
+	listener.handlers = append(listener.handlers, handler)
+}
+
+// ServeHTTP validates and decodes an event sent by the service and
+// dispatches it to the registered handlers. Handlers are called before the
+// response is sent, so they should not block for long or the service may
+// consider the delivery failed and retry it.
+func (listener *Listener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	for name, value := range listener.config.Headers {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(name)), []byte(value)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var event redfish.Event
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEventSize)).Decode(&event)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid event: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if event.Context != listener.config.Context {
+		http.Error(w, "unknown subscription context", http.StatusBadRequest)
+		return
+	}
+
+	listener.mutex.RLock()
+	handlers := listener.handlers
+	listener.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(r.Context(), &event)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Start serves HTTPS on the configured address and, if eventService is not
+// nil, subscribes the configured destination to its events. The
+// subscription is removed when the listener is stopped.
+func (listener *Listener) Start(ctx context.Context, eventService *redfish.EventService) error {
+	if listener.server != nil {
+		return fmt.Errorf("the listener is already started")
+	}
+
+	tlsConfig := &tls.Config{}
+	if listener.config.TLSConfig != nil {
+		tlsConfig = listener.config.TLSConfig.Clone()
+	}
+	if listener.config.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(listener.config.CertFile, listener.config.KeyFile)
+		if err != nil {
+			return err
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, certificate)
+	}
+	if len(tlsConfig.Certificates) == 0 && tlsConfig.GetCertificate == nil {
+		return fmt.Errorf("a certificate is required to serve HTTPS")
+	}
+
+	ln, err := net.Listen("tcp", listener.config.Address)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:   listener,
+		TLSConfig: tlsConfig,
+	}
+	serverError := make(chan error, 1)
+	go func() {
+		serverError <- server.ServeTLS(ln, "", "")
+	}()
+
+	listener.server = server
+	listener.serverError = serverError
+	listener.address = ln.Addr()
+
+	if eventService == nil {
+		return nil
+	}
+
+	eventTypes := listener.config.EventTypes
+	if len(eventTypes) == 0 {
+		eventTypes = eventService.EventTypesForSubscription
+	}
+
+	subscription, err := eventService.CreateEventSubscription(
+		ctx,
+		listener.config.Destination,
+		eventTypes,
+		listener.config.Headers,
+		redfish.RedfishEventDestinationProtocol,
+		listener.config.Context,
+		nil,
+	)
+	if err != nil {
+		listener.shutdown(ctx)
+		return err
+	}
+
+	listener.eventService = eventService
+	listener.subscription = subscription
+
+	return nil
+}
+
+// Stop removes the subscription created when the listener was started and
+// stops serving HTTPS, waiting for the events being handled.
+func (listener *Listener) Stop(ctx context.Context) error {
+	if listener.server == nil {
+		return fmt.Errorf("the listener is not started")
+	}
+
+	var errs []string
+	if listener.subscription != "" {
+		err := listener.eventService.DeleteEventSubscription(ctx, listener.subscription)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		listener.eventService = nil
+		listener.subscription = ""
+	}
+
+	if err := listener.shutdown(ctx); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to stop the listener: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// Address returns the address the listener is serving on, or nil if it is not
+// started.
+func (listener *Listener) Address() net.Addr {
+	return listener.address
+}
+
+// Subscription returns the URI of the subscription created when the listener
+// was started, if any.
+func (listener *Listener) Subscription() string {
+	return listener.subscription
+}
+
+// shutdown stops the HTTPS server.
+func (listener *Listener) shutdown(ctx context.Context) error {
+	err := listener.server.Shutdown(ctx)
+	if serveErr := <-listener.serverError; serveErr != nil && serveErr != http.ErrServerClosed && err == nil {
+		err = serveErr
+	}
+
+	listener.server = nil
+	listener.serverError = nil
+	listener.address = nil
+
+	return err
+}
@@ -0,0 +1,202 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+	"github.com/jacobweinstock/gophish/redfish"
+)
+
+var eventBody = `{
+		"@odata.type": "#Event.v1_3_0.Event",
+		"Id": "1",
+		"Name": "Event Array",
+		"Context": "MyContext",
+		"Events": [
+			{
+				"EventType": "Alert",
+				"EventId": "4593",
+				"EventTimestamp": "2019-08-22T10:35:16-06:00",
+				"Severity": "Warning",
+				"Message": "The LAN has been disconnected",
+				"MessageId": "Alert.1.0.LanDisconnect",
+				"OriginOfCondition": {
+					"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"
+				}
+			}
+		]
+	}`
+
+var eventServiceBody = `{
+		"@odata.id": "/redfish/v1/EventService",
+		"Id": "EventService",
+		"Name": "Event Service",
+		"EventTypesForSubscription": ["Alert", "StatusChange"],
+		"Subscriptions": {
+			"@odata.id": "/redfish/v1/EventService/Subscriptions"
+		}
+	}`
+
+func newTestListener(t *testing.T) *Listener {
+	listener, err := New(Config{
+		Address:     "127.0.0.1:0",
+		Destination: "https://listener.example.com/events",
+		Context:     "MyContext",
+		Headers:     map[string]string{"X-Secret": "s3cr3t"},
+	})
+	if err != nil {
+		t.Fatalf("Error creating listener: %s", err)
+	}
+	return listener
+}
+
+// TestListenerServeHTTP tests validating and dispatching received events.
+func TestListenerServeHTTP(t *testing.T) {
+	listener := newTestListener(t)
+
+	var received []*redfish.Event
+	listener.Handle(func(ctx context.Context, event *redfish.Event) {
+		received = append(received, event)
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		secret string
+		body   string
+		status int
+	}{
+		{"valid", http.MethodPost, "s3cr3t", eventBody, http.StatusNoContent},
+		{"method", http.MethodGet, "s3cr3t", "", http.StatusMethodNotAllowed},
+		{"secret", http.MethodPost, "wrong", eventBody, http.StatusUnauthorized},
+		{"payload", http.MethodPost, "s3cr3t", "{", http.StatusBadRequest},
+		{"context", http.MethodPost, "s3cr3t", strings.Replace(eventBody, "MyContext", "Other", 1), http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/events", strings.NewReader(test.body))
+		req.Header.Set("X-Secret", test.secret)
+		w := httptest.NewRecorder()
+		listener.ServeHTTP(w, req)
+
+		if w.Code != test.status {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.status, w.Code)
+		}
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 event to be dispatched, got %d", len(received))
+	}
+
+	record := received[0].Events[0]
+	if record.MessageID != "Alert.1.0.LanDisconnect" {
+		t.Errorf("Invalid MessageID: %s", record.MessageID)
+	}
+
+	if record.OriginOfCondition != "/redfish/v1/Systems/1/EthernetInterfaces/1" {
+		t.Errorf("Invalid OriginOfCondition: %s", record.OriginOfCondition)
+	}
+}
+
+// TestListenerStartStop tests serving HTTPS and managing the subscription.
+func TestListenerStartStop(t *testing.T) {
+	// Borrow the certificate of a test server to serve HTTPS.
+	certificateServer := httptest.NewTLSServer(http.NotFoundHandler())
+	certificateServer.Close()
+
+	listener := newTestListener(t)
+	listener.config.TLSConfig = certificateServer.TLS
+
+	dispatched := make(chan *redfish.Event, 1)
+	listener.Handle(func(ctx context.Context, event *redfish.Event) {
+		dispatched <- event
+	})
+
+	var eventService redfish.EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&eventService)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header: http.Header{
+						"Location": []string{"/redfish/v1/EventService/Subscriptions/1"},
+					},
+				},
+			},
+		},
+	}
+	eventService.SetClient(testClient)
+
+	err = listener.Start(context.Background(), &eventService)
+	if err != nil {
+		t.Fatalf("Error starting listener: %s", err)
+	}
+
+	if listener.Subscription() != "/redfish/v1/EventService/Subscriptions/1" {
+		t.Errorf("Invalid subscription: %s", listener.Subscription())
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	req, _ := http.NewRequest(http.MethodPost, "https://"+listener.Address().String()+"/events", strings.NewReader(eventBody))
+	req.Header.Set("X-Secret", "s3cr3t")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Error sending event: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	event := <-dispatched
+	if event.ID != "1" {
+		t.Errorf("Invalid event ID: %s", event.ID)
+	}
+
+	err = listener.Stop(context.Background())
+	if err != nil {
+		t.Errorf("Error stopping listener: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got %d", len(calls))
+	}
+
+	if !strings.Contains(calls[0].Payload, "Context:MyContext") ||
+		!strings.Contains(calls[0].Payload, "EventTypes:[Alert StatusChange]") ||
+		!strings.Contains(calls[0].Payload, "HttpHeaders:map[X-Secret:s3cr3t]") {
+		t.Errorf("Unexpected subscription payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Action != http.MethodDelete || calls[1].URL != "/redfish/v1/EventService/Subscriptions/1" {
+		t.Errorf("Unexpected cleanup call: %s %s", calls[1].Action, calls[1].URL)
+	}
+
+	if listener.Address() != nil {
+		t.Error("Address should be cleared once stopped")
+	}
+}
@@ -0,0 +1,172 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package health builds the health tree of a computer system or chassis and
+// its components, to explain which components cause a degraded health
+// rollup.
+package health
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// severity orders the health values from the least to the most severe. An
+// unknown or missing health is considered OK.
+func severity(health common.Health) int {
+	switch health {
+	case common.WarningHealth:
+		return 1
+	case common.CriticalHealth:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// worst returns the most severe of two health values.
+func worst(a, b common.Health) common.Health {
+	if severity(b) > severity(a) {
+		return b
+	}
+	return a
+}
+
+// Node is a resource of the health tree.
+type Node struct {
+	// Kind is the type of the resource, such as Processor or Drive.
+	Kind string
+	// Name is the name of the resource, or its ID if it has no name.
+	Name string
+	// URI is the @odata.id of the resource.
+	URI string
+	// Status is the status reported by the resource.
+	Status common.Status
+	// Err is the error reading the components of the resource, if any.
+	Err error
+	// Children are the components of the resource.
+	Children []*Node
+}
+
+// Health returns the most severe health of the node and its descendants.
+func (node *Node) Health() common.Health {
+	health := node.Status.Health
+	if health == "" {
+		health = common.OKHealth
+	}
+	for _, child := range node.Children {
+		health = worst(health, child.Health())
+	}
+	return health
+}
+
+// Degraded returns a copy of the tree holding only the nodes whose health or
+// health rollup is Warning or Critical, along with their ancestors. It
+// returns nil if the whole tree is healthy.
+func (node *Node) Degraded() *Node {
+	var children []*Node
+	for _, child := range node.Children {
+		if degraded := child.Degraded(); degraded != nil {
+			children = append(children, degraded)
+		}
+	}
+
+	if len(children) == 0 && severity(node.Status.Health) == 0 && severity(node.Status.HealthRollup) == 0 {
+		return nil
+	}
+
+	degraded := *node
+	degraded.Children = children
+	return &degraded
+}
+
+// Cause is a degraded component explaining the health of the tree.
+type Cause struct {
+	// Path holds the nodes from the root of the tree to the component.
+	Path []*Node
+	// Health is the health of the component.
+	Health common.Health
+	// Rollup is true if the component only reports a degraded health
+	// rollup: one of its own components is degraded but could not be
+	// inspected.
+	Rollup bool
+}
+
+// Component returns the degraded component.
+func (cause *Cause) Component() *Node {
+	return cause.Path[len(cause.Path)-1]
+}
+
+// String describes the cause, such as
+// "Critical: ComputerSystem System 1 (/redfish/v1/Systems/1) > Drive Disk 0 (/redfish/v1/...)".
+func (cause *Cause) String() string {
+	parts := make([]string, 0, len(cause.Path))
+	for _, node := range cause.Path {
+		parts = append(parts, fmt.Sprintf("%s %s (%s)", node.Kind, node.Name, node.URI))
+	}
+
+	description := fmt.Sprintf("%s: %s", cause.Health, strings.Join(parts, " > "))
+	if cause.Rollup {
+		description += " reports a degraded component that could not be inspected"
+	}
+	return description
+}
+
+// Causes returns the components explaining the Warning or Critical health of
+// the tree. A degraded component is a cause unless a descendant is at least
+// as degraded, in which case the descendant explains it. A component whose
+// health rollup is degraded without any degraded descendant is reported as
+// well, as the service knows of a problem the tree does not show.
+func (node *Node) Causes() []Cause {
+	var causes []Cause
+	node.causes(nil, &causes)
+	return causes
+}
+
+// causes adds the causes found in the subtree of the node, and returns the
+// most severe health they explain: the health and health rollup of the node
+// and of its descendants. An ancestor whose rollup is no more severe is
+// explained by them and is not reported again.
+func (node *Node) causes(path []*Node, causes *[]Cause) common.Health {
+	path = append(path[:len(path):len(path)], node)
+
+	descendants := common.OKHealth
+	for _, child := range node.Children {
+		descendants = worst(descendants, child.causes(path, causes))
+	}
+
+	if severity(node.Status.Health) > severity(descendants) {
+		*causes = append(*causes, Cause{Path: path, Health: node.Status.Health})
+	} else if severity(node.Status.HealthRollup) > severity(worst(descendants, node.Status.Health)) {
+		*causes = append(*causes, Cause{Path: path, Health: node.Status.HealthRollup, Rollup: true})
+	}
+
+	return worst(descendants, worst(node.Status.Health, node.Status.HealthRollup))
+}
+
+// String renders the tree, one node per line indented by depth, with the
+// health of the node and its descendants.
+func (node *Node) String() string {
+	var builder strings.Builder
+	node.format(&builder, 0)
+	return builder.String()
+}
+
+// format writes the subtree of the node.
+func (node *Node) format(builder *strings.Builder, depth int) {
+	fmt.Fprintf(builder, "%s%s %s %s (%s)", strings.Repeat("  ", depth), node.Health(), node.Kind, node.Name, node.URI)
+	if node.Status.State != "" {
+		fmt.Fprintf(builder, " [%s]", node.Status.State)
+	}
+	if node.Err != nil {
+		fmt.Fprintf(builder, " error: %s", node.Err)
+	}
+	builder.WriteString("\n")
+
+	for _, child := range node.Children {
+		child.format(builder, depth+1)
+	}
+}
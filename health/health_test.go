@@ -0,0 +1,121 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package health
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+func testTree() *Node {
+	return &Node{
+		Kind:   "ComputerSystem",
+		Name:   "System 1",
+		URI:    "/redfish/v1/Systems/1",
+		Status: common.Status{Health: common.OKHealth, HealthRollup: common.CriticalHealth},
+		Children: []*Node{
+			{
+				Kind:   "Processor",
+				Name:   "CPU 1",
+				URI:    "/redfish/v1/Systems/1/Processors/1",
+				Status: common.Status{Health: common.OKHealth},
+			},
+			{
+				Kind:   "Storage",
+				Name:   "RAID",
+				URI:    "/redfish/v1/Systems/1/Storage/1",
+				Status: common.Status{Health: common.CriticalHealth, HealthRollup: common.CriticalHealth},
+				Children: []*Node{
+					{
+						Kind:   "Drive",
+						Name:   "Disk 0",
+						URI:    "/redfish/v1/Systems/1/Storage/1/Drives/0",
+						Status: common.Status{Health: common.CriticalHealth, State: common.EnabledState},
+					},
+					{
+						Kind:   "Drive",
+						Name:   "Disk 1",
+						URI:    "/redfish/v1/Systems/1/Storage/1/Drives/1",
+						Status: common.Status{Health: common.OKHealth},
+					},
+				},
+			},
+			{
+				Kind:   "Memory",
+				Name:   "DIMM A1",
+				URI:    "/redfish/v1/Systems/1/Memory/A1",
+				Status: common.Status{Health: common.WarningHealth},
+			},
+		},
+	}
+}
+
+// TestNodeCauses tests explaining the health of a tree.
+func TestNodeCauses(t *testing.T) {
+	tree := testTree()
+
+	if tree.Health() != common.CriticalHealth {
+		t.Errorf("Unexpected tree health: %s", tree.Health())
+	}
+
+	causes := tree.Causes()
+	if len(causes) != 2 {
+		t.Fatalf("Unexpected causes: %v", causes)
+	}
+
+	// The storage is critical because of its drive.
+	if causes[0].Component().URI != "/redfish/v1/Systems/1/Storage/1/Drives/0" || causes[0].Health != common.CriticalHealth {
+		t.Errorf("Unexpected first cause: %s", causes[0].String())
+	}
+
+	expected := "Critical: ComputerSystem System 1 (/redfish/v1/Systems/1) > Storage RAID (/redfish/v1/Systems/1/Storage/1) > " +
+		"Drive Disk 0 (/redfish/v1/Systems/1/Storage/1/Drives/0)"
+	if causes[0].String() != expected {
+		t.Errorf("Unexpected cause description: %s", causes[0].String())
+	}
+
+	if causes[1].Component().Name != "DIMM A1" || causes[1].Health != common.WarningHealth {
+		t.Errorf("Unexpected second cause: %s", causes[1].String())
+	}
+
+	// A rollup that the tree does not explain is reported.
+	tree.Children = tree.Children[:1]
+	causes = tree.Causes()
+	if len(causes) != 1 || !causes[0].Rollup || causes[0].Component().Kind != "ComputerSystem" {
+		t.Errorf("Unexpected rollup causes: %v", causes)
+	}
+
+	// A rollup is reported by the deepest component, not again by its
+	// ancestors.
+	tree.Children[0].Status.HealthRollup = common.CriticalHealth
+	causes = tree.Causes()
+	if len(causes) != 1 || !causes[0].Rollup || causes[0].Component().Kind != "Processor" {
+		t.Errorf("Unexpected nested rollup causes: %v", causes)
+	}
+}
+
+// TestNodeDegraded tests pruning the healthy nodes of a tree.
+func TestNodeDegraded(t *testing.T) {
+	degraded := testTree().Degraded()
+	if degraded == nil || len(degraded.Children) != 2 {
+		t.Fatalf("Unexpected degraded tree: %v", degraded)
+	}
+
+	if len(degraded.Children[0].Children) != 1 || degraded.Children[0].Children[0].Name != "Disk 0" {
+		t.Errorf("Unexpected degraded storage: %v", degraded.Children[0])
+	}
+
+	output := degraded.String()
+	if !strings.Contains(output, "\n    Critical Drive Disk 0 (/redfish/v1/Systems/1/Storage/1/Drives/0) [Enabled]\n") {
+		t.Errorf("Unexpected tree output:\n%s", output)
+	}
+
+	healthy := &Node{Kind: "Chassis", Status: common.Status{Health: common.OKHealth}}
+	if healthy.Degraded() != nil {
+		t.Error("Healthy tree should have no degraded nodes")
+	}
+}
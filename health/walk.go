@@ -0,0 +1,183 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jacobweinstock/gophish/common"
+	"github.com/jacobweinstock/gophish/redfish"
+)
+
+// walker builds a health tree, recording the components it could not read.
+type walker struct {
+	failures []string
+}
+
+// node creates a node for a resource.
+func node(kind string, entity *common.Entity, status common.Status) *Node {
+	name := entity.Name
+	if name == "" {
+		name = entity.ID
+	}
+	return &Node{Kind: kind, Name: name, URI: entity.ODataID, Status: status}
+}
+
+// check records the error reading the components of a node.
+func (w *walker) check(parent *Node, what string, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	err = fmt.Errorf("failed to read %s: %s", what, err)
+	if parent.Err == nil {
+		parent.Err = err
+	} else {
+		parent.Err = fmt.Errorf("%s; %s", parent.Err, err)
+	}
+	w.failures = append(w.failures, fmt.Sprintf("%s of %s", what, parent.URI))
+	return false
+}
+
+// err returns an error listing the components that could not be read.
+func (w *walker) err() error {
+	if len(w.failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to read the %s", strings.Join(w.failures, ", "))
+}
+
+// System builds the health tree of a computer system: its processors,
+// memory, storage controllers and drives, and network interfaces. Components
+// that cannot be read are recorded on their parent node and reported in the
+// error, the tree holding everything else is still returned.
+func System(ctx context.Context, system *redfish.ComputerSystem) (*Node, error) {
+	w := &walker{}
+	root := node("ComputerSystem", &system.Entity, system.Status)
+
+	processors, err := system.Processors(ctx)
+	if w.check(root, "processors", err) {
+		for _, processor := range processors {
+			root.Children = append(root.Children, node("Processor", &processor.Entity, processor.Status))
+		}
+	}
+
+	memory, err := system.Memory(ctx)
+	if w.check(root, "memory", err) {
+		for _, module := range memory {
+			root.Children = append(root.Children, node("Memory", &module.Entity, module.Status))
+		}
+	}
+
+	storage, err := system.Storage(ctx)
+	if w.check(root, "storage", err) {
+		for _, subsystem := range storage {
+			storageNode := node("Storage", &subsystem.Entity, subsystem.Status)
+			controllers, err := subsystem.Controllers(ctx)
+			if w.check(storageNode, "storage controllers", err) {
+				for _, controller := range controllers {
+					storageNode.Children = append(storageNode.Children, node("StorageController", &controller.Entity, controller.Status))
+				}
+			}
+
+			drives, err := subsystem.Drives(ctx)
+			if w.check(storageNode, "drives", err) {
+				for _, drive := range drives {
+					storageNode.Children = append(storageNode.Children, node("Drive", &drive.Entity, drive.Status))
+				}
+			}
+			root.Children = append(root.Children, storageNode)
+		}
+	}
+
+	interfaces, err := system.EthernetInterfaces(ctx)
+	if w.check(root, "ethernet interfaces", err) {
+		for _, iface := range interfaces {
+			root.Children = append(root.Children, node("EthernetInterface", &iface.Entity, iface.Status))
+		}
+	}
+
+	return root, w.err()
+}
+
+// Chassis builds the health tree of a chassis: its fans, temperature sensors,
+// power supplies and network adapters. They are read from the Thermal and
+// Power resources, or from the ThermalSubsystem and PowerSubsystem resources
+// on services that no longer provide them. Components that cannot be read are
+// recorded on their parent node and reported in the error, the tree holding
+// everything else is still returned.
+func Chassis(ctx context.Context, chassis *redfish.Chassis) (*Node, error) {
+	w := &walker{}
+	root := node("Chassis", &chassis.Entity, chassis.Status)
+
+	thermal, err := chassis.Thermal(ctx)
+	if w.check(root, "thermal", err) && thermal != nil {
+		for i := range thermal.Fans {
+			fan := &thermal.Fans[i]
+			root.Children = append(root.Children, node("Fan", &fan.Entity, fan.Status))
+		}
+		for i := range thermal.Temperatures {
+			temperature := &thermal.Temperatures[i]
+			root.Children = append(root.Children, node("Temperature", &temperature.Entity, temperature.Status))
+		}
+	} else if thermal == nil && err == nil {
+		w.thermalSubsystem(ctx, chassis, root)
+	}
+
+	power, err := chassis.Power(ctx)
+	if w.check(root, "power", err) && power != nil {
+		for i := range power.PowerSupplies {
+			supply := &power.PowerSupplies[i]
+			root.Children = append(root.Children, node("PowerSupply", &supply.Entity, supply.Status))
+		}
+	} else if power == nil && err == nil {
+		w.powerSubsystem(ctx, chassis, root)
+	}
+
+	adapters, err := chassis.NetworkAdapters(ctx)
+	if w.check(root, "network adapters", err) {
+		for _, adapter := range adapters {
+			root.Children = append(root.Children, node("NetworkAdapter", &adapter.Entity, adapter.Status))
+		}
+	}
+
+	return root, w.err()
+}
+
+// thermalSubsystem adds the fans of the thermal subsystem of a chassis.
+func (w *walker) thermalSubsystem(ctx context.Context, chassis *redfish.Chassis, root *Node) {
+	subsystem, err := chassis.ThermalSubsystem(ctx)
+	if !w.check(root, "thermal subsystem", err) || subsystem == nil {
+		return
+	}
+
+	subsystemNode := node("ThermalSubsystem", &subsystem.Entity, subsystem.Status)
+	fans, err := subsystem.Fans(ctx)
+	if w.check(subsystemNode, "fans", err) {
+		for _, fan := range fans {
+			subsystemNode.Children = append(subsystemNode.Children, node("Fan", &fan.Entity, fan.Status))
+		}
+	}
+	root.Children = append(root.Children, subsystemNode)
+}
+
+// powerSubsystem adds the power supplies of the power subsystem of a chassis.
+func (w *walker) powerSubsystem(ctx context.Context, chassis *redfish.Chassis, root *Node) {
+	subsystem, err := chassis.PowerSubsystem(ctx)
+	if !w.check(root, "power subsystem", err) || subsystem == nil {
+		return
+	}
+
+	subsystemNode := node("PowerSubsystem", &subsystem.Entity, subsystem.Status)
+	supplies, err := subsystem.PowerSupplies(ctx)
+	if w.check(subsystemNode, "power supplies", err) {
+		for _, supply := range supplies {
+			subsystemNode.Children = append(subsystemNode.Children, node("PowerSupply", &supply.Entity, supply.Status))
+		}
+	}
+	root.Children = append(root.Children, subsystemNode)
+}
@@ -0,0 +1,192 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish"
+	"github.com/jacobweinstock/gophish/common"
+	"github.com/jacobweinstock/gophish/redfish"
+)
+
+// testResources are the resources served by the fake service.
+var testResources = map[string]string{
+	"/redfish/v1/": `{"@odata.id": "/redfish/v1/"}`,
+	"/redfish/v1/Systems/1": `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Name": "System 1",
+		"Status": {"State": "Enabled", "Health": "OK", "HealthRollup": "Critical"},
+		"Processors": {"@odata.id": "/redfish/v1/Systems/1/Processors"},
+		"Storage": {"@odata.id": "/redfish/v1/Systems/1/Storage"}
+	}`,
+	"/redfish/v1/Systems/1/Processors": `{
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1/Processors/1"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Systems/1/Processors/1": `{
+		"@odata.id": "/redfish/v1/Systems/1/Processors/1",
+		"Id": "1",
+		"Status": {"State": "Enabled", "Health": "OK"}
+	}`,
+	"/redfish/v1/Systems/1/Storage": `{
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1/Storage/1"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Systems/1/Storage/1": `{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1",
+		"Id": "1",
+		"Name": "RAID",
+		"Status": {"State": "Enabled", "Health": "OK", "HealthRollup": "Critical"},
+		"Controllers": {"@odata.id": "/redfish/v1/Systems/1/Storage/1/Controllers"},
+		"Drives": [{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/0"}],
+		"Drives@odata.count": 1
+	}`,
+	"/redfish/v1/Systems/1/Storage/1/Controllers": `{
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Controllers/0"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Systems/1/Storage/1/Controllers/0": `{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1/Controllers/0",
+		"Id": "0",
+		"Name": "Controller",
+		"Status": {"State": "Enabled", "Health": "OK"}
+	}`,
+	"/redfish/v1/Systems/1/Storage/1/Drives/0": `{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/0",
+		"Id": "0",
+		"Name": "Disk 0",
+		"Status": {"State": "Enabled", "Health": "Critical"}
+	}`,
+	"/redfish/v1/Chassis/1": `{
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id": "1",
+		"Status": {"State": "Enabled", "Health": "Warning"},
+		"ThermalSubsystem": {"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem"},
+		"Power": {"@odata.id": "/redfish/v1/Chassis/1/Power"}
+	}`,
+	"/redfish/v1/Chassis/1/ThermalSubsystem": `{
+		"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem",
+		"Id": "ThermalSubsystem",
+		"Status": {"State": "Enabled", "Health": "Warning"},
+		"Fans": {"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans"}
+	}`,
+	"/redfish/v1/Chassis/1/ThermalSubsystem/Fans": `{
+		"Members": [{"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans/1"}],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/Chassis/1/ThermalSubsystem/Fans/1": `{
+		"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans/1",
+		"Id": "1",
+		"Name": "Fan 1",
+		"Status": {"State": "Enabled", "Health": "Warning"}
+	}`,
+	"/redfish/v1/Chassis/1/Power": `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0",
+			"MemberId": "0",
+			"Name": "PSU 1",
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}]
+	}`,
+}
+
+func testClient(t *testing.T) (*gophish.APIClient, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := testResources[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+
+	client, err := gophish.ConnectDefault(context.Background(), server.URL)
+	if err != nil {
+		server.Close()
+		t.Fatalf("Error connecting: %s", err)
+	}
+	return client, server.Close
+}
+
+// TestSystem tests building the health tree of a computer system.
+func TestSystem(t *testing.T) {
+	client, stop := testClient(t)
+	defer stop()
+
+	system, err := redfish.GetComputerSystem(context.Background(), client, "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+
+	tree, err := System(context.Background(), system)
+	if err != nil {
+		t.Errorf("Error building health tree: %s", err)
+	}
+
+	if tree.Health() != common.CriticalHealth || len(tree.Children) != 2 {
+		t.Errorf("Unexpected health tree:\n%s", tree)
+	}
+
+	causes := tree.Causes()
+	if len(causes) != 1 || causes[0].Component().URI != "/redfish/v1/Systems/1/Storage/1/Drives/0" {
+		t.Errorf("Unexpected causes: %v", causes)
+	}
+
+	if !strings.Contains(tree.String(), "  Critical Storage RAID (/redfish/v1/Systems/1/Storage/1) [Enabled]\n") {
+		t.Errorf("Unexpected tree output:\n%s", tree)
+	}
+
+	if !strings.Contains(tree.String(), "    OK StorageController Controller (/redfish/v1/Systems/1/Storage/1/Controllers/0) [Enabled]\n") {
+		t.Errorf("Storage controllers missing from the tree:\n%s", tree)
+	}
+}
+
+// TestChassis tests building the health tree of a chassis from the thermal
+// subsystem and the power resource, with a component that cannot be read.
+func TestChassis(t *testing.T) {
+	client, stop := testClient(t)
+	defer stop()
+
+	chassis, err := redfish.GetChassis(context.Background(), client, "/redfish/v1/Chassis/1")
+	if err != nil {
+		t.Fatalf("Error getting chassis: %s", err)
+	}
+
+	tree, err := Chassis(context.Background(), chassis)
+	if err != nil {
+		t.Errorf("Error building health tree: %s", err)
+	}
+
+	if len(tree.Children) != 2 || tree.Children[0].Kind != "ThermalSubsystem" || tree.Children[1].Kind != "PowerSupply" {
+		t.Errorf("Unexpected health tree:\n%s", tree)
+	}
+
+	causes := tree.Causes()
+	if len(causes) != 1 || causes[0].Component().Name != "Fan 1" || causes[0].Health != common.WarningHealth {
+		t.Errorf("Unexpected causes: %v", causes)
+	}
+
+	fans := testResources["/redfish/v1/Chassis/1/ThermalSubsystem/Fans"]
+	delete(testResources, "/redfish/v1/Chassis/1/ThermalSubsystem/Fans")
+	defer func() {
+		testResources["/redfish/v1/Chassis/1/ThermalSubsystem/Fans"] = fans
+	}()
+
+	tree, err = Chassis(context.Background(), chassis)
+	if err == nil {
+		t.Error("Unreadable fans should be reported")
+	}
+
+	if tree.Children[0].Err == nil || len(tree.Children) != 2 {
+		t.Errorf("Unexpected health tree:\n%s", tree)
+	}
+}
@@ -0,0 +1,146 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package logexport converts the log entries of Redfish log services, such as
+// the IPMI System Event Log, into JSON Lines, CSV or RFC 5424 syslog records
+// to feed log pipelines.
+package logexport
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jacobweinstock/gophish/redfish"
+)
+
+// Fields are the names of the fields of a record, in the order of the CSV
+// columns. They are also the keys of the JSON Lines records and the
+// parameters of the syslog structured data, and do not change between
+// releases.
+var Fields = []string{
+	"bmc",
+	"log_service",
+	"id",
+	"uri",
+	"created",
+	"severity",
+	"entry_type",
+	"entry_code",
+	"sensor_type",
+	"sensor_number",
+	"message_id",
+	"message",
+	"origin_of_condition",
+	"event_id",
+}
+
+// Resolver resolves a MessageId to text, such as redfish.MessageRegistries.
+type Resolver interface {
+	Format(messageID string, args []string) (string, bool)
+}
+
+// Source identifies where log entries come from.
+type Source struct {
+	// BMC identifies the service, such as its host name or address.
+	BMC string
+	// LogService is the URI of the log service holding the entries.
+	LogService string
+}
+
+// Record is a log entry with the service it comes from.
+type Record struct {
+	BMC               string `json:"bmc"`
+	LogService        string `json:"log_service"`
+	ID                string `json:"id"`
+	URI               string `json:"uri"`
+	Created           string `json:"created"`
+	Severity          string `json:"severity"`
+	EntryType         string `json:"entry_type"`
+	EntryCode         string `json:"entry_code"`
+	SensorType        string `json:"sensor_type"`
+	SensorNumber      int    `json:"sensor_number"`
+	MessageID         string `json:"message_id"`
+	Message           string `json:"message"`
+	OriginOfCondition string `json:"origin_of_condition"`
+	EventID           string `json:"event_id"`
+}
+
+// NewRecord converts a log entry. The message of Event entries is resolved
+// from its MessageId when the service did not include it and a resolver is
+// provided. The OEM sensor type and entry code are used when the entry
+// reports an OEM one.
+func NewRecord(source Source, entry *redfish.LogEntry, resolver Resolver) Record {
+	record := Record{
+		BMC:               source.BMC,
+		LogService:        source.LogService,
+		ID:                entry.ID,
+		URI:               entry.ODataID,
+		Created:           entry.Created,
+		Severity:          string(entry.Severity),
+		EntryType:         string(entry.EntryType),
+		EntryCode:         string(entry.EntryCode),
+		SensorType:        string(entry.SensorType),
+		SensorNumber:      entry.SensorNumber,
+		MessageID:         entry.MessageID,
+		Message:           entry.Message,
+		OriginOfCondition: entry.OriginOfCondition(),
+		EventID:           entry.EventID,
+	}
+
+	if entry.EntryCode == redfish.OEMLogEntryCode && entry.OemLogEntryCode != "" {
+		record.EntryCode = entry.OemLogEntryCode
+	}
+	if entry.SensorType == redfish.OEMSensorType && entry.OemSensorType != "" {
+		record.SensorType = entry.OemSensorType
+	}
+
+	if record.Message == "" && record.MessageID != "" && resolver != nil {
+		if text, ok := resolver.Format(entry.MessageID, entry.MessageArgs); ok {
+			record.Message = text
+		}
+	}
+
+	return record
+}
+
+// NewRecords converts the log entries of a source.
+func NewRecords(source Source, entries []*redfish.LogEntry, resolver Resolver) []Record {
+	records := make([]Record, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, NewRecord(source, entry, resolver))
+	}
+	return records
+}
+
+// FromLogService reads the entries of a log service matching the filter and
+// converts them, identifying the service as bmc.
+func FromLogService(ctx context.Context, bmc string, logservice *redfish.LogService, filter redfish.LogEntryFilter, resolver Resolver) ([]Record, error) {
+	entries, err := logservice.QueryEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	source := Source{BMC: bmc, LogService: logservice.ODataID}
+	return NewRecords(source, entries, resolver), nil
+}
+
+// values returns the values of the record in the order of Fields.
+func (record *Record) values() []string {
+	return []string{
+		record.BMC,
+		record.LogService,
+		record.ID,
+		record.URI,
+		record.Created,
+		record.Severity,
+		record.EntryType,
+		record.EntryCode,
+		record.SensorType,
+		strconv.Itoa(record.SensorNumber),
+		record.MessageID,
+		record.Message,
+		record.OriginOfCondition,
+		record.EventID,
+	}
+}
@@ -0,0 +1,76 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package logexport
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/redfish"
+)
+
+// resolverFunc resolves message IDs with a function.
+type resolverFunc func(messageID string, args []string) (string, bool)
+
+func (f resolverFunc) Format(messageID string, args []string) (string, bool) {
+	return f(messageID, args)
+}
+
+// decodeEntry decodes a log entry.
+func decodeEntry(t *testing.T, body string) *redfish.LogEntry {
+	var entry redfish.LogEntry
+	if err := json.Unmarshal([]byte(body), &entry); err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	return &entry
+}
+
+// TestNewRecord tests the conversion of log entries.
+func TestNewRecord(t *testing.T) {
+	source := Source{BMC: "bmc1", LogService: "/redfish/v1/Managers/1/LogServices/SEL"}
+
+	sel := decodeEntry(t, `{
+		"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL/Entries/5",
+		"Id": "5",
+		"Created": "2021-03-01T10:00:00Z",
+		"EntryType": "SEL",
+		"EntryCode": "OEM",
+		"OemLogEntryCode": "Vendor Code",
+		"SensorType": "Temperature",
+		"SensorNumber": 0,
+		"Severity": "Critical",
+		"Message": "Inlet temperature is above the upper critical threshold.",
+		"Links": {"OriginOfCondition": {"@odata.id": "/redfish/v1/Chassis/1/Thermal"}}
+	}`)
+	record := NewRecord(source, sel, nil)
+	if record.BMC != "bmc1" || record.LogService != source.LogService || record.ID != "5" {
+		t.Errorf("Invalid record source: %+v", record)
+	}
+	if record.EntryCode != "Vendor Code" || record.SensorType != "Temperature" {
+		t.Errorf("Invalid SEL fields: %+v", record)
+	}
+	if record.OriginOfCondition != "/redfish/v1/Chassis/1/Thermal" {
+		t.Errorf("Invalid origin of condition: %s", record.OriginOfCondition)
+	}
+
+	event := decodeEntry(t, `{
+		"Id": "6",
+		"EntryType": "Event",
+		"MessageId": "Base.1.8.ResourceAtUriUnauthorized",
+		"MessageArgs": ["/redfish/v1", "401"]
+	}`)
+	resolver := resolverFunc(func(messageID string, args []string) (string, bool) {
+		return messageID + " " + args[1], true
+	})
+	record = NewRecord(source, event, resolver)
+	if record.Message != "Base.1.8.ResourceAtUriUnauthorized 401" {
+		t.Errorf("Message should be resolved: %s", record.Message)
+	}
+
+	records := NewRecords(source, []*redfish.LogEntry{sel, event}, nil)
+	if len(records) != 2 || records[1].Message != "" {
+		t.Errorf("Unexpected records: %+v", records)
+	}
+}
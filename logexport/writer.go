@@ -0,0 +1,203 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package logexport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format is an output format of the records.
+type Format string
+
+const (
+	// JSONLinesFormat writes a JSON object per line.
+	JSONLinesFormat Format = "jsonl"
+	// CSVFormat writes a header line with the Fields followed by a line per
+	// record.
+	CSVFormat Format = "csv"
+	// SyslogFormat writes an RFC 5424 syslog message per line, with the
+	// fields as structured data.
+	SyslogFormat Format = "syslog"
+)
+
+// ParseFormat returns the format with the provided name.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case JSONLinesFormat, CSVFormat, SyslogFormat:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown format '%s', expected jsonl, csv or syslog", name)
+}
+
+const (
+	// defaultFacility is the syslog facility of the messages, local0.
+	defaultFacility = 16
+	// defaultAppName is the syslog APP-NAME of the messages.
+	defaultAppName = "redfish"
+	// structuredDataID identifies the structured data of the messages. It
+	// uses the private enterprise number reserved for documentation, as the
+	// fields are not registered.
+	structuredDataID = "redfish@32473"
+)
+
+// createdLayouts are the layouts of the Created timestamps of log entries.
+// Some services leave out the seconds.
+var createdLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+}
+
+// Writer writes records in one of the formats.
+type Writer struct {
+	// Facility is the syslog facility of the messages, local0 by default.
+	Facility int
+	// AppName is the syslog APP-NAME of the messages, redfish by default.
+	AppName string
+
+	format        Format
+	w             io.Writer
+	csv           *csv.Writer
+	headerWritten bool
+}
+
+// NewWriter creates a writer of the records in the format.
+func NewWriter(w io.Writer, format Format) (*Writer, error) {
+	if _, err := ParseFormat(string(format)); err != nil {
+		return nil, err
+	}
+
+	writer := &Writer{
+		Facility: defaultFacility,
+		AppName:  defaultAppName,
+		format:   format,
+		w:        w,
+	}
+	if format == CSVFormat {
+		writer.csv = csv.NewWriter(w)
+	}
+	return writer, nil
+}
+
+// Write writes a record.
+func (writer *Writer) Write(record Record) error {
+	switch writer.format {
+	case CSVFormat:
+		if !writer.headerWritten {
+			if err := writer.csv.Write(Fields); err != nil {
+				return err
+			}
+			writer.headerWritten = true
+		}
+		return writer.csv.Write(record.values())
+	case SyslogFormat:
+		_, err := io.WriteString(writer.w, writer.syslog(&record)+"\n")
+		return err
+	default:
+		return json.NewEncoder(writer.w).Encode(record)
+	}
+}
+
+// Flush writes any buffered data. It must be called once all the records are
+// written.
+func (writer *Writer) Flush() error {
+	if writer.csv == nil {
+		return nil
+	}
+	writer.csv.Flush()
+	return writer.csv.Error()
+}
+
+// Export writes the records in the format.
+func Export(w io.Writer, format Format, records []Record) error {
+	writer, err := NewWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// syslog formats a record as an RFC 5424 message. The host name is the BMC
+// and the MSGID the MessageId of the entry, every other field is included
+// as a structured data parameter.
+func (writer *Writer) syslog(record *Record) string {
+	timestamp := "-"
+	for _, layout := range createdLayouts {
+		if created, err := time.Parse(layout, record.Created); err == nil {
+			timestamp = created.Format("2006-01-02T15:04:05.999999Z07:00")
+			break
+		}
+	}
+
+	var params strings.Builder
+	for i, value := range record.values() {
+		if value == "" || Fields[i] == "message" {
+			continue
+		}
+		fmt.Fprintf(&params, " %s=\"%s\"", Fields[i], escapeParamValue(value))
+	}
+
+	message := strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(record.Message)
+
+	return fmt.Sprintf("<%d>1 %s %s %s - %s [%s%s] %s",
+		writer.Facility*8+syslogSeverity(record.Severity),
+		timestamp,
+		headerField(record.BMC, 255),
+		headerField(writer.AppName, 48),
+		headerField(record.MessageID, 32),
+		structuredDataID,
+		params.String(),
+		message)
+}
+
+// syslogSeverity returns the syslog severity of a Redfish severity.
+func syslogSeverity(severity string) int {
+	switch severity {
+	case "Critical":
+		return 2
+	case "Warning":
+		return 4
+	case "OK":
+		return 6
+	default:
+		return 5
+	}
+}
+
+// headerField returns a syslog header field: printable ASCII without spaces,
+// at most maxLength long, or "-" if empty.
+func headerField(value string, maxLength int) string {
+	field := strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+
+	if len(field) > maxLength {
+		field = field[:maxLength]
+	}
+	if field == "" {
+		return "-"
+	}
+	return field
+}
+
+// escapeParamValue escapes the characters a structured data parameter value
+// cannot hold.
+func escapeParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
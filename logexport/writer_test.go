@@ -0,0 +1,123 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package logexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testRecords = []Record{
+	{
+		BMC:               "bmc1",
+		LogService:        "/redfish/v1/Managers/1/LogServices/SEL",
+		ID:                "5",
+		URI:               "/redfish/v1/Managers/1/LogServices/SEL/Entries/5",
+		Created:           "2021-03-01T10:00:00+02:00",
+		Severity:          "Critical",
+		EntryType:         "SEL",
+		SensorType:        "Temperature",
+		MessageID:         "Base.1.8.Failure",
+		Message:           "Inlet \"temp\"\nis critical",
+		OriginOfCondition: "/redfish/v1/Chassis/1",
+	},
+	{
+		BMC:      "bmc 2",
+		ID:       "6",
+		Created:  "unknown",
+		Severity: "OK",
+	},
+}
+
+// TestExportJSONLines tests writing JSON Lines.
+func TestExportJSONLines(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Export(&buffer, JSONLinesFormat, testRecords); err != nil {
+		t.Fatalf("Error exporting: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected a line per record:\n%s", buffer.String())
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &fields); err != nil {
+		t.Fatalf("Invalid JSON line: %s", err)
+	}
+	// Every field is present, even when empty.
+	for _, field := range Fields {
+		if _, ok := fields[field]; !ok {
+			t.Errorf("Missing field %s in %s", field, lines[1])
+		}
+	}
+}
+
+// TestExportCSV tests writing CSV.
+func TestExportCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Export(&buffer, CSVFormat, testRecords); err != nil {
+		t.Fatalf("Error exporting: %s", err)
+	}
+
+	rows, err := csv.NewReader(&buffer).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %s", err)
+	}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(Fields, ",") {
+		t.Fatalf("Unexpected rows: %v", rows)
+	}
+	if rows[1][11] != "Inlet \"temp\"\nis critical" || rows[1][9] != "0" {
+		t.Errorf("Unexpected row: %v", rows[1])
+	}
+}
+
+// TestExportSyslog tests writing RFC 5424 messages.
+func TestExportSyslog(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Export(&buffer, SyslogFormat, testRecords); err != nil {
+		t.Fatalf("Error exporting: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected a line per record:\n%s", buffer.String())
+	}
+
+	expected := `<130>1 2021-03-01T10:00:00+02:00 bmc1 redfish - Base.1.8.Failure [redfish@32473 bmc="bmc1"` +
+		` log_service="/redfish/v1/Managers/1/LogServices/SEL" id="5" uri="/redfish/v1/Managers/1/LogServices/SEL/Entries/5"` +
+		` created="2021-03-01T10:00:00+02:00" severity="Critical" entry_type="SEL" sensor_type="Temperature"` +
+		` sensor_number="0" message_id="Base.1.8.Failure" origin_of_condition="/redfish/v1/Chassis/1"] Inlet "temp" is critical`
+	if lines[0] != expected {
+		t.Errorf("Unexpected syslog message:\n%s\nexpected:\n%s", lines[0], expected)
+	}
+
+	if !strings.HasPrefix(lines[1], `<134>1 - bmc_2 redfish - - [redfish@32473 bmc="bmc 2" id="6"`) {
+		t.Errorf("Unexpected syslog message: %s", lines[1])
+	}
+}
+
+// TestParseFormat tests the format names.
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat("syslog"); err != nil || format != SyslogFormat {
+		t.Errorf("Unexpected format %s: %v", format, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+	if _, err := NewWriter(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("Expected an error creating a writer for an unknown format")
+	}
+}
+
+// TestEscapeParamValue tests escaping structured data values.
+func TestEscapeParamValue(t *testing.T) {
+	if escaped := escapeParamValue(`a\b"c]d`); escaped != `a\\b\"c\]d` {
+		t.Errorf("Invalid escaping: %s", escaped)
+	}
+}
@@ -0,0 +1,218 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// Aggregate is used to represent a grouping of resources, such as computer
+// systems, that actions can be applied to as a whole.
+type Aggregate struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// elements shall contain an array of links to the elements of this
+	// aggregate.
+	elements []string
+	// ElementsCount is the number of elements in this aggregate.
+	ElementsCount int `json:"Elements@odata.count"`
+	// SupportedResetTypes, if provided, is the reset types this aggregate
+	// supports.
+	SupportedResetTypes []ResetType
+	// addElementsTarget is the URL to send AddElements actions to.
+	addElementsTarget string
+	// removeElementsTarget is the URL to send RemoveElements actions to.
+	removeElementsTarget string
+	// resetTarget is the URL to send Reset actions to.
+	resetTarget string
+	// setDefaultBootOrderTarget is the URL to send SetDefaultBootOrder
+	// actions to.
+	setDefaultBootOrderTarget string
+}
+
+// UnmarshalJSON unmarshals an Aggregate object from the raw JSON.
+func (aggregate *Aggregate) UnmarshalJSON(b []byte) error {
+	type temp Aggregate
+	type Actions struct {
+		AddElements struct {
+			Target string
+		} `json:"#Aggregate.AddElements"`
+		RemoveElements struct {
+			Target string
+		} `json:"#Aggregate.RemoveElements"`
+		Reset struct {
+			AllowedResetTypes []ResetType `json:"ResetType@Redfish.AllowableValues"`
+			Target            string
+		} `json:"#Aggregate.Reset"`
+		SetDefaultBootOrder struct {
+			Target string
+		} `json:"#Aggregate.SetDefaultBootOrder"`
+	}
+	var t struct {
+		temp
+		Actions  Actions
+		Elements common.Links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*aggregate = Aggregate(t.temp)
+
+	// Extract the links to other entities for later
+	aggregate.elements = t.Elements.ToStrings()
+	aggregate.SupportedResetTypes = t.Actions.Reset.AllowedResetTypes
+	aggregate.addElementsTarget = t.Actions.AddElements.Target
+	aggregate.removeElementsTarget = t.Actions.RemoveElements.Target
+	aggregate.resetTarget = t.Actions.Reset.Target
+	aggregate.setDefaultBootOrderTarget = t.Actions.SetDefaultBootOrder.Target
+
+	return nil
+}
+
+// GetAggregate will get an Aggregate instance from the service.
+func GetAggregate(ctx context.Context, c common.Client, uri string) (*Aggregate, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var aggregate Aggregate
+	err = json.NewDecoder(resp.Body).Decode(&aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate.SetClient(c)
+	return &aggregate, nil
+}
+
+// ListReferencedAggregates gets the collection of Aggregate from
+// a provided reference.
+func ListReferencedAggregates(ctx context.Context, c common.Client, link string) ([]*Aggregate, error) {
+	var result []*Aggregate
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(ctx, c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, aggregateLink := range links.ItemLinks {
+		aggregate, err := GetAggregate(ctx, c, aggregateLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, aggregate)
+	}
+
+	return result, nil
+}
+
+// Elements gets the URIs of the resources that are members of this
+// aggregate.
+func (aggregate *Aggregate) Elements() []string {
+	return aggregate.elements
+}
+
+// ComputerSystems gets the computer systems that are members of this
+// aggregate.
+func (aggregate *Aggregate) ComputerSystems(ctx context.Context) ([]*ComputerSystem, error) {
+	var result []*ComputerSystem
+	for _, systemLink := range aggregate.elements {
+		system, err := GetComputerSystem(ctx, aggregate.Client, systemLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, system)
+	}
+	return result, nil
+}
+
+// AddElements adds the resources found at the provided URIs to this
+// aggregate.
+func (aggregate *Aggregate) AddElements(ctx context.Context, elements []string) error {
+	if aggregate.addElementsTarget == "" {
+		return fmt.Errorf("AddElements is not supported by this aggregate")
+	}
+
+	t := struct {
+		Elements []common.ODataLink
+	}{
+		Elements: common.ToODataLinks(elements),
+	}
+
+	_, err := aggregate.Client.Post(ctx, aggregate.addElementsTarget, t)
+	return err
+}
+
+// RemoveElements removes the resources found at the provided URIs from this
+// aggregate.
+func (aggregate *Aggregate) RemoveElements(ctx context.Context, elements []string) error {
+	if aggregate.removeElementsTarget == "" {
+		return fmt.Errorf("RemoveElements is not supported by this aggregate")
+	}
+
+	t := struct {
+		Elements []common.ODataLink
+	}{
+		Elements: common.ToODataLinks(elements),
+	}
+
+	_, err := aggregate.Client.Post(ctx, aggregate.removeElementsTarget, t)
+	return err
+}
+
+// Reset performs a reset of all elements of this aggregate. batchSize and
+// delayBetweenBatchesInSeconds are optional and may be zero to let the
+// service reset all elements at once.
+func (aggregate *Aggregate) Reset(ctx context.Context, resetType ResetType, batchSize, delayBetweenBatchesInSeconds int) error {
+	if aggregate.resetTarget == "" {
+		return fmt.Errorf("Reset is not supported by this aggregate")
+	}
+
+	if err := validateResetType(resetType, aggregate.SupportedResetTypes); err != nil {
+		return err
+	}
+
+	t := struct {
+		ResetType                    ResetType
+		BatchSize                    int `json:",omitempty"`
+		DelayBetweenBatchesInSeconds int `json:",omitempty"`
+	}{
+		ResetType:                    resetType,
+		BatchSize:                    batchSize,
+		DelayBetweenBatchesInSeconds: delayBetweenBatchesInSeconds,
+	}
+
+	_, err := aggregate.Client.Post(ctx, aggregate.resetTarget, t)
+	return err
+}
+
+// SetDefaultBootOrder sets the BootOrder of all computer systems in this
+// aggregate to their default settings.
+func (aggregate *Aggregate) SetDefaultBootOrder(ctx context.Context) error {
+	if aggregate.setDefaultBootOrderTarget == "" {
+		return fmt.Errorf("SetDefaultBootOrder is not supported by this aggregate")
+	}
+
+	_, err := aggregate.Client.Post(ctx, aggregate.setDefaultBootOrderTarget, nil)
+	return err
+}
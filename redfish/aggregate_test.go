@@ -0,0 +1,122 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var aggregateBody = `{
+		"@odata.context": "/redfish/v1/$metadata#Aggregate.Aggregate",
+		"@odata.id": "/redfish/v1/AggregationService/Aggregates/Aggregate1",
+		"@odata.type": "#Aggregate.v1_0_1.Aggregate",
+		"Id": "Aggregate1",
+		"Name": "Aggregate One",
+		"ElementsCount": 2,
+		"Elements": [
+			{
+				"@odata.id": "/redfish/v1/Systems/cluster-node3"
+			},
+			{
+				"@odata.id": "/redfish/v1/Systems/cluster-node4"
+			}
+		],
+		"Elements@odata.count": 2,
+		"Actions": {
+			"#Aggregate.AddElements": {
+				"target": "/redfish/v1/AggregationService/Aggregates/Aggregate1/Actions/Aggregate.AddElements"
+			},
+			"#Aggregate.RemoveElements": {
+				"target": "/redfish/v1/AggregationService/Aggregates/Aggregate1/Actions/Aggregate.RemoveElements"
+			},
+			"#Aggregate.Reset": {
+				"target": "/redfish/v1/AggregationService/Aggregates/Aggregate1/Actions/Aggregate.Reset"
+			},
+			"#Aggregate.SetDefaultBootOrder": {
+				"target": "/redfish/v1/AggregationService/Aggregates/Aggregate1/Actions/Aggregate.SetDefaultBootOrder"
+			}
+		}
+	}`
+
+// TestAggregate tests the parsing of Aggregate objects.
+func TestAggregate(t *testing.T) {
+	var result Aggregate
+	err := json.NewDecoder(strings.NewReader(aggregateBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Aggregate1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ElementsCount != 2 {
+		t.Errorf("Invalid elements count: %d", result.ElementsCount)
+	}
+
+	if len(result.Elements()) != 2 {
+		t.Errorf("Invalid number of elements: %d", len(result.Elements()))
+	}
+
+	if result.Elements()[1] != "/redfish/v1/Systems/cluster-node4" {
+		t.Errorf("Invalid element link: %s", result.Elements()[1])
+	}
+
+	if result.resetTarget != "/redfish/v1/AggregationService/Aggregates/Aggregate1/Actions/Aggregate.Reset" {
+		t.Errorf("Invalid reset target: %s", result.resetTarget)
+	}
+}
+
+// TestAggregateActions tests the Aggregate actions.
+func TestAggregateActions(t *testing.T) {
+	var result Aggregate
+	err := json.NewDecoder(strings.NewReader(aggregateBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.AddElements(context.Background(), []string{"/redfish/v1/Systems/cluster-node5"})
+	if err != nil {
+		t.Errorf("Error making AddElements call: %s", err)
+	}
+
+	err = result.Reset(context.Background(), ForceOffResetType, 0, 0)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	err = result.SetDefaultBootOrder(context.Background())
+	if err != nil {
+		t.Errorf("Error making SetDefaultBootOrder call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 3 {
+		t.Fatalf("Expected 3 calls, got %d", len(calls))
+	}
+
+	if !strings.Contains(calls[0].Payload, "Elements:[map[@odata.id:/redfish/v1/Systems/cluster-node5]]") {
+		t.Errorf("Unexpected AddElements payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Payload != "map[ResetType:ForceOff]" {
+		t.Errorf("Unexpected Reset payload: %s", calls[1].Payload)
+	}
+
+	if calls[2].URL != "/redfish/v1/AggregationService/Aggregates/Aggregate1/Actions/Aggregate.SetDefaultBootOrder" {
+		t.Errorf("Unexpected SetDefaultBootOrder URL: %s", calls[2].URL)
+	}
+}
@@ -0,0 +1,284 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// AggregationService is used to represent an aggregation service for a
+// Redfish implementation. An aggregator fronts multiple other Redfish
+// services (such as BMCs) and exposes their resources as a single service.
+type AggregationService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// aggregates shall contain a link to a resource collection of type
+	// AggregateCollection.
+	aggregates string
+	// aggregationSources shall contain a link to a resource collection of
+	// type AggregationSourceCollection.
+	aggregationSources string
+	// connectionMethods shall contain a link to a resource collection of
+	// type ConnectionMethodCollection.
+	connectionMethods string
+	// Description provides a description of this resource.
+	Description string
+	// ServiceEnabled shall indicate whether the aggregation service is
+	// enabled.
+	ServiceEnabled bool
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// SupportedResetTypes, if provided, is the reset types this service
+	// supports for aggregate-wide resets.
+	SupportedResetTypes []ResetType
+	// resetTarget is the URL to send Reset actions to.
+	resetTarget string
+	// setDefaultBootOrderTarget is the URL to send SetDefaultBootOrder
+	// actions to.
+	setDefaultBootOrderTarget string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals an AggregationService object from the raw JSON.
+func (aggregationservice *AggregationService) UnmarshalJSON(b []byte) error {
+	type temp AggregationService
+	type Actions struct {
+		Reset struct {
+			AllowedResetTypes []ResetType `json:"ResetType@Redfish.AllowableValues"`
+			Target            string
+		} `json:"#AggregationService.Reset"`
+		SetDefaultBootOrder struct {
+			Target string
+		} `json:"#AggregationService.SetDefaultBootOrder"`
+	}
+	var t struct {
+		temp
+		Actions            Actions
+		Aggregates         common.Link
+		AggregationSources common.Link
+		ConnectionMethods  common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*aggregationservice = AggregationService(t.temp)
+
+	// Extract the links to other entities for later
+	aggregationservice.aggregates = string(t.Aggregates)
+	aggregationservice.aggregationSources = string(t.AggregationSources)
+	aggregationservice.connectionMethods = string(t.ConnectionMethods)
+	aggregationservice.SupportedResetTypes = t.Actions.Reset.AllowedResetTypes
+	aggregationservice.resetTarget = t.Actions.Reset.Target
+	aggregationservice.setDefaultBootOrderTarget = t.Actions.SetDefaultBootOrder.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	aggregationservice.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (aggregationservice *AggregationService) Update(ctx context.Context) error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(AggregationService)
+	original.UnmarshalJSON(aggregationservice.rawData)
+
+	readWriteFields := []string{
+		"ServiceEnabled",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(aggregationservice).Elem()
+
+	return aggregationservice.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+}
+
+// GetAggregationService will get an AggregationService instance from the service.
+func GetAggregationService(ctx context.Context, c common.Client, uri string) (*AggregationService, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var aggregationservice AggregationService
+	err = json.NewDecoder(resp.Body).Decode(&aggregationservice)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregationservice.SetClient(c)
+	return &aggregationservice, nil
+}
+
+// Aggregates gets the aggregates defined on this service.
+func (aggregationservice *AggregationService) Aggregates(ctx context.Context) ([]*Aggregate, error) {
+	return ListReferencedAggregates(ctx, aggregationservice.Client, aggregationservice.aggregates)
+}
+
+// AggregationSources gets the aggregation sources registered with this
+// service.
+func (aggregationservice *AggregationService) AggregationSources(ctx context.Context) ([]*AggregationSource, error) {
+	return ListReferencedAggregationSources(ctx, aggregationservice.Client, aggregationservice.aggregationSources)
+}
+
+// CreateAggregate creates a new aggregate from the provided element URIs. It
+// returns the URI of the new aggregate, or the task creating it if the service
+// creates it asynchronously, in which case the URI is empty.
+func (aggregationservice *AggregationService) CreateAggregate(ctx context.Context, elements []string) (string, *Task, error) {
+	if aggregationservice.aggregates == "" {
+		return "", nil, fmt.Errorf("aggregates are not supported by this service")
+	}
+
+	t := struct {
+		Elements []common.ODataLink
+	}{
+		Elements: common.ToODataLinks(elements),
+	}
+
+	resp, err := aggregationservice.Client.Post(ctx, aggregationservice.aggregates, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return LocationOrTask(ctx, aggregationservice.Client, resp)
+}
+
+// DeleteAggregate deletes the aggregate found at the provided URI.
+func (aggregationservice *AggregationService) DeleteAggregate(ctx context.Context, uri string) error {
+	if len(strings.TrimSpace(uri)) == 0 {
+		return fmt.Errorf("uri should not be empty")
+	}
+	_, err := aggregationservice.Client.Delete(ctx, uri)
+	return err
+}
+
+// RegisterAggregationSource registers a new aggregation source (for example a
+// BMC) with the aggregator. hostName is the URI of the source service and
+// userName and password are the credentials the aggregator should use to
+// access it. It returns the URI of the new aggregation source, or the task
+// registering it if the service registers it asynchronously, in which case
+// the URI is empty.
+func (aggregationservice *AggregationService) RegisterAggregationSource(ctx context.Context, hostName, userName, password string) (string, *Task, error) {
+	if aggregationservice.aggregationSources == "" {
+		return "", nil, fmt.Errorf("aggregation sources are not supported by this service")
+	}
+
+	if len(strings.TrimSpace(hostName)) == 0 {
+		return "", nil, fmt.Errorf("empty host name is not valid")
+	}
+
+	t := struct {
+		HostName string
+		UserName string `json:",omitempty"`
+		Password string `json:",omitempty"`
+	}{
+		HostName: hostName,
+		UserName: userName,
+		Password: password,
+	}
+
+	resp, err := aggregationservice.Client.Post(ctx, aggregationservice.aggregationSources, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return LocationOrTask(ctx, aggregationservice.Client, resp)
+}
+
+// UnregisterAggregationSource removes the aggregation source found at the
+// provided URI from the aggregator.
+func (aggregationservice *AggregationService) UnregisterAggregationSource(ctx context.Context, uri string) error {
+	if len(strings.TrimSpace(uri)) == 0 {
+		return fmt.Errorf("uri should not be empty")
+	}
+	_, err := aggregationservice.Client.Delete(ctx, uri)
+	return err
+}
+
+// Reset performs a reset of the set of resources identified by targetURIs.
+// batchSize and delayBetweenBatchesInSeconds are optional and may be zero to
+// let the service reset all targets at once.
+func (aggregationservice *AggregationService) Reset(ctx context.Context, resetType ResetType, targetURIs []string, batchSize, delayBetweenBatchesInSeconds int) error {
+	if aggregationservice.resetTarget == "" {
+		return fmt.Errorf("Reset is not supported by this service")
+	}
+
+	if err := validateResetType(resetType, aggregationservice.SupportedResetTypes); err != nil {
+		return err
+	}
+
+	if len(targetURIs) == 0 {
+		return fmt.Errorf("at least one target should be defined")
+	}
+
+	t := struct {
+		ResetType                    ResetType
+		TargetURIs                   []string
+		BatchSize                    int `json:",omitempty"`
+		DelayBetweenBatchesInSeconds int `json:",omitempty"`
+	}{
+		ResetType:                    resetType,
+		TargetURIs:                   targetURIs,
+		BatchSize:                    batchSize,
+		DelayBetweenBatchesInSeconds: delayBetweenBatchesInSeconds,
+	}
+
+	_, err := aggregationservice.Client.Post(ctx, aggregationservice.resetTarget, t)
+	return err
+}
+
+// SetDefaultBootOrder sets the BootOrder of the provided computer systems to
+// their default settings.
+func (aggregationservice *AggregationService) SetDefaultBootOrder(ctx context.Context, systems []string) error {
+	if aggregationservice.setDefaultBootOrderTarget == "" {
+		return fmt.Errorf("SetDefaultBootOrder is not supported by this service")
+	}
+
+	if len(systems) == 0 {
+		return fmt.Errorf("at least one system should be defined")
+	}
+
+	t := struct {
+		Systems []common.ODataLink
+	}{
+		Systems: common.ToODataLinks(systems),
+	}
+
+	_, err := aggregationservice.Client.Post(ctx, aggregationservice.setDefaultBootOrderTarget, t)
+	return err
+}
+
+// validateResetType makes sure the requested reset type is within the
+// allowed values. No allowed values means the service did not advertise
+// them, in which case the reset type is assumed to be valid.
+func validateResetType(resetType ResetType, allowed []ResetType) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	for _, allowedType := range allowed {
+		if resetType == allowedType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("reset type '%s' is not supported by this service", resetType)
+}
@@ -0,0 +1,307 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var aggregationServiceBody = `{
+		"@odata.context": "/redfish/v1/$metadata#AggregationService.AggregationService",
+		"@odata.id": "/redfish/v1/AggregationService",
+		"@odata.type": "#AggregationService.v1_0_1.AggregationService",
+		"Id": "AggregationService",
+		"Name": "Aggregation Service",
+		"Description": "Aggregation Service",
+		"ServiceEnabled": true,
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Aggregates": {
+			"@odata.id": "/redfish/v1/AggregationService/Aggregates"
+		},
+		"AggregationSources": {
+			"@odata.id": "/redfish/v1/AggregationService/AggregationSources"
+		},
+		"ConnectionMethods": {
+			"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods"
+		},
+		"Actions": {
+			"#AggregationService.Reset": {
+				"target": "/redfish/v1/AggregationService/Actions/AggregationService.Reset",
+				"ResetType@Redfish.AllowableValues": [
+					"On",
+					"ForceOff",
+					"GracefulRestart"
+				]
+			},
+			"#AggregationService.SetDefaultBootOrder": {
+				"target": "/redfish/v1/AggregationService/Actions/AggregationService.SetDefaultBootOrder"
+			}
+		}
+	}`
+
+// TestAggregationService tests the parsing of AggregationService objects.
+func TestAggregationService(t *testing.T) {
+	var result AggregationService
+	err := json.NewDecoder(strings.NewReader(aggregationServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "AggregationService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if !result.ServiceEnabled {
+		t.Error("ServiceEnabled should be true")
+	}
+
+	if result.aggregates != "/redfish/v1/AggregationService/Aggregates" {
+		t.Errorf("Invalid aggregates link: %s", result.aggregates)
+	}
+
+	if result.aggregationSources != "/redfish/v1/AggregationService/AggregationSources" {
+		t.Errorf("Invalid aggregation sources link: %s", result.aggregationSources)
+	}
+
+	if result.resetTarget != "/redfish/v1/AggregationService/Actions/AggregationService.Reset" {
+		t.Errorf("Invalid reset target: %s", result.resetTarget)
+	}
+
+	if len(result.SupportedResetTypes) != 3 {
+		t.Errorf("Invalid allowable reset actions, expected 3, got %d",
+			len(result.SupportedResetTypes))
+	}
+
+	if result.setDefaultBootOrderTarget != "/redfish/v1/AggregationService/Actions/AggregationService.SetDefaultBootOrder" {
+		t.Errorf("Invalid SetDefaultBootOrder target: %s", result.setDefaultBootOrderTarget)
+	}
+}
+
+// TestAggregationServiceUpdate tests the Update call.
+func TestAggregationServiceUpdate(t *testing.T) {
+	var result AggregationService
+	err := json.NewDecoder(strings.NewReader(aggregationServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.ServiceEnabled = false
+	err = result.Update(context.Background())
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "ServiceEnabled:false") {
+		t.Errorf("Unexpected ServiceEnabled update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestAggregationServiceRegisterAggregationSource tests the
+// RegisterAggregationSource call.
+func TestAggregationServiceRegisterAggregationSource(t *testing.T) {
+	var result AggregationService
+	err := json.NewDecoder(strings.NewReader(aggregationServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	expectedURI := "/redfish/v1/AggregationService/AggregationSources/BMC-1"
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				&http.Response{
+					Status:     "201 Created",
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header: http.Header{
+						"Location": []string{
+							fmt.Sprintf("https://aggregator%s", expectedURI),
+						},
+					},
+				},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, task, err := result.RegisterAggregationSource(context.Background(),
+		"https://10.0.0.12", "admin", "secret")
+
+	if err != nil {
+		t.Errorf("Error making RegisterAggregationSource call: %s", err)
+	}
+
+	if uri != expectedURI || task != nil {
+		t.Errorf("Unexpected aggregation source URI: %s %v", uri, task)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != "/redfish/v1/AggregationService/AggregationSources" {
+		t.Errorf("Unexpected RegisterAggregationSource URL: %s", calls[0].URL)
+	}
+
+	if !strings.Contains(calls[0].Payload, "HostName:https://10.0.0.12") {
+		t.Errorf("Unexpected HostName payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "UserName:admin") {
+		t.Errorf("Unexpected UserName payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "Password:secret") {
+		t.Errorf("Unexpected Password payload: %s", calls[0].Payload)
+	}
+}
+
+// TestAggregationServiceUnregisterAggregationSource tests the
+// UnregisterAggregationSource call.
+func TestAggregationServiceUnregisterAggregationSource(t *testing.T) {
+	var result AggregationService
+	err := json.NewDecoder(strings.NewReader(aggregationServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.UnregisterAggregationSource(context.Background(),
+		"/redfish/v1/AggregationService/AggregationSources/BMC-1")
+
+	if err != nil {
+		t.Errorf("Error making UnregisterAggregationSource call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Action != http.MethodDelete {
+		t.Errorf("Unexpected UnregisterAggregationSource action: %s", calls[0].Action)
+	}
+
+	if calls[0].URL != "/redfish/v1/AggregationService/AggregationSources/BMC-1" {
+		t.Errorf("Unexpected UnregisterAggregationSource URL: %s", calls[0].URL)
+	}
+}
+
+// TestAggregationServiceReset tests the Reset call.
+func TestAggregationServiceReset(t *testing.T) {
+	var result AggregationService
+	err := json.NewDecoder(strings.NewReader(aggregationServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Reset(context.Background(), ForceRestartResetType,
+		[]string{"/redfish/v1/Systems/1"}, 0, 0)
+	if err == nil {
+		t.Error("Expected error for unsupported reset type")
+	}
+
+	err = result.Reset(context.Background(), GracefulRestartResetType,
+		[]string{"/redfish/v1/Systems/1", "/redfish/v1/Systems/2"}, 1, 30)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call, got %d", len(calls))
+	}
+
+	if !strings.Contains(calls[0].Payload, "ResetType:GracefulRestart") {
+		t.Errorf("Unexpected ResetType payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "TargetURIs:[/redfish/v1/Systems/1 /redfish/v1/Systems/2]") {
+		t.Errorf("Unexpected TargetURIs payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "DelayBetweenBatchesInSeconds:30") {
+		t.Errorf("Unexpected DelayBetweenBatchesInSeconds payload: %s", calls[0].Payload)
+	}
+}
+
+// TestAggregationServiceSetDefaultBootOrder tests the SetDefaultBootOrder call.
+func TestAggregationServiceSetDefaultBootOrder(t *testing.T) {
+	var result AggregationService
+	err := json.NewDecoder(strings.NewReader(aggregationServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetDefaultBootOrder(context.Background(), []string{"/redfish/v1/Systems/1"})
+	if err != nil {
+		t.Errorf("Error making SetDefaultBootOrder call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "Systems:[map[@odata.id:/redfish/v1/Systems/1]]") {
+		t.Errorf("Unexpected SetDefaultBootOrder payload: %s", calls[0].Payload)
+	}
+}
+
+// TestAggregationServiceCreateAggregateAsync tests creating an aggregate the
+// service creates asynchronously.
+func TestAggregationServiceCreateAggregateAsync(t *testing.T) {
+	var result AggregationService
+	err := json.NewDecoder(strings.NewReader(aggregationServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {acceptedResponse(`{"@odata.id": "/redfish/v1/TaskService/Tasks/3", "Id": "3"}`, "")},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, task, err := result.CreateAggregate(context.Background(), []string{"/redfish/v1/Systems/1"})
+	if err != nil {
+		t.Fatalf("Error making CreateAggregate call: %s", err)
+	}
+
+	if uri != "" || task == nil || task.ID != "3" {
+		t.Errorf("Unexpected CreateAggregate result: %s %v", uri, task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if !strings.Contains(calls[0].Payload, "Elements:[map[@odata.id:/redfish/v1/Systems/1]]") {
+		t.Errorf("Unexpected CreateAggregate payload: %s", calls[0].Payload)
+	}
+}
@@ -0,0 +1,137 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// AggregationSource is used to represent a source of resources, such as a
+// BMC, that is aggregated by an AggregationService.
+type AggregationSource struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// HostName shall contain the URI of the system to be aggregated.
+	HostName string
+	// Password shall contain a password for accessing the aggregation source.
+	// The value shall be null in responses.
+	Password string
+	// UserName shall contain the user name for accessing the aggregation
+	// source.
+	UserName string
+	// connectionMethod shall contain a link to a resource of type
+	// ConnectionMethod that is used to connect to the aggregation source.
+	connectionMethod string
+	// resourcesAccessed shall contain an array of links to the resources
+	// added to the service through this aggregation source.
+	resourcesAccessed []string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals an AggregationSource object from the raw JSON.
+func (aggregationsource *AggregationSource) UnmarshalJSON(b []byte) error {
+	type temp AggregationSource
+	var t struct {
+		temp
+		Links struct {
+			ConnectionMethod  common.Link
+			ResourcesAccessed common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*aggregationsource = AggregationSource(t.temp)
+
+	// Extract the links to other entities for later
+	aggregationsource.connectionMethod = string(t.Links.ConnectionMethod)
+	aggregationsource.resourcesAccessed = t.Links.ResourcesAccessed.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	aggregationsource.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (aggregationsource *AggregationSource) Update(ctx context.Context) error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(AggregationSource)
+	original.UnmarshalJSON(aggregationsource.rawData)
+
+	readWriteFields := []string{
+		"HostName",
+		"Password",
+		"UserName",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(aggregationsource).Elem()
+
+	return aggregationsource.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+}
+
+// GetAggregationSource will get an AggregationSource instance from the service.
+func GetAggregationSource(ctx context.Context, c common.Client, uri string) (*AggregationSource, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var aggregationsource AggregationSource
+	err = json.NewDecoder(resp.Body).Decode(&aggregationsource)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregationsource.SetClient(c)
+	return &aggregationsource, nil
+}
+
+// ListReferencedAggregationSources gets the collection of AggregationSource
+// from a provided reference.
+func ListReferencedAggregationSources(ctx context.Context, c common.Client, link string) ([]*AggregationSource, error) {
+	var result []*AggregationSource
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(ctx, c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, aggregationsourceLink := range links.ItemLinks {
+		aggregationsource, err := GetAggregationSource(ctx, c, aggregationsourceLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, aggregationsource)
+	}
+
+	return result, nil
+}
+
+// ResourcesAccessed gets the URIs of the resources added to the service
+// through this aggregation source.
+func (aggregationsource *AggregationSource) ResourcesAccessed() []string {
+	return aggregationsource.resourcesAccessed
+}
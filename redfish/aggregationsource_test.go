@@ -0,0 +1,91 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var aggregationSourceBody = `{
+		"@odata.context": "/redfish/v1/$metadata#AggregationSource.AggregationSource",
+		"@odata.id": "/redfish/v1/AggregationService/AggregationSources/AggregationSource1",
+		"@odata.type": "#AggregationSource.v1_0_0.AggregationSource",
+		"Id": "AggregationSource1",
+		"Name": "AggregationSource One",
+		"HostName": "https://Someserver.Contoso.com/redfish/v1",
+		"UserName": "root",
+		"Password": null,
+		"Links": {
+			"ConnectionMethod": {
+				"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods/ConnectionMethod1"
+			},
+			"ResourcesAccessed": [
+				{
+					"@odata.id": "/redfish/v1/Systems/cluster-node3"
+				}
+			]
+		}
+	}`
+
+// TestAggregationSource tests the parsing of AggregationSource objects.
+func TestAggregationSource(t *testing.T) {
+	var result AggregationSource
+	err := json.NewDecoder(strings.NewReader(aggregationSourceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "AggregationSource1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.HostName != "https://Someserver.Contoso.com/redfish/v1" {
+		t.Errorf("Received invalid HostName: %s", result.HostName)
+	}
+
+	if result.UserName != "root" {
+		t.Errorf("Received invalid UserName: %s", result.UserName)
+	}
+
+	if result.connectionMethod != "/redfish/v1/AggregationService/ConnectionMethods/ConnectionMethod1" {
+		t.Errorf("Received invalid connection method: %s", result.connectionMethod)
+	}
+
+	if len(result.ResourcesAccessed()) != 1 {
+		t.Errorf("Invalid number of resources accessed: %d", len(result.ResourcesAccessed()))
+	}
+}
+
+// TestAggregationSourceUpdate tests the Update call.
+func TestAggregationSourceUpdate(t *testing.T) {
+	var result AggregationSource
+	err := json.NewDecoder(strings.NewReader(aggregationSourceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.Password = "newpassword"
+	err = result.Update(context.Background())
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "Password:newpassword") {
+		t.Errorf("Unexpected Password update payload: %s", calls[0].Payload)
+	}
+}
@@ -0,0 +1,550 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// AttributeType is the type of an attribute in an attribute registry.
+type AttributeType string
+
+const (
+	// EnumerationAttributeType shall indicate the attribute value is one of
+	// the ValueName values of the attribute's Value array.
+	EnumerationAttributeType AttributeType = "Enumeration"
+	// StringAttributeType shall indicate the attribute value is a string.
+	StringAttributeType AttributeType = "String"
+	// IntegerAttributeType shall indicate the attribute value is an integer.
+	IntegerAttributeType AttributeType = "Integer"
+	// BooleanAttributeType shall indicate the attribute value is a boolean.
+	BooleanAttributeType AttributeType = "Boolean"
+	// PasswordAttributeType shall indicate the attribute value is a password.
+	// The value shall be null in responses.
+	PasswordAttributeType AttributeType = "Password"
+)
+
+// DependencyType is the type of a dependency in an attribute registry.
+type DependencyType string
+
+const (
+	// MapDependencyType shall indicate a simple mapping dependency. The
+	// attribute value or state is changed to the mapped value if the
+	// condition evaluates to true.
+	MapDependencyType DependencyType = "Map"
+)
+
+// MapFromCondition is the condition used to evaluate a dependency.
+type MapFromCondition string
+
+const (
+	// EQUMapFromCondition shall indicate the logical operation for 'Equal'.
+	EQUMapFromCondition MapFromCondition = "EQU"
+	// NEQMapFromCondition shall indicate the logical operation for 'Not
+	// Equal'.
+	NEQMapFromCondition MapFromCondition = "NEQ"
+	// GTRMapFromCondition shall indicate the logical operation for 'Greater
+	// than'.
+	GTRMapFromCondition MapFromCondition = "GTR"
+	// GEQMapFromCondition shall indicate the logical operation for 'Greater
+	// than or Equal'.
+	GEQMapFromCondition MapFromCondition = "GEQ"
+	// LSSMapFromCondition shall indicate the logical operation for 'Less
+	// than'.
+	LSSMapFromCondition MapFromCondition = "LSS"
+	// LEQMapFromCondition shall indicate the logical operation for 'Less
+	// than or Equal'.
+	LEQMapFromCondition MapFromCondition = "LEQ"
+)
+
+// MapTerms is the logical term used to combine dependency conditions.
+type MapTerms string
+
+const (
+	// ANDMapTerms shall indicate the logical operation for 'AND'.
+	ANDMapTerms MapTerms = "AND"
+	// ORMapTerms shall indicate the logical operation for 'OR'.
+	ORMapTerms MapTerms = "OR"
+)
+
+// AttributeValue is an allowable value of an enumeration attribute.
+type AttributeValue struct {
+	// ValueDisplayName shall contain a user-readable display string of the
+	// value of the attribute in the defined language.
+	ValueDisplayName string
+	// ValueName shall contain the unique value name of the attribute.
+	ValueName string
+}
+
+// Attribute describes a single attribute of an attribute registry.
+type Attribute struct {
+	// AttributeName shall contain the name of the attribute.
+	AttributeName string
+	// CurrentValue shall contain the placeholder of the current value of
+	// the attribute.
+	CurrentValue interface{}
+	// DefaultValue shall contain the default value of the attribute.
+	DefaultValue interface{}
+	// DisplayName shall contain the user-readable display string of the
+	// attribute in the defined language.
+	DisplayName string
+	// GrayOut shall indicate whether this attribute is grayed out. A
+	// grayed-out attribute is not active and is grayed out in user
+	// interfaces but the attribute value can be modified.
+	GrayOut bool
+	// HelpText shall contain the help text for the attribute.
+	HelpText string
+	// Hidden shall indicate whether this attribute is hidden in user
+	// interfaces.
+	Hidden bool
+	// Immutable shall indicate whether this attribute is immutable.
+	// Immutable attributes shall not be modified.
+	Immutable bool
+	// IsSystemUniqueProperty shall indicate whether this attribute is unique
+	// for this system and should not be replicated.
+	IsSystemUniqueProperty bool
+	// LowerBound shall contain a number indicating the lower limit for an
+	// integer attribute.
+	LowerBound *int
+	// MaxLength shall contain a number indicating the maximum length of a
+	// string attribute.
+	MaxLength *int
+	// MenuPath shall contain the menu hierarchy of this attribute.
+	MenuPath string
+	// MinLength shall contain a number indicating the minimum length of a
+	// string attribute.
+	MinLength *int
+	// ReadOnly shall indicate whether this attribute is read-only. A
+	// read-only attribute cannot be modified.
+	ReadOnly bool
+	// ResetRequired shall indicate whether a system or device reset is
+	// required for this attribute value change to take effect.
+	ResetRequired bool
+	// ScalarIncrement shall contain a number indicating the amount to
+	// increment or decrement an integer attribute each time a user requests
+	// a value change. The zero value indicates a free-form variable.
+	ScalarIncrement int
+	// Type shall contain an enumeration that describes the attribute type.
+	Type AttributeType
+	// UpperBound shall contain a number indicating the upper limit for an
+	// integer attribute.
+	UpperBound *int
+	// Value shall contain an array containing the possible values of an
+	// attribute of the Enumeration type.
+	Value []AttributeValue
+	// ValueExpression shall contain a valid regular expression, according to
+	// the Perl regular expression dialect, that validates the attribute
+	// value.
+	ValueExpression string
+	// WarningText shall contain the warning text for the attribute.
+	WarningText string
+	// WriteOnly shall indicate whether this attribute is write-only.
+	WriteOnly bool
+}
+
+// MapFrom is a condition of a dependency.
+type MapFrom struct {
+	// MapFromAttribute shall contain the AttributeName for the attribute to
+	// use to evaluate this dependency expression.
+	MapFromAttribute string
+	// MapFromCondition shall contain the condition to use to evaluate this
+	// dependency expression.
+	MapFromCondition MapFromCondition
+	// MapFromProperty shall contain the metadata property for the attribute
+	// that the MapFromAttribute property specifies to use to evaluate this
+	// dependency expression.
+	MapFromProperty string
+	// MapFromValue shall contain the value to use to evaluate this
+	// dependency expression.
+	MapFromValue interface{}
+	// MapTerms shall contain the logical term that combines two or more
+	// MapFrom conditions in this dependency expression.
+	MapTerms MapTerms
+}
+
+// DependencyExpression describes a dependency expression.
+type DependencyExpression struct {
+	// MapFrom shall contain an array containing the map-from conditions that
+	// apply to this dependency.
+	MapFrom []MapFrom
+	// MapToAttribute shall contain the AttributeName of the attribute that
+	// is affected by this dependency expression.
+	MapToAttribute string
+	// MapToProperty shall contain the metadata property for the attribute
+	// that the MapToAttribute property specifies to change if the
+	// dependency expression evaluates to true.
+	MapToProperty string
+	// MapToValue shall contain the value to set for the property that
+	// MapToProperty specifies if the dependency expression evaluates to
+	// true.
+	MapToValue interface{}
+}
+
+// Dependency describes a dependency of attributes on other attributes.
+type Dependency struct {
+	// Dependency shall contain the dependency expression for one or more
+	// attributes in this attribute registry.
+	Dependency DependencyExpression
+	// DependencyFor shall contain the AttributeName of the attribute whose
+	// change triggers the evaluation of this dependency expression.
+	DependencyFor string
+	// Type shall contain an enumeration that describes the type for the
+	// attribute dependency.
+	Type DependencyType
+}
+
+// RegistryEntries contains the attributes and dependencies of an attribute
+// registry.
+type RegistryEntries struct {
+	// Attributes shall contain an array containing the attributes and their
+	// possible values and other metadata in the attribute registry.
+	Attributes []Attribute
+	// Dependencies shall contain an array containing a list of dependencies
+	// of attributes on this component.
+	Dependencies []Dependency
+}
+
+// SupportedSystem describes a system that an attribute registry applies to.
+type SupportedSystem struct {
+	// FirmwareVersion shall contain the version of the component firmware
+	// image to which this attribute registry applies.
+	FirmwareVersion string
+	// ProductName shall contain the product name of the computer system to
+	// which this attribute registry applies.
+	ProductName string
+	// SystemID shall contain the system ID that identifies the computer
+	// system model to which this attribute registry applies.
+	SystemID string `json:"SystemId"`
+}
+
+// AttributeRegistry is used to represent an attribute registry, such as the
+// registry describing the attributes of a Bios resource.
+type AttributeRegistry struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Language shall contain an RFC5646-conformant language code.
+	Language string
+	// OwningEntity shall be a string that represents the publisher of this
+	// registry.
+	OwningEntity string
+	// RegistryEntries shall contain a list of all attributes for this
+	// component, along with their possible values, dependencies, and other
+	// metadata.
+	RegistryEntries RegistryEntries
+	// RegistryVersion shall contain the version of this attribute registry.
+	RegistryVersion string
+	// SupportedSystems shall contain an array containing a list of systems
+	// that this attribute registry supports.
+	SupportedSystems []SupportedSystem
+}
+
+// GetAttributeRegistry will get an AttributeRegistry instance from the
+// service.
+func GetAttributeRegistry(ctx context.Context, c common.Client, uri string) (*AttributeRegistry, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var attributeregistry AttributeRegistry
+	err = json.NewDecoder(resp.Body).Decode(&attributeregistry)
+	if err != nil {
+		return nil, err
+	}
+
+	attributeregistry.SetClient(c)
+	return &attributeregistry, nil
+}
+
+// FindAttributeRegistry looks up the registry with the provided name (such as
+// the AttributeRegistry property of a Bios) in the registries collection
+// found at registriesLink and downloads it.
+func FindAttributeRegistry(ctx context.Context, c common.Client, registriesLink string, name string) (*AttributeRegistry, error) {
+	if name == "" {
+		return nil, fmt.Errorf("attribute registry name should not be empty")
+	}
+
+	files, err := ListReferencedMessageRegistryFiles(ctx, c, registriesLink)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if file.ID != name && file.Registry != name {
+			continue
+		}
+
+		uri := file.LocationURI("en")
+		if uri == "" {
+			return nil, fmt.Errorf("attribute registry %s has no location on the service", name)
+		}
+
+		return GetAttributeRegistry(ctx, c, uri)
+	}
+
+	return nil, fmt.Errorf("attribute registry %s was not found", name)
+}
+
+// Attribute gets the definition of the attribute with the provided name or
+// nil if the registry does not define it.
+func (attributeregistry *AttributeRegistry) Attribute(name string) *Attribute {
+	for i := range attributeregistry.RegistryEntries.Attributes {
+		if attributeregistry.RegistryEntries.Attributes[i].AttributeName == name {
+			return &attributeregistry.RegistryEntries.Attributes[i]
+		}
+	}
+	return nil
+}
+
+// ResetRequired returns the names of the provided attributes that require a
+// system reset to take effect, sorted by name.
+func (attributeregistry *AttributeRegistry) ResetRequired(attrs BiosAttributes) []string {
+	var result []string
+	for name := range attrs {
+		if attribute := attributeregistry.Attribute(name); attribute != nil && attribute.ResetRequired {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// AttributeValidationError is returned when proposed attribute values do not
+// satisfy the attribute registry. It holds every violation found.
+type AttributeValidationError struct {
+	// Violations describes each problem found, sorted by attribute name.
+	Violations []string
+}
+
+func (e *AttributeValidationError) Error() string {
+	return fmt.Sprintf("invalid attribute values: %s", strings.Join(e.Violations, "; "))
+}
+
+// ValidateAttributes checks the proposed attribute values against this
+// registry. current holds the attribute values currently in effect and is
+// used, together with the proposed values, to evaluate dependencies. All
+// violations are returned at once in an AttributeValidationError.
+func (attributeregistry *AttributeRegistry) ValidateAttributes(attrs BiosAttributes, current BiosAttributes) error {
+	// The values that will be in effect if the change is applied
+	effective := make(BiosAttributes, len(current)+len(attrs))
+	for name, value := range current {
+		effective[name] = value
+	}
+	for name, value := range attrs {
+		effective[name] = value
+	}
+
+	readOnly := attributeregistry.dependentReadOnly(effective)
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var violations []string
+	for _, name := range names {
+		attribute := attributeregistry.Attribute(name)
+		if attribute == nil {
+			violations = append(violations, fmt.Sprintf("%s: unknown attribute", name))
+			continue
+		}
+
+		if attribute.ReadOnly || attribute.Immutable || readOnly[name] {
+			violations = append(violations, fmt.Sprintf("%s: attribute is read only", name))
+			continue
+		}
+
+		if err := attribute.validateValue(attrs[name]); err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &AttributeValidationError{Violations: violations}
+	}
+
+	return nil
+}
+
+// validateValue checks a single value against the attribute definition.
+func (attribute *Attribute) validateValue(value interface{}) error {
+	switch attribute.Type {
+	case EnumerationAttributeType:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("value %v is not a string", value)
+		}
+		var allowed []string
+		for _, v := range attribute.Value {
+			if v.ValueName == s {
+				return nil
+			}
+			allowed = append(allowed, v.ValueName)
+		}
+		return fmt.Errorf("value %s is not one of %v", s, allowed)
+	case IntegerAttributeType:
+		n, ok := attributeNumber(value)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("value %v is not an integer", value)
+		}
+		if attribute.LowerBound != nil && n < float64(*attribute.LowerBound) {
+			return fmt.Errorf("value %v is lower than %d", value, *attribute.LowerBound)
+		}
+		if attribute.UpperBound != nil && n > float64(*attribute.UpperBound) {
+			return fmt.Errorf("value %v is greater than %d", value, *attribute.UpperBound)
+		}
+		if attribute.ScalarIncrement > 0 {
+			lower := 0
+			if attribute.LowerBound != nil {
+				lower = *attribute.LowerBound
+			}
+			if (int(n)-lower)%attribute.ScalarIncrement != 0 {
+				return fmt.Errorf("value %v is not a multiple of %d from %d", value, attribute.ScalarIncrement, lower)
+			}
+		}
+	case StringAttributeType, PasswordAttributeType:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("value %v is not a string", value)
+		}
+		if attribute.MinLength != nil && len(s) < *attribute.MinLength {
+			return fmt.Errorf("value is shorter than %d characters", *attribute.MinLength)
+		}
+		if attribute.MaxLength != nil && len(s) > *attribute.MaxLength {
+			return fmt.Errorf("value is longer than %d characters", *attribute.MaxLength)
+		}
+		if attribute.ValueExpression != "" {
+			// The expression applies to the whole value. Registries may use
+			// Perl syntax Go does not support, such as lookaheads, in which
+			// case the value is left for the service to check.
+			expression, err := regexp.Compile("^(?:" + attribute.ValueExpression + ")$")
+			if err == nil && !expression.MatchString(s) {
+				return fmt.Errorf("value does not match %s", attribute.ValueExpression)
+			}
+		}
+	case BooleanAttributeType:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("value %v is not a boolean", value)
+		}
+	}
+
+	return nil
+}
+
+// dependentReadOnly evaluates the registry dependencies against the provided
+// attribute values and returns the attributes they make read only.
+func (attributeregistry *AttributeRegistry) dependentReadOnly(values BiosAttributes) map[string]bool {
+	result := make(map[string]bool)
+	for _, dependency := range attributeregistry.RegistryEntries.Dependencies {
+		expression := dependency.Dependency
+		if dependency.Type != MapDependencyType || expression.MapToProperty != "ReadOnly" {
+			continue
+		}
+		if readOnly, ok := expression.MapToValue.(bool); !ok || !readOnly {
+			continue
+		}
+		if evaluateMapFrom(expression.MapFrom, values) {
+			result[expression.MapToAttribute] = true
+		}
+	}
+	return result
+}
+
+// evaluateMapFrom evaluates the conditions of a dependency in order, combining
+// them with their MapTerms.
+func evaluateMapFrom(conditions []MapFrom, values BiosAttributes) bool {
+	if len(conditions) == 0 {
+		return false
+	}
+
+	result := false
+	for i, condition := range conditions {
+		matched := evaluateCondition(condition, values)
+		if i == 0 {
+			result = matched
+			continue
+		}
+		if condition.MapTerms == ORMapTerms {
+			result = result || matched
+		} else {
+			result = result && matched
+		}
+	}
+	return result
+}
+
+// evaluateCondition evaluates a single dependency condition. Only conditions
+// on the CurrentValue of an attribute can be evaluated from attribute values.
+func evaluateCondition(condition MapFrom, values BiosAttributes) bool {
+	if condition.MapFromProperty != "" && condition.MapFromProperty != "CurrentValue" {
+		return false
+	}
+
+	value, ok := values[condition.MapFromAttribute]
+	if !ok {
+		return false
+	}
+
+	switch condition.MapFromCondition {
+	case EQUMapFromCondition:
+		return fmt.Sprintf("%v", value) == fmt.Sprintf("%v", condition.MapFromValue)
+	case NEQMapFromCondition:
+		return fmt.Sprintf("%v", value) != fmt.Sprintf("%v", condition.MapFromValue)
+	}
+
+	left, leftOK := attributeNumber(value)
+	right, rightOK := attributeNumber(condition.MapFromValue)
+	if !leftOK || !rightOK {
+		return false
+	}
+
+	switch condition.MapFromCondition {
+	case GTRMapFromCondition:
+		return left > right
+	case GEQMapFromCondition:
+		return left >= right
+	case LSSMapFromCondition:
+		return left < right
+	case LEQMapFromCondition:
+		return left <= right
+	}
+	return false
+}
+
+// attributeNumber converts a numeric attribute value to a float64.
+func attributeNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,259 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var attributeRegistryBody = `{
+		"@odata.type": "#AttributeRegistry.v1_1_0.AttributeRegistry",
+		"Id": "BiosAttributeRegistryP89.v1_0_0",
+		"Name": "BIOS Attribute Registry",
+		"Language": "en",
+		"Description": "This registry defines a representation of BIOS Attribute instances",
+		"OwningEntity": "Contoso",
+		"RegistryVersion": "1.0.0",
+		"SupportedSystems": [
+			{
+				"ProductName": "Contoso Server GLH",
+				"SystemId": "P89",
+				"FirmwareVersion": "v1.00 (06/02/2014)"
+			}
+		],
+		"RegistryEntries": {
+			"Attributes": [
+				{
+					"AttributeName": "ProcTurboMode",
+					"Type": "Enumeration",
+					"ResetRequired": true,
+					"Value": [
+						{"ValueDisplayName": "Enabled", "ValueName": "Enabled"},
+						{"ValueDisplayName": "Disabled", "ValueName": "Disabled"}
+					]
+				},
+				{
+					"AttributeName": "ProcCoreDisable",
+					"Type": "Integer",
+					"LowerBound": 0,
+					"UpperBound": 8,
+					"ScalarIncrement": 2
+				},
+				{
+					"AttributeName": "AdminPhone",
+					"Type": "String",
+					"MaxLength": 8,
+					"ValueExpression": "^[0-9-]*$"
+				},
+				{
+					"AttributeName": "AssetTag",
+					"Type": "String",
+					"ValueExpression": "[A-Z]{3}[0-9]*"
+				},
+				{
+					"AttributeName": "OemTag",
+					"Type": "String",
+					"ValueExpression": "(?=[A-Z])\\w+"
+				},
+				{
+					"AttributeName": "SerialNumber",
+					"Type": "String",
+					"ReadOnly": true
+				},
+				{
+					"AttributeName": "EmbeddedSata",
+					"Type": "Enumeration",
+					"Value": [
+						{"ValueDisplayName": "AHCI", "ValueName": "Ahci"},
+						{"ValueDisplayName": "RAID", "ValueName": "Raid"}
+					]
+				},
+				{
+					"AttributeName": "BootMode",
+					"Type": "Enumeration",
+					"Value": [
+						{"ValueDisplayName": "UEFI", "ValueName": "Uefi"},
+						{"ValueDisplayName": "Legacy", "ValueName": "Bios"}
+					]
+				}
+			],
+			"Dependencies": [
+				{
+					"DependencyFor": "EmbeddedSata",
+					"Type": "Map",
+					"Dependency": {
+						"MapFrom": [
+							{
+								"MapFromAttribute": "BootMode",
+								"MapFromProperty": "CurrentValue",
+								"MapFromCondition": "EQU",
+								"MapFromValue": "Bios"
+							}
+						],
+						"MapToAttribute": "EmbeddedSata",
+						"MapToProperty": "ReadOnly",
+						"MapToValue": true
+					}
+				}
+			]
+		}
+	}`
+
+var registriesBody = `{
+		"@odata.id": "/redfish/v1/Registries",
+		"Name": "Registry File Collection",
+		"Members@odata.count": 2,
+		"Members": [
+			{"@odata.id": "/redfish/v1/Registries/Base.1.0.0"},
+			{"@odata.id": "/redfish/v1/Registries/BiosAttributeRegistryP89.v1_0_0"}
+		]
+	}`
+
+// TestAttributeRegistry tests the parsing of AttributeRegistry objects.
+func TestAttributeRegistry(t *testing.T) {
+	var result AttributeRegistry
+	err := json.NewDecoder(strings.NewReader(attributeRegistryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "BiosAttributeRegistryP89.v1_0_0" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if len(result.RegistryEntries.Attributes) != 8 {
+		t.Errorf("Invalid number of attributes: %d", len(result.RegistryEntries.Attributes))
+	}
+
+	attribute := result.Attribute("ProcCoreDisable")
+	if attribute == nil {
+		t.Fatal("ProcCoreDisable attribute not found")
+	}
+
+	if attribute.Type != IntegerAttributeType {
+		t.Errorf("Invalid attribute type: %s", attribute.Type)
+	}
+
+	if attribute.UpperBound == nil || *attribute.UpperBound != 8 {
+		t.Errorf("Invalid upper bound: %v", attribute.UpperBound)
+	}
+
+	if attribute.MaxLength != nil {
+		t.Errorf("Missing MaxLength should be nil: %v", *attribute.MaxLength)
+	}
+
+	if result.SupportedSystems[0].SystemID != "P89" {
+		t.Errorf("Invalid supported system: %s", result.SupportedSystems[0].SystemID)
+	}
+
+	reset := result.ResetRequired(BiosAttributes{"ProcTurboMode": "Disabled", "AdminPhone": "1"})
+	if len(reset) != 1 || reset[0] != "ProcTurboMode" {
+		t.Errorf("Unexpected reset required attributes: %v", reset)
+	}
+}
+
+// TestAttributeRegistryValidateAttributes tests the ValidateAttributes call.
+func TestAttributeRegistryValidateAttributes(t *testing.T) {
+	var result AttributeRegistry
+	err := json.NewDecoder(strings.NewReader(attributeRegistryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	current := BiosAttributes{"BootMode": "Uefi", "EmbeddedSata": "Raid"}
+
+	err = result.ValidateAttributes(BiosAttributes{
+		"ProcTurboMode":   "Disabled",
+		"ProcCoreDisable": float64(4),
+		"AdminPhone":      "555-1234",
+		"AssetTag":        "ABC123",
+		"EmbeddedSata":    "Ahci",
+	}, current)
+	if err != nil {
+		t.Errorf("Unexpected validation error: %s", err)
+	}
+
+	err = result.ValidateAttributes(BiosAttributes{
+		"ProcTurboMode":   "Maybe",
+		"ProcCoreDisable": float64(3),
+		"AdminPhone":      "call me",
+		"AssetTag":        "xABC123",
+		"OemTag":          "a-1", // Left to the service, Go does not support lookaheads.
+		"SerialNumber":    "1234",
+		"Unknown":         true,
+		"BootMode":        "Bios",
+		"EmbeddedSata":    "Ahci",
+	}, current)
+
+	validationError, ok := err.(*AttributeValidationError)
+	if !ok {
+		t.Fatalf("Expected AttributeValidationError, got: %v", err)
+	}
+
+	expected := []string{
+		"AdminPhone: value does not match ^[0-9-]*$",
+		"AssetTag: value does not match [A-Z]{3}[0-9]*",
+		"EmbeddedSata: attribute is read only",
+		"ProcCoreDisable: value 3 is not a multiple of 2 from 0",
+		"ProcTurboMode: value Maybe is not one of [Enabled Disabled]",
+		"SerialNumber: attribute is read only",
+		"Unknown: unknown attribute",
+	}
+
+	if len(validationError.Violations) != len(expected) {
+		t.Fatalf("Unexpected violations: %v", validationError.Violations)
+	}
+
+	for i := range expected {
+		if validationError.Violations[i] != expected[i] {
+			t.Errorf("Unexpected violation: %s, expected: %s", validationError.Violations[i], expected[i])
+		}
+	}
+}
+
+// TestFindAttributeRegistry tests the FindAttributeRegistry call.
+func TestFindAttributeRegistry(t *testing.T) {
+	var responses []interface{}
+	for _, body := range []string{registriesBody, baseRegistryFileBody, messageRegistryFileBody, attributeRegistryBody} {
+		responses = append(responses, &http.Response{
+			Status:     "200 OK",
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			Header:     make(http.Header),
+		})
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: responses,
+		},
+	}
+
+	result, err := FindAttributeRegistry(context.Background(), testClient,
+		"/redfish/v1/Registries", "BiosAttributeRegistryP89.v1_0_0")
+	if err != nil {
+		t.Fatalf("Error making FindAttributeRegistry call: %s", err)
+	}
+
+	if result.ID != "BiosAttributeRegistryP89.v1_0_0" {
+		t.Errorf("Received invalid registry: %s", result.ID)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[3].URL != "/redfish/v1/Registries/BiosAttributeRegistryP89.v1_0_0/BiosAttributeRegistryP89.json" {
+		t.Errorf("Unexpected registry URL: %s", calls[3].URL)
+	}
+}
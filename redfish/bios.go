@@ -85,6 +85,8 @@ type Bios struct {
 	// settingsApplyTimes is a set of allowed settings update apply times. If none
 	// are specified, then the system does not provide that information.
 	settingsApplyTimes []common.ApplyTime
+	// settings is the @Redfish.Settings annotation of this resource.
+	settings common.Settings
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -112,6 +114,7 @@ func (bios *Bios) UnmarshalJSON(b []byte) error {
 	}
 
 	*bios = Bios(t.temp)
+	bios.settings = t.Settings
 
 	// Extract the links to other entities for later
 	bios.changePasswordTarget = t.Actions.ChangePassword.Target
@@ -250,3 +253,32 @@ func (bios *Bios) UpdateBiosAttributes(ctx context.Context, attrs BiosAttributes
 
 	return nil
 }
+
+// PendingSettings gives access to the settings that will be applied to this
+// BIOS, following the @Redfish.Settings pattern.
+func (bios *Bios) PendingSettings() *common.PendingSettings {
+	return common.NewPendingSettings(bios.Client, bios.ODataID, bios.settings, bios.rawData)
+}
+
+// ValidateBiosAttributes checks the proposed attribute values against the
+// provided attribute registry, which should be the one named by the
+// AttributeRegistry property. All violations are returned at once in an
+// AttributeValidationError.
+func (bios *Bios) ValidateBiosAttributes(registry *AttributeRegistry, attrs BiosAttributes) error {
+	if registry == nil {
+		return fmt.Errorf("attribute registry must be supplied")
+	}
+
+	return registry.ValidateAttributes(attrs, bios.Attributes)
+}
+
+// UpdateBiosAttributesWithValidation validates the attribute values against
+// the provided attribute registry and only updates them if they are all
+// valid.
+func (bios *Bios) UpdateBiosAttributesWithValidation(ctx context.Context, registry *AttributeRegistry, attrs BiosAttributes) error {
+	if err := bios.ValidateBiosAttributes(registry, attrs); err != nil {
+		return err
+	}
+
+	return bios.UpdateBiosAttributes(ctx, attrs)
+}
@@ -206,3 +206,46 @@ func TestUpdateBiosAttributes(t *testing.T) {
 		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestUpdateBiosAttributesWithValidation tests the
+// UpdateBiosAttributesWithValidation call.
+func TestUpdateBiosAttributesWithValidation(t *testing.T) {
+	var result Bios
+	err := json.NewDecoder(strings.NewReader(biosBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	var registry AttributeRegistry
+	err = json.NewDecoder(strings.NewReader(attributeRegistryBody)).Decode(&registry)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.UpdateBiosAttributesWithValidation(context.Background(), &registry,
+		BiosAttributes{"ProcTurboMode": "Off"})
+	if err == nil {
+		t.Error("Expected validation error")
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Invalid attributes should not be sent: %v", testClient.CapturedCalls())
+	}
+
+	err = result.UpdateBiosAttributesWithValidation(context.Background(), &registry,
+		BiosAttributes{"ProcTurboMode": "Disabled"})
+	if err != nil {
+		t.Errorf("Error making UpdateBiosAttributesWithValidation call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "ProcTurboMode:Disabled") {
+		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
+	}
+}
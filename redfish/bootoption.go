@@ -0,0 +1,141 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// BootOption represents a single boot option (for example an UEFI
+// Boot#### variable) of a computer system.
+type BootOption struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Alias shall contain the string alias of this boot source that
+	// describes the type of boot.
+	Alias BootSourceOverrideTarget
+	// BootOptionEnabled shall indicate whether the boot option is enabled.
+	// If true, it is enabled. If false, the boot option that the boot order
+	// array on the computer system contains shall be skipped.
+	BootOptionEnabled bool
+	// BootOptionReference shall correspond to the boot option or device. For
+	// UEFI systems, this string shall match the UEFI boot option variable
+	// name, such as Boot####. The BootOrder array of a computer system
+	// resource contains this value.
+	BootOptionReference string
+	// Description provides a description of this resource.
+	Description string
+	// DisplayName shall contain a user-readable boot option name, as it
+	// should appear in the boot order list in the user interface.
+	DisplayName string
+	// relatedItem shall contain an array of links to resources or objects
+	// associated with this boot option.
+	relatedItem []string
+	// RelatedItemCount is the number of related items.
+	RelatedItemCount int `json:"RelatedItem@odata.count"`
+	// UefiDevicePath shall contain the UEFI Specification-defined UEFI
+	// device path that identifies and locates the device for this boot
+	// option.
+	UefiDevicePath string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals a BootOption object from the raw JSON.
+func (bootoption *BootOption) UnmarshalJSON(b []byte) error {
+	type temp BootOption
+	var t struct {
+		temp
+		RelatedItem common.Links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*bootoption = BootOption(t.temp)
+
+	// Extract the links to other entities for later
+	bootoption.relatedItem = t.RelatedItem.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	bootoption.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (bootoption *BootOption) Update(ctx context.Context) error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(BootOption)
+	original.UnmarshalJSON(bootoption.rawData)
+
+	readWriteFields := []string{
+		"BootOptionEnabled",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(bootoption).Elem()
+
+	return bootoption.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+}
+
+// GetBootOption will get a BootOption instance from the service.
+func GetBootOption(ctx context.Context, c common.Client, uri string) (*BootOption, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var bootoption BootOption
+	err = json.NewDecoder(resp.Body).Decode(&bootoption)
+	if err != nil {
+		return nil, err
+	}
+
+	bootoption.SetClient(c)
+	return &bootoption, nil
+}
+
+// ListReferencedBootOptions gets the collection of BootOption from
+// a provided reference.
+func ListReferencedBootOptions(ctx context.Context, c common.Client, link string) ([]*BootOption, error) {
+	var result []*BootOption
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(ctx, c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, bootoptionLink := range links.ItemLinks {
+		bootoption, err := GetBootOption(ctx, c, bootoptionLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, bootoption)
+	}
+
+	return result, nil
+}
+
+// RelatedItem gets the URIs of the resources associated with this boot
+// option, such as the network interface or drive it boots from.
+func (bootoption *BootOption) RelatedItem() []string {
+	return bootoption.relatedItem
+}
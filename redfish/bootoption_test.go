@@ -0,0 +1,94 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var bootOptionBody = `{
+		"@odata.context": "/redfish/v1/$metadata#BootOption.BootOption",
+		"@odata.id": "/redfish/v1/Systems/1/BootOptions/1",
+		"@odata.type": "#BootOption.v1_0_1.BootOption",
+		"Id": "1",
+		"Name": "Boot Option",
+		"Description": "UEFI Boot Option",
+		"BootOptionReference": "Boot0003",
+		"DisplayName": "PXE IPv4 NIC 1",
+		"UefiDevicePath": "PciRoot(0x0)/Pci(0x1C,0x0)/Pci(0x0,0x0)/MAC(0CC47A000001,0x1)/IPv4(0.0.0.0)",
+		"Alias": "Pxe",
+		"BootOptionEnabled": true,
+		"RelatedItem": [
+			{
+				"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"
+			}
+		],
+		"RelatedItem@odata.count": 1
+	}`
+
+// TestBootOption tests the parsing of BootOption objects.
+func TestBootOption(t *testing.T) {
+	var result BootOption
+	err := json.NewDecoder(strings.NewReader(bootOptionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.BootOptionReference != "Boot0003" {
+		t.Errorf("Received invalid BootOptionReference: %s", result.BootOptionReference)
+	}
+
+	if result.DisplayName != "PXE IPv4 NIC 1" {
+		t.Errorf("Received invalid DisplayName: %s", result.DisplayName)
+	}
+
+	if result.Alias != PxeBootSourceOverrideTarget {
+		t.Errorf("Received invalid Alias: %s", result.Alias)
+	}
+
+	if !result.BootOptionEnabled {
+		t.Error("BootOptionEnabled should be true")
+	}
+
+	if len(result.RelatedItem()) != 1 {
+		t.Errorf("Invalid number of related items: %d", len(result.RelatedItem()))
+	}
+}
+
+// TestBootOptionUpdate tests the Update call.
+func TestBootOptionUpdate(t *testing.T) {
+	var result BootOption
+	err := json.NewDecoder(strings.NewReader(bootOptionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.BootOptionEnabled = false
+	err = result.Update(context.Background())
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "BootOptionEnabled:false") {
+		t.Errorf("Unexpected BootOptionEnabled update payload: %s", calls[0].Payload)
+	}
+}
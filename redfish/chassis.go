@@ -216,13 +216,17 @@ type Chassis struct {
 	WeightKg float64
 	// WidthMm shall represent the width of the chassis, in
 	// millimeters, as specified by the manufacturer.
-	WidthMm         float64
-	thermal         string
-	power           string
-	networkAdapters string
-	computerSystems []string
-	resourceBlocks  []string
-	managedBy       []string
+	WidthMm            float64
+	thermal            string
+	power              string
+	thermalSubsystem   string
+	powerSubsystem     string
+	sensors            string
+	environmentMetrics string
+	networkAdapters    string
+	computerSystems    []string
+	resourceBlocks     []string
+	managedBy          []string
 	// resetTarget is the internal URL to send reset actions to.
 	resetTarget string
 	// SupportedResetTypes, if provided, is the reset types this chassis supports.
@@ -248,11 +252,15 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 
 	var t struct {
 		temp
-		Thermal         common.Link
-		Power           common.Link
-		NetworkAdapters common.Link
-		Links           linkReference
-		Actions         Actions
+		Thermal            common.Link
+		Power              common.Link
+		ThermalSubsystem   common.Link
+		PowerSubsystem     common.Link
+		Sensors            common.Link
+		EnvironmentMetrics common.Link
+		NetworkAdapters    common.Link
+		Links              linkReference
+		Actions            Actions
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -265,6 +273,10 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	chassis.thermal = string(t.Thermal)
 	chassis.power = string(t.Power)
+	chassis.thermalSubsystem = string(t.ThermalSubsystem)
+	chassis.powerSubsystem = string(t.PowerSubsystem)
+	chassis.sensors = string(t.Sensors)
+	chassis.environmentMetrics = string(t.EnvironmentMetrics)
 	chassis.networkAdapters = string(t.NetworkAdapters)
 	chassis.computerSystems = t.Links.ComputerSystems.ToStrings()
 	chassis.resourceBlocks = t.Links.ResourceBlocks.ToStrings()
@@ -340,19 +352,7 @@ func (chassis *Chassis) Thermal(ctx context.Context) (*Thermal, error) {
 		return nil, nil
 	}
 
-	resp, err := chassis.Client.Get(ctx, chassis.thermal)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var thermal Thermal
-	err = json.NewDecoder(resp.Body).Decode(&thermal)
-	if err != nil {
-		return nil, err
-	}
-
-	return &thermal, nil
+	return GetThermal(ctx, chassis.Client, chassis.thermal)
 }
 
 // Power gets the power information for the chassis
@@ -361,19 +361,41 @@ func (chassis *Chassis) Power(ctx context.Context) (*Power, error) {
 		return nil, nil
 	}
 
-	resp, err := chassis.Client.Get(ctx, chassis.power)
-	if err != nil {
-		return nil, err
+	return GetPower(ctx, chassis.Client, chassis.power)
+}
+
+// ThermalSubsystem gets the thermal subsystem of the chassis, which replaces
+// the Thermal resource on newer services.
+func (chassis *Chassis) ThermalSubsystem(ctx context.Context) (*ThermalSubsystem, error) {
+	if chassis.thermalSubsystem == "" {
+		return nil, nil
 	}
-	defer resp.Body.Close()
 
-	var power Power
-	err = json.NewDecoder(resp.Body).Decode(&power)
-	if err != nil {
-		return nil, err
+	return GetThermalSubsystem(ctx, chassis.Client, chassis.thermalSubsystem)
+}
+
+// PowerSubsystem gets the power subsystem of the chassis, which replaces the
+// Power resource on newer services.
+func (chassis *Chassis) PowerSubsystem(ctx context.Context) (*PowerSubsystem, error) {
+	if chassis.powerSubsystem == "" {
+		return nil, nil
+	}
+
+	return GetPowerSubsystem(ctx, chassis.Client, chassis.powerSubsystem)
+}
+
+// Sensors gets the collection of sensors located in the chassis.
+func (chassis *Chassis) Sensors(ctx context.Context) ([]*Sensor, error) {
+	return ListReferencedSensors(ctx, chassis.Client, chassis.sensors)
+}
+
+// EnvironmentMetrics gets the environmental metrics of the chassis.
+func (chassis *Chassis) EnvironmentMetrics(ctx context.Context) (*EnvironmentMetrics, error) {
+	if chassis.environmentMetrics == "" {
+		return nil, nil
 	}
 
-	return &power, nil
+	return GetEnvironmentMetrics(ctx, chassis.Client, chassis.environmentMetrics)
 }
 
 // ComputerSystems returns the collection of systems from this chassis
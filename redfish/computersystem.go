@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/jacobweinstock/gophish/common"
 )
@@ -323,6 +324,15 @@ type Boot struct {
 	// one time boot only. Changes to this property do not alter the BIOS
 	// persistent boot order configuration.
 	UefiTargetBootSourceOverride string `json:",omitempty"`
+	// HTTPBootURI shall contain the URI to perform an HTTP or HTTPS boot
+	// when BootSourceOverrideTarget is set to UefiHttp.
+	HTTPBootURI string `json:"HttpBootUri,omitempty"`
+	// AllowedBootSourceOverrideTargets, if provided, is the list of boot
+	// source override targets this system supports.
+	AllowedBootSourceOverrideTargets []BootSourceOverrideTarget `json:"-"`
+	// AllowedBootSourceOverrideModes, if provided, is the list of boot source
+	// override modes this system supports.
+	AllowedBootSourceOverrideModes []BootSourceOverrideMode `json:"-"`
 }
 
 // UnmarshalJSON unmarshals a Boot object from the raw JSON.
@@ -330,7 +340,9 @@ func (boot *Boot) UnmarshalJSON(b []byte) error {
 	type temp Boot
 	var t struct {
 		temp
-		BootOptions common.Link
+		BootOptions                      common.Link
+		AllowedBootSourceOverrideTargets []BootSourceOverrideTarget `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
+		AllowedBootSourceOverrideModes   []BootSourceOverrideMode   `json:"BootSourceOverrideMode@Redfish.AllowableValues"`
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -342,6 +354,8 @@ func (boot *Boot) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	boot.bootOptions = string(t.BootOptions)
+	boot.AllowedBootSourceOverrideTargets = t.AllowedBootSourceOverrideTargets
+	boot.AllowedBootSourceOverrideModes = t.AllowedBootSourceOverrideModes
 
 	return nil
 }
@@ -506,6 +520,8 @@ type ComputerSystem struct {
 	SupportedResetTypes []ResetType
 	// setDefaultBootOrderTarget is the URL to send SetDefaultBootOrder actions to.
 	setDefaultBootOrderTarget string
+	// settings is the @Redfish.Settings annotation of this resource.
+	settings common.Settings
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -525,6 +541,7 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 	type temp ComputerSystem
 	var t struct {
 		temp
+		Settings           common.Settings `json:"@Redfish.Settings"`
 		Actions            CSActions
 		Bios               common.Link
 		Processors         common.Link
@@ -547,6 +564,7 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 	}
 
 	*computersystem = ComputerSystem(t.temp)
+	computersystem.settings = t.Settings
 
 	// Extract the links to other entities for later
 	computersystem.bios = string(t.Bios)
@@ -704,8 +722,115 @@ func (computersystem *ComputerSystem) SecureBoot(ctx context.Context) (*SecureBo
 	return GetSecureBoot(ctx, computersystem.Client, computersystem.secureBoot)
 }
 
-// SetBoot set a boot object based on a payload request
+// SetBoot set a boot object based on a payload request. The boot source
+// override target and mode are validated against the values the system
+// allows before anything is sent.
 func (computersystem *ComputerSystem) SetBoot(ctx context.Context, b Boot) error {
+	if err := computersystem.validateBoot(b); err != nil {
+		return err
+	}
+
+	return computersystem.patchBoot(ctx, b)
+}
+
+// SetBootSequential sets the same properties as SetBoot, but for BMCs that
+// reject changing several boot override properties in one request. The
+// override mode and any other properties are sent first, then the override
+// target with its related properties, then BootSourceOverrideEnabled.
+func (computersystem *ComputerSystem) SetBootSequential(ctx context.Context, b Boot) error {
+	if err := computersystem.validateBoot(b); err != nil {
+		return err
+	}
+
+	target := Boot{
+		BootNext:                     b.BootNext,
+		BootSourceOverrideTarget:     b.BootSourceOverrideTarget,
+		HTTPBootURI:                  b.HTTPBootURI,
+		UefiTargetBootSourceOverride: b.UefiTargetBootSourceOverride,
+	}
+	enabled := Boot{
+		BootSourceOverrideEnabled: b.BootSourceOverrideEnabled,
+	}
+
+	other := b
+	other.BootNext = ""
+	other.BootSourceOverrideTarget = ""
+	other.HTTPBootURI = ""
+	other.UefiTargetBootSourceOverride = ""
+	other.BootSourceOverrideEnabled = ""
+
+	for _, step := range []Boot{other, target, enabled} {
+		// Steps without any property to send are skipped, the allowable
+		// values copied from the current settings are not sent.
+		payload, err := json.Marshal(step)
+		if err != nil {
+			return err
+		}
+		if string(payload) == "{}" {
+			continue
+		}
+		if err := computersystem.patchBoot(ctx, step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetHTTPBoot sets the system to boot from the provided HTTP(S) URI using UEFI
+// HTTP boot. enabled controls whether this is a one time or continuous
+// override.
+func (computersystem *ComputerSystem) SetHTTPBoot(ctx context.Context, uri string, enabled BootSourceOverrideEnabled) error {
+	return computersystem.SetBoot(ctx, Boot{
+		BootSourceOverrideEnabled: enabled,
+		BootSourceOverrideTarget:  UefiHTTPBootSourceOverrideTarget,
+		HTTPBootURI:               uri,
+	})
+}
+
+// validateBoot makes sure the boot override settings are supported by the
+// system.
+func (computersystem *ComputerSystem) validateBoot(b Boot) error {
+	if b.BootSourceOverrideTarget != "" && len(computersystem.Boot.AllowedBootSourceOverrideTargets) > 0 {
+		valid := false
+		for _, allowed := range computersystem.Boot.AllowedBootSourceOverrideTargets {
+			if b.BootSourceOverrideTarget == allowed {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("boot source override target '%s' is not supported by this system, allowed values are %v",
+				b.BootSourceOverrideTarget, computersystem.Boot.AllowedBootSourceOverrideTargets)
+		}
+	}
+
+	if b.BootSourceOverrideMode != "" && len(computersystem.Boot.AllowedBootSourceOverrideModes) > 0 {
+		valid := false
+		for _, allowed := range computersystem.Boot.AllowedBootSourceOverrideModes {
+			if b.BootSourceOverrideMode == allowed {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("boot source override mode '%s' is not supported by this system, allowed values are %v",
+				b.BootSourceOverrideMode, computersystem.Boot.AllowedBootSourceOverrideModes)
+		}
+	}
+
+	if b.HTTPBootURI != "" && b.BootSourceOverrideTarget != UefiHTTPBootSourceOverrideTarget {
+		return fmt.Errorf("HttpBootUri requires the boot source override target to be '%s'",
+			UefiHTTPBootSourceOverrideTarget)
+	}
+
+	return nil
+}
+
+// patchBoot sends the boot properties to the system.
+func (computersystem *ComputerSystem) patchBoot(ctx context.Context, b Boot) error {
 	type temp struct {
 		Boot Boot
 	}
@@ -717,6 +842,128 @@ func (computersystem *ComputerSystem) SetBoot(ctx context.Context, b Boot) error
 	return err
 }
 
+// BootOptions gets the boot options of this system.
+func (computersystem *ComputerSystem) BootOptions(ctx context.Context) ([]*BootOption, error) {
+	return ListReferencedBootOptions(ctx, computersystem.Client, computersystem.Boot.bootOptions)
+}
+
+// SetBootOrder sets the persistent boot order of this system to the provided
+// BootOptionReference values.
+func (computersystem *ComputerSystem) SetBootOrder(ctx context.Context, bootOrder []string) error {
+	if len(bootOrder) == 0 {
+		return fmt.Errorf("boot order should not be empty")
+	}
+
+	return computersystem.SetBoot(ctx, Boot{BootOrder: bootOrder})
+}
+
+// SetBootOrderByDisplayName moves the boot options with the provided display
+// names, in the provided order, to the front of the persistent boot order.
+// The remaining boot options keep their relative order.
+func (computersystem *ComputerSystem) SetBootOrderByDisplayName(ctx context.Context, displayNames ...string) error {
+	return computersystem.reorderBootOptions(ctx, displayNames, func(option *BootOption, displayName string) bool {
+		return option.DisplayName == displayName
+	})
+}
+
+// SetBootOrderByDevicePath moves the boot options whose UEFI device path
+// starts with the provided device paths, in the provided order, to the front
+// of the persistent boot order. The remaining boot options keep their
+// relative order.
+func (computersystem *ComputerSystem) SetBootOrderByDevicePath(ctx context.Context, devicePaths ...string) error {
+	return computersystem.reorderBootOptions(ctx, devicePaths, func(option *BootOption, devicePath string) bool {
+		return option.UefiDevicePath != "" && strings.HasPrefix(option.UefiDevicePath, devicePath)
+	})
+}
+
+// reorderBootOptions moves the boot options matching keys to the front of the
+// current boot order and sends the result to the system.
+func (computersystem *ComputerSystem) reorderBootOptions(ctx context.Context, keys []string, match func(*BootOption, string) bool) error {
+	options, err := computersystem.BootOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	bootOrder, err := reorderBootOrder(computersystem.Boot.BootOrder, options, keys, match)
+	if err != nil {
+		return err
+	}
+
+	return computersystem.SetBootOrder(ctx, bootOrder)
+}
+
+// reorderBootOrder computes a new boot order where the boot options matching
+// keys come first, in the order of keys, followed by the rest of the current
+// boot order.
+func reorderBootOrder(current []string, options []*BootOption, keys []string, match func(*BootOption, string) bool) ([]string, error) {
+	var result []string
+	seen := make(map[string]bool)
+
+	for _, key := range keys {
+		found := false
+		for _, option := range options {
+			if !match(option, key) {
+				continue
+			}
+			found = true
+			if !seen[option.BootOptionReference] {
+				seen[option.BootOptionReference] = true
+				result = append(result, option.BootOptionReference)
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("no boot option matches '%s'", key)
+		}
+	}
+
+	for _, reference := range current {
+		if !seen[reference] {
+			seen[reference] = true
+			result = append(result, reference)
+		}
+	}
+
+	return result, nil
+}
+
+// SetBootNext sets the boot option to use for the next boot only. The
+// reference must be the BootOptionReference of one of the system's boot
+// options.
+func (computersystem *ComputerSystem) SetBootNext(ctx context.Context, bootOptionReference string) error {
+	valid := false
+	if computersystem.Boot.bootOptions != "" {
+		options, err := computersystem.BootOptions(ctx)
+		if err != nil {
+			return err
+		}
+		for _, option := range options {
+			if option.BootOptionReference == bootOptionReference {
+				valid = true
+				break
+			}
+		}
+	} else {
+		// No boot option collection, the valid values are those in BootOrder
+		for _, reference := range computersystem.Boot.BootOrder {
+			if reference == bootOptionReference {
+				valid = true
+				break
+			}
+		}
+	}
+
+	if !valid {
+		return fmt.Errorf("boot option '%s' is not known to this system", bootOptionReference)
+	}
+
+	return computersystem.SetBoot(ctx, Boot{
+		BootNext:                  bootOptionReference,
+		BootSourceOverrideEnabled: OnceBootSourceOverrideEnabled,
+		BootSourceOverrideTarget:  UefiBootNextBootSourceOverrideTarget,
+	})
+}
+
 // Reset shall perform a reset of the ComputerSystem. For systems which implement
 // ACPI Power Button functionality, the PushPowerButton value shall perform or
 // emulate an ACPI Power Button push. The ForceOff value shall remove power from
@@ -897,3 +1144,9 @@ type WatchdogTimer struct {
 	// is dependent on the implementation.
 	WarningAction string
 }
+
+// PendingSettings gives access to the settings that will be applied to this
+// system, following the @Redfish.Settings pattern.
+func (computersystem *ComputerSystem) PendingSettings() *common.PendingSettings {
+	return common.NewPendingSettings(computersystem.Client, computersystem.ODataID, computersystem.settings, computersystem.rawData)
+}
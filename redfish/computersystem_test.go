@@ -5,8 +5,11 @@
 package redfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -52,9 +55,30 @@ var computerSystemBody = `{
 				"Diags",
 				"UefiTarget",
 				"SDCard",
-				"UefiHttp"
+				"UefiHttp",
+				"UefiBootNext"
 			],
-			"UefiTargetBootSourceOverride": "uefi device path"
+			"BootSourceOverrideMode@Redfish.AllowableValues": [
+				"UEFI"
+			],
+			"UefiTargetBootSourceOverride": "uefi device path",
+			"BootOrder": [
+				"Boot0001",
+				"Boot0002",
+				"Boot0003"
+			],
+			"BootOptions": {
+				"@odata.id": "/redfish/v1/Systems/System-1/BootOptions"
+			}
+		},
+		"@Redfish.Settings": {
+			"@odata.type": "#Settings.v1_0_0.Settings",
+			"SettingsObject": {
+				"@odata.id": "/redfish/v1/Systems/System-1/Settings"
+			},
+			"SupportedApplyTimes": [
+				"OnReset"
+			]
 		},
 		"BiosVersion": "P79 v1.00 (09/20/2013)",
 		"ProcessorSummary": {
@@ -182,6 +206,16 @@ func TestComputerSystem(t *testing.T) {
 		t.Errorf("Received invalid uefi target boot source: %s", result.Boot.UefiTargetBootSourceOverride)
 	}
 
+	if len(result.Boot.AllowedBootSourceOverrideTargets) != 13 {
+		t.Errorf("Received invalid number of allowed boot targets: %d",
+			len(result.Boot.AllowedBootSourceOverrideTargets))
+	}
+
+	if len(result.Boot.AllowedBootSourceOverrideModes) != 1 {
+		t.Errorf("Received invalid number of allowed boot modes: %d",
+			len(result.Boot.AllowedBootSourceOverrideModes))
+	}
+
 	if result.ProcessorSummary.Status.State != common.EnabledState {
 		t.Errorf("Received invalid processor summary state: %s", result.ProcessorSummary.Status.State)
 	}
@@ -286,3 +320,344 @@ func TestComputerSystemUpdate(t *testing.T) {
 		t.Errorf("Unexpected IndicatorLED update payload: %s", calls[0].Payload)
 	}
 }
+
+var bootOptionsBody = `{
+		"@odata.id": "/redfish/v1/Systems/System-1/BootOptions",
+		"Name": "Boot Options",
+		"Members@odata.count": 3,
+		"Members": [
+			{"@odata.id": "/redfish/v1/Systems/System-1/BootOptions/1"},
+			{"@odata.id": "/redfish/v1/Systems/System-1/BootOptions/2"},
+			{"@odata.id": "/redfish/v1/Systems/System-1/BootOptions/3"}
+		]
+	}`
+
+// bootOptionResponses returns the GET responses needed to list the boot
+// options of the test system.
+func bootOptionResponses() []interface{} {
+	bodies := []string{
+		bootOptionsBody,
+		`{"@odata.id": "/redfish/v1/Systems/System-1/BootOptions/1", "Id": "1",
+			"BootOptionReference": "Boot0001", "DisplayName": "Hard Disk",
+			"UefiDevicePath": "PciRoot(0x0)/Pci(0x1F,0x2)/Sata(0x0,0xFFFF,0x0)"}`,
+		`{"@odata.id": "/redfish/v1/Systems/System-1/BootOptions/2", "Id": "2",
+			"BootOptionReference": "Boot0002", "DisplayName": "UEFI Shell"}`,
+		`{"@odata.id": "/redfish/v1/Systems/System-1/BootOptions/3", "Id": "3",
+			"BootOptionReference": "Boot0003", "DisplayName": "PXE IPv4 NIC 1",
+			"UefiDevicePath": "PciRoot(0x0)/Pci(0x1C,0x0)/Pci(0x0,0x0)/MAC(0CC47A000001,0x1)/IPv4(0.0.0.0)"}`,
+	}
+
+	var responses []interface{}
+	for _, body := range bodies {
+		responses = append(responses, &http.Response{
+			Status:     "200 OK",
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			Header:     make(http.Header),
+		})
+	}
+	return responses
+}
+
+// TestComputerSystemSetBootOrderByDisplayName tests the
+// SetBootOrderByDisplayName call.
+func TestComputerSystemSetBootOrderByDisplayName(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: bootOptionResponses(),
+		},
+	}
+	result.SetClient(testClient)
+
+	err = result.SetBootOrderByDisplayName(context.Background(), "PXE IPv4 NIC 1")
+	if err != nil {
+		t.Errorf("Error making SetBootOrderByDisplayName call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	patch := calls[len(calls)-1]
+
+	if patch.Action != http.MethodPatch {
+		t.Errorf("Expected a PATCH call, got: %s", patch.Action)
+	}
+
+	if !strings.Contains(patch.Payload, "BootOrder:[Boot0003 Boot0001 Boot0002]") {
+		t.Errorf("Unexpected BootOrder payload: %s", patch.Payload)
+	}
+}
+
+// TestComputerSystemSetBootOrderByDevicePath tests the
+// SetBootOrderByDevicePath call.
+func TestComputerSystemSetBootOrderByDevicePath(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: bootOptionResponses(),
+		},
+	}
+	result.SetClient(testClient)
+
+	err = result.SetBootOrderByDevicePath(context.Background(),
+		"PciRoot(0x0)/Pci(0x1C,0x0)", "PciRoot(0x0)/Pci(0x1F,0x2)")
+	if err != nil {
+		t.Errorf("Error making SetBootOrderByDevicePath call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	patch := calls[len(calls)-1]
+
+	if !strings.Contains(patch.Payload, "BootOrder:[Boot0003 Boot0001 Boot0002]") {
+		t.Errorf("Unexpected BootOrder payload: %s", patch.Payload)
+	}
+}
+
+// TestComputerSystemSetBootOrderUnknownOption tests reordering with an
+// unknown boot option.
+func TestComputerSystemSetBootOrderUnknownOption(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: bootOptionResponses(),
+		},
+	}
+	result.SetClient(testClient)
+
+	err = result.SetBootOrderByDisplayName(context.Background(), "Floppy")
+	if err == nil {
+		t.Error("Expected error for unknown boot option")
+	}
+
+	for _, call := range testClient.CapturedCalls() {
+		if call.Action == http.MethodPatch {
+			t.Errorf("Unexpected PATCH call: %s", call.Payload)
+		}
+	}
+}
+
+// TestComputerSystemSetBootNext tests the SetBootNext call.
+func TestComputerSystemSetBootNext(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: append(bootOptionResponses(), bootOptionResponses()...),
+		},
+	}
+	result.SetClient(testClient)
+
+	err = result.SetBootNext(context.Background(), "Boot0009")
+	if err == nil {
+		t.Error("Expected error for unknown boot option reference")
+	}
+
+	err = result.SetBootNext(context.Background(), "Boot0003")
+	if err != nil {
+		t.Errorf("Error making SetBootNext call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	patch := calls[len(calls)-1]
+
+	if !strings.Contains(patch.Payload, "BootNext:Boot0003") {
+		t.Errorf("Unexpected BootNext payload: %s", patch.Payload)
+	}
+
+	if !strings.Contains(patch.Payload, "BootSourceOverrideTarget:UefiBootNext") {
+		t.Errorf("Unexpected BootSourceOverrideTarget payload: %s", patch.Payload)
+	}
+}
+
+// TestComputerSystemSetBoot tests the SetBoot call.
+func TestComputerSystemSetBoot(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetBoot(context.Background(), Boot{
+		BootSourceOverrideTarget: UefiShellBootSourceOverrideTarget,
+	})
+	if err == nil {
+		t.Error("Expected error for unsupported boot target")
+	}
+
+	err = result.SetBoot(context.Background(), Boot{
+		BootSourceOverrideTarget: PxeBootSourceOverrideTarget,
+		BootSourceOverrideMode:   LegacyBootSourceOverrideMode,
+	})
+	if err == nil {
+		t.Error("Expected error for unsupported boot mode")
+	}
+
+	err = result.SetBoot(context.Background(), Boot{
+		BootSourceOverrideEnabled: OnceBootSourceOverrideEnabled,
+		BootSourceOverrideTarget:  PxeBootSourceOverrideTarget,
+		BootSourceOverrideMode:    UEFIBootSourceOverrideMode,
+	})
+	if err != nil {
+		t.Errorf("Error making SetBoot call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call, got %d", len(calls))
+	}
+
+	if calls[0].Payload != "map[Boot:map[BootSourceOverrideEnabled:Once BootSourceOverrideMode:UEFI BootSourceOverrideTarget:Pxe]]" {
+		t.Errorf("Unexpected SetBoot payload: %s", calls[0].Payload)
+	}
+}
+
+// TestComputerSystemSetHTTPBoot tests the SetHTTPBoot call.
+func TestComputerSystemSetHTTPBoot(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetHTTPBoot(context.Background(), "https://boot.example.com/ipxe.efi",
+		OnceBootSourceOverrideEnabled)
+	if err != nil {
+		t.Errorf("Error making SetHTTPBoot call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "HttpBootUri:https://boot.example.com/ipxe.efi") {
+		t.Errorf("Unexpected HttpBootUri payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "BootSourceOverrideTarget:UefiHttp") {
+		t.Errorf("Unexpected BootSourceOverrideTarget payload: %s", calls[0].Payload)
+	}
+}
+
+// TestComputerSystemSetBootSequential tests the SetBootSequential call.
+func TestComputerSystemSetBootSequential(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetBootSequential(context.Background(), Boot{
+		BootSourceOverrideEnabled: ContinuousBootSourceOverrideEnabled,
+		BootSourceOverrideTarget:  HddBootSourceOverrideTarget,
+		BootSourceOverrideMode:    UEFIBootSourceOverrideMode,
+	})
+	if err != nil {
+		t.Errorf("Error making SetBootSequential call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 3 {
+		t.Fatalf("Expected 3 calls, got %d", len(calls))
+	}
+
+	if calls[0].Payload != "map[Boot:map[BootSourceOverrideMode:UEFI]]" {
+		t.Errorf("Unexpected first payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Payload != "map[Boot:map[BootSourceOverrideTarget:Hdd]]" {
+		t.Errorf("Unexpected second payload: %s", calls[1].Payload)
+	}
+
+	if calls[2].Payload != "map[Boot:map[BootSourceOverrideEnabled:Continuous]]" {
+		t.Errorf("Unexpected third payload: %s", calls[2].Payload)
+	}
+
+	// Settings built from the current ones carry their allowable values,
+	// which are not sent on their own.
+	boot := Boot{
+		BootSourceOverrideEnabled:        OnceBootSourceOverrideEnabled,
+		BootSourceOverrideTarget:         HddBootSourceOverrideTarget,
+		AllowedBootSourceOverrideTargets: result.Boot.AllowedBootSourceOverrideTargets,
+		AllowedBootSourceOverrideModes:   result.Boot.AllowedBootSourceOverrideModes,
+	}
+	err = result.SetBootSequential(context.Background(), boot)
+	if err != nil {
+		t.Errorf("Error making SetBootSequential call: %s", err)
+	}
+
+	calls = testClient.CapturedCalls()
+	if len(calls) != 5 {
+		t.Fatalf("Expected 5 calls, got %d", len(calls))
+	}
+
+	if calls[3].Payload != "map[Boot:map[BootSourceOverrideTarget:Hdd]]" {
+		t.Errorf("Unexpected target payload: %s", calls[3].Payload)
+	}
+}
+
+// TestComputerSystemPendingSettings tests updating the system through its
+// settings resource.
+func TestComputerSystemPendingSettings(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	settings := result.PendingSettings()
+
+	if settings.SettingsObject() != "/redfish/v1/Systems/System-1/Settings" {
+		t.Errorf("Invalid settings object: %s", settings.SettingsObject())
+	}
+
+	err = settings.Patch(context.Background(),
+		map[string]interface{}{"AssetTag": "NewTag"},
+		&common.PreferredApplyTime{ApplyTime: common.OnResetApplyTime})
+	if err != nil {
+		t.Errorf("Error making Patch call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != "/redfish/v1/Systems/System-1/Settings" {
+		t.Errorf("Unexpected settings URL: %s", calls[0].URL)
+	}
+}
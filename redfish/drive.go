@@ -7,6 +7,7 @@ package redfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/jacobweinstock/gophish/common"
@@ -50,6 +51,21 @@ const (
 	UnencryptedEncryptionStatus EncryptionStatus = "Unencrypted"
 )
 
+// DataSanitizationType is the method used to securely erase a drive.
+type DataSanitizationType string
+
+const (
+	// BlockEraseDataSanitizationType sets the data on each physical block to
+	// a vendor-defined value, such as zeros.
+	BlockEraseDataSanitizationType DataSanitizationType = "BlockErase"
+	// CryptographicEraseDataSanitizationType erases the target data by
+	// changing the media encryption key.
+	CryptographicEraseDataSanitizationType DataSanitizationType = "CryptographicErase"
+	// OverwriteDataSanitizationType overwrites the data with a fixed pattern,
+	// one or more times.
+	OverwriteDataSanitizationType DataSanitizationType = "Overwrite"
+)
+
 // HotspareReplacementModeType is the replacement operation mode of a hot spare.
 type HotspareReplacementModeType string
 
@@ -162,6 +178,9 @@ type Drive struct {
 	IndicatorLED common.IndicatorLED
 	// Location shall contain location information of the associated drive.
 	Location []common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this drive.
+	LocationIndicatorActive bool
 	// Manufacturer shall be the name of the organization responsible for
 	// producing the drive. This organization might be the entity from whom the
 	// drive is purchased, but this is not necessarily true.
@@ -230,6 +249,14 @@ type Drive struct {
 	PCIeFunctionCount int
 	storagePools      []string
 	StoragePoolsCount int
+	// SupportedResetTypes, if provided, is the reset types this drive
+	// supports.
+	SupportedResetTypes []ResetType
+	// SupportedSanitizationTypes, if provided, is the sanitization types
+	// SecureEraseWithOptions supports for this drive.
+	SupportedSanitizationTypes []DataSanitizationType
+	// resetTarget is the URL to send Reset actions to.
+	resetTarget string
 	// secureEraseTarget is the URL for SecureErase actions.
 	secureEraseTarget string
 	// rawData holds the original serialized JSON so we can compare updates.
@@ -255,8 +282,13 @@ func (drive *Drive) UnmarshalJSON(b []byte) error {
 		VolumeCount        int `json:"Volumes@odata.count"`
 	}
 	type Actions struct {
+		Reset struct {
+			AllowedResetTypes []ResetType `json:"ResetType@Redfish.AllowableValues"`
+			Target            string
+		} `json:"#Drive.Reset"`
 		SecureErase struct {
-			Target string
+			AllowedSanitizationTypes []DataSanitizationType `json:"SanitizationType@Redfish.AllowableValues"`
+			Target                   string
 		} `json:"#Drive.SecureErase"`
 	}
 	var t struct {
@@ -281,6 +313,9 @@ func (drive *Drive) UnmarshalJSON(b []byte) error {
 	drive.VolumesCount = t.Links.VolumeCount
 	drive.pcieFunctions = t.Links.PCIeFunctions.ToStrings()
 	drive.PCIeFunctionCount = t.Links.PCIeFunctionsCount
+	drive.SupportedResetTypes = t.Actions.Reset.AllowedResetTypes
+	drive.resetTarget = t.Actions.Reset.Target
+	drive.SupportedSanitizationTypes = t.Actions.SecureErase.AllowedSanitizationTypes
 	drive.secureEraseTarget = t.Actions.SecureErase.Target
 
 	// This is a read/write object, so we need to save the raw object data for later
@@ -300,7 +335,9 @@ func (drive *Drive) Update(ctx context.Context) error {
 	readWriteFields := []string{
 		"AssetTag",
 		"HotspareReplacementMode",
+		"HotspareType",
 		"IndicatorLED",
+		"LocationIndicatorActive",
 		"StatusIndicator",
 		"WriteCacheEnabled",
 	}
@@ -435,3 +472,71 @@ func (drive *Drive) SecureErase(ctx context.Context) error {
 	_, err := drive.Client.Post(ctx, drive.secureEraseTarget, nil)
 	return err
 }
+
+// SecureEraseWithOptions securely erases the drive with the provided
+// sanitization type. overwritePasses is the number of passes of the Overwrite
+// sanitization, the service default if zero, and must be zero for the other
+// types. It returns the task erasing the drive if the service erases it
+// asynchronously.
+func (drive *Drive) SecureEraseWithOptions(ctx context.Context, sanitizationType DataSanitizationType, overwritePasses int) (*Task, error) {
+	if drive.secureEraseTarget == "" {
+		return nil, fmt.Errorf("SecureErase is not supported by this drive")
+	}
+
+	if overwritePasses < 0 {
+		return nil, fmt.Errorf("overwrite passes should not be negative")
+	}
+	if overwritePasses > 0 && sanitizationType != OverwriteDataSanitizationType {
+		return nil, fmt.Errorf("overwrite passes only apply to the Overwrite sanitization type")
+	}
+
+	if len(drive.SupportedSanitizationTypes) > 0 {
+		supported := false
+		for _, supportedType := range drive.SupportedSanitizationTypes {
+			if sanitizationType == supportedType {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return nil, fmt.Errorf("sanitization type '%s' is not supported by this drive", sanitizationType)
+		}
+	}
+
+	type temp struct {
+		SanitizationType DataSanitizationType
+		OverwritePasses  int `json:",omitempty"`
+	}
+	t := temp{
+		SanitizationType: sanitizationType,
+		OverwritePasses:  overwritePasses,
+	}
+
+	resp, err := drive.Client.Post(ctx, drive.secureEraseTarget, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return TaskFromResponse(ctx, drive.Client, resp)
+}
+
+// Reset resets the drive. Not all drives support this action.
+func (drive *Drive) Reset(ctx context.Context, resetType ResetType) error {
+	if drive.resetTarget == "" {
+		return fmt.Errorf("Reset is not supported by this drive")
+	}
+
+	if err := validateResetType(resetType, drive.SupportedResetTypes); err != nil {
+		return err
+	}
+
+	type temp struct {
+		ResetType ResetType
+	}
+	t := temp{
+		ResetType: resetType,
+	}
+
+	_, err := drive.Client.Post(ctx, drive.resetTarget, t)
+	return err
+}
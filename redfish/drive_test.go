@@ -7,6 +7,7 @@ package redfish
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -22,7 +23,12 @@ var driveBody = `{
 		"Description": "One drive",
 		"Actions": {
 			"#Drive.SecureErase": {
-				"target": "/redfish/v1/Chassis/NVMeChassis/Disk.Bay.0/Actions/Drive.SecureErase"
+				"target": "/redfish/v1/Chassis/NVMeChassis/Disk.Bay.0/Actions/Drive.SecureErase",
+				"SanitizationType@Redfish.AllowableValues": ["CryptographicErase", "Overwrite"]
+			},
+			"#Drive.Reset": {
+				"target": "/redfish/v1/Chassis/NVMeChassis/Disk.Bay.0/Actions/Drive.Reset",
+				"ResetType@Redfish.AllowableValues": ["ForceRestart"]
 			}
 		},
 		"Assembly": {
@@ -160,6 +166,8 @@ func TestDriveUpdate(t *testing.T) {
 	result.IndicatorLED = common.LitIndicatorLED
 	result.StatusIndicator = HotspareStatusIndicator
 	result.WriteCacheEnabled = false
+	result.HotspareType = GlobalHotspareType
+	result.LocationIndicatorActive = true
 	err = result.Update(context.Background())
 
 	if err != nil {
@@ -183,4 +191,79 @@ func TestDriveUpdate(t *testing.T) {
 	if !strings.Contains(calls[0].Payload, "WriteCacheEnabled:false") {
 		t.Errorf("Unexpected WriteCacheEnabled update payload: %s", calls[0].Payload)
 	}
+
+	if !strings.Contains(calls[0].Payload, "HotspareType:Global") {
+		t.Errorf("Unexpected HotspareType update payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "LocationIndicatorActive:true") {
+		t.Errorf("Unexpected LocationIndicatorActive update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestDriveSecureEraseWithOptions tests the SecureEraseWithOptions call.
+func TestDriveSecureEraseWithOptions(t *testing.T) {
+	var result Drive
+	err := json.NewDecoder(strings.NewReader(driveBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {acceptedResponse(`{"@odata.id": "/redfish/v1/TaskService/Tasks/2", "Id": "2"}`, "")},
+		},
+	}
+	result.SetClient(testClient)
+
+	task, err := result.SecureEraseWithOptions(context.Background(), OverwriteDataSanitizationType, 3)
+	if err != nil {
+		t.Fatalf("Error making SecureEraseWithOptions call: %s", err)
+	}
+	if task == nil || task.ID != "2" {
+		t.Errorf("Unexpected task: %v", task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if !strings.Contains(calls[0].Payload, "SanitizationType:Overwrite") ||
+		!strings.Contains(calls[0].Payload, "OverwritePasses:3") {
+		t.Errorf("Unexpected SecureErase payload: %s", calls[0].Payload)
+	}
+
+	if _, err := result.SecureEraseWithOptions(context.Background(), BlockEraseDataSanitizationType, 0); err == nil {
+		t.Error("Expected an error for a sanitization type the drive does not support")
+	}
+	if _, err := result.SecureEraseWithOptions(context.Background(), CryptographicEraseDataSanitizationType, 2); err == nil {
+		t.Error("Expected an error for overwrite passes of a cryptographic erase")
+	}
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Errorf("Invalid erases should not be sent: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestDriveReset tests the Reset call.
+func TestDriveReset(t *testing.T) {
+	var result Drive
+	err := json.NewDecoder(strings.NewReader(driveBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Reset(context.Background(), ForceRestartResetType)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Chassis/NVMeChassis/Disk.Bay.0/Actions/Drive.Reset" ||
+		!strings.Contains(calls[0].Payload, "ResetType:ForceRestart") {
+		t.Errorf("Unexpected Reset call: %v", calls[0])
+	}
+
+	if err := result.Reset(context.Background(), PowerCycleResetType); err == nil {
+		t.Error("Expected an error for a reset type the drive does not support")
+	}
 }
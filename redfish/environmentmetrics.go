@@ -0,0 +1,61 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// EnvironmentMetrics shall represent the environmental metrics for a Redfish
+// implementation.
+type EnvironmentMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// DewPointCelsius shall contain the dew point, in degree Celsius units,
+	// based on the temperature and humidity values for this resource.
+	DewPointCelsius SensorExcerpt
+	// EnergykWh shall contain the total energy, in kilowatt-hour units, for
+	// this resource.
+	EnergykWh SensorEnergykWhExcerpt
+	// FanSpeedsPercent shall contain the fan speeds, in percent units, for
+	// this resource.
+	FanSpeedsPercent []SensorFanArrayExcerpt
+	// HumidityPercent shall contain the humidity, in percent units, for this
+	// resource.
+	HumidityPercent SensorExcerpt
+	// PowerWatts shall contain the total power, in watts, for this resource.
+	PowerWatts SensorPowerExcerpt
+	// TemperatureCelsius shall contain the temperature, in degree Celsius
+	// units, for this resource.
+	TemperatureCelsius SensorExcerpt
+}
+
+// GetEnvironmentMetrics will get an EnvironmentMetrics instance from the
+// service.
+func GetEnvironmentMetrics(ctx context.Context, c common.Client, uri string) (*EnvironmentMetrics, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var environmentmetrics EnvironmentMetrics
+	err = json.NewDecoder(resp.Body).Decode(&environmentmetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	environmentmetrics.SetClient(c)
+	return &environmentmetrics, nil
+}
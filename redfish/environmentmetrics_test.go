@@ -0,0 +1,67 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var environmentMetricsBody = `{
+		"@odata.type": "#EnvironmentMetrics.v1_2_0.EnvironmentMetrics",
+		"@odata.id": "/redfish/v1/Chassis/1/EnvironmentMetrics",
+		"Id": "EnvironmentMetrics",
+		"Name": "Chassis Environment Metrics",
+		"TemperatureCelsius": {
+			"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/AmbientTemp",
+			"Reading": 25.4
+		},
+		"HumidityPercent": {
+			"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/Humidity",
+			"Reading": null
+		},
+		"PowerWatts": {
+			"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/TotalPower",
+			"Reading": 374
+		},
+		"EnergykWh": {
+			"Reading": 2.5,
+			"LifetimeReading": 36166
+		},
+		"FanSpeedsPercent": [{
+			"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/FanBay1",
+			"DeviceName": "Fan Bay 1",
+			"PhysicalContext": "Chassis",
+			"Reading": 45,
+			"SpeedRPM": 2200
+		}]
+	}`
+
+// TestEnvironmentMetrics tests the parsing of EnvironmentMetrics objects.
+func TestEnvironmentMetrics(t *testing.T) {
+	var result EnvironmentMetrics
+	err := json.NewDecoder(strings.NewReader(environmentMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.TemperatureCelsius.Reading == nil || *result.TemperatureCelsius.Reading != 25.4 {
+		t.Errorf("Invalid temperature: %v", result.TemperatureCelsius.Reading)
+	}
+
+	if result.HumidityPercent.Reading != nil {
+		t.Errorf("Missing humidity should be nil: %v", result.HumidityPercent.Reading)
+	}
+
+	if result.EnergykWh.LifetimeReading == nil || *result.EnergykWh.LifetimeReading != 36166 {
+		t.Errorf("Invalid lifetime energy: %v", result.EnergykWh.LifetimeReading)
+	}
+
+	if len(result.FanSpeedsPercent) != 1 || result.FanSpeedsPercent[0].DeviceName != "Fan Bay 1" {
+		t.Errorf("Invalid fan speeds: %v", result.FanSpeedsPercent)
+	}
+}
@@ -209,6 +209,8 @@ type EthernetInterface struct {
 	EndpointsCount int
 	// HostInterface is used by a host to communicate with a Manager.
 	hostInterface string
+	// settings is the @Redfish.Settings annotation of this resource.
+	settings common.Settings
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -226,8 +228,9 @@ func (ethernetinterface *EthernetInterface) UnmarshalJSON(b []byte) error {
 
 	var t struct {
 		temp
-		Links links
-		VLANs common.Link
+		Settings common.Settings `json:"@Redfish.Settings"`
+		Links    links
+		VLANs    common.Link
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -236,6 +239,7 @@ func (ethernetinterface *EthernetInterface) UnmarshalJSON(b []byte) error {
 	}
 
 	*ethernetinterface = EthernetInterface(t.temp)
+	ethernetinterface.settings = t.Settings
 
 	// Extract the links to other entities for later
 	ethernetinterface.chassis = string(t.Links.Chassis)
@@ -344,3 +348,9 @@ type StatelessAddressAutoConfiguration struct {
 }
 
 // TODO: Add vlans
+
+// PendingSettings gives access to the settings that will be applied to this
+// interface, following the @Redfish.Settings pattern.
+func (ethernetinterface *EthernetInterface) PendingSettings() *common.PendingSettings {
+	return common.NewPendingSettings(ethernetinterface.Client, ethernetinterface.ODataID, ethernetinterface.settings, ethernetinterface.rawData)
+}
@@ -0,0 +1,204 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// eventTimestampLayouts are the layouts accepted for EventTimestamp. Besides
+// RFC 3339, some services omit the colon in the offset or the offset itself.
+var eventTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// parseEventTimestamp parses the time at which an event occurred. Timestamps
+// without an offset are assumed to be UTC.
+func parseEventTimestamp(timestamp string) (time.Time, error) {
+	for _, layout := range eventTimestampLayouts {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid event timestamp '%s'", timestamp)
+}
+
+// EventRecord describes a single event or condition reported by the service.
+type EventRecord struct {
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Context shall contain a client supplied context for the event
+	// destination to which this event is being sent. Older services set it
+	// on every record rather than on the event.
+	Context string
+	// EventGroupID shall indicate that events are related and shall have the
+	// same value in the case where multiple event messages are produced by
+	// the same root cause.
+	EventGroupID int `json:"EventGroupId"`
+	// EventID shall contain a service-defined unique identifier for the
+	// event.
+	EventID string `json:"EventId"`
+	// EventTimestamp shall indicate the time the event occurred, as sent by
+	// the service.
+	EventTimestamp string
+	// Timestamp is EventTimestamp parsed. It is the zero time if the service
+	// did not send a timestamp or it could not be parsed.
+	Timestamp time.Time `json:"-"`
+	// EventType shall indicate the type of event. EventType is deprecated as
+	// of Redfish Specification v1.6, so services that do not send it get
+	// OtherEventType.
+	EventType EventType
+	// MemberID shall uniquely identify the member within the collection.
+	MemberID string `json:"MemberId"`
+	// Message shall contain a human-readable event message.
+	Message string
+	// MessageArgs shall contain an array of message arguments that are
+	// substituted for the arguments in the message when looked up in the
+	// message registry. Arguments sent as numbers or booleans are converted
+	// to strings.
+	MessageArgs []string
+	// MessageID shall contain a MessageId, as defined in the Redfish
+	// specification.
+	MessageID string `json:"MessageId"`
+	// MessageSeverity shall contain the severity of the message.
+	MessageSeverity common.Health
+	// Oem shall contain the OEM extensions of the event record.
+	Oem json.RawMessage
+	// OriginOfCondition shall contain a link to the resource or object that
+	// originated the condition that caused the event to be generated.
+	OriginOfCondition common.Link
+	// Severity shall contain the severity of the event, as defined in the
+	// Status section of the Redfish specification.
+	Severity string
+}
+
+// UnmarshalJSON unmarshals an EventRecord object from the raw JSON.
+func (eventrecord *EventRecord) UnmarshalJSON(b []byte) error {
+	type temp EventRecord
+	var t struct {
+		temp
+		EventGroupID      json.RawMessage `json:"EventGroupId"`
+		MessageArgs       []interface{}
+		OriginOfCondition json.RawMessage
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*eventrecord = EventRecord(t.temp)
+
+	// Some services send the group ID as a string.
+	if groupID := bytes.Trim(t.EventGroupID, `"`); len(groupID) > 0 {
+		if id, err := strconv.Atoi(string(groupID)); err == nil {
+			eventrecord.EventGroupID = id
+		}
+	}
+
+	for _, arg := range t.MessageArgs {
+		switch value := arg.(type) {
+		case string:
+			eventrecord.MessageArgs = append(eventrecord.MessageArgs, value)
+		case nil:
+			eventrecord.MessageArgs = append(eventrecord.MessageArgs, "")
+		default:
+			eventrecord.MessageArgs = append(eventrecord.MessageArgs, fmt.Sprint(value))
+		}
+	}
+
+	// Some services send the origin as a plain URI instead of a link.
+	var origin string
+	if json.Unmarshal(t.OriginOfCondition, &origin) == nil {
+		eventrecord.OriginOfCondition = common.Link(origin)
+	} else if len(t.OriginOfCondition) > 0 {
+		err = json.Unmarshal(t.OriginOfCondition, &eventrecord.OriginOfCondition)
+		if err != nil {
+			return err
+		}
+	}
+
+	if eventrecord.EventType == "" {
+		eventrecord.EventType = OtherEventType
+	}
+
+	if eventrecord.EventTimestamp != "" {
+		eventrecord.Timestamp, _ = parseEventTimestamp(eventrecord.EventTimestamp)
+	}
+
+	return nil
+}
+
+// Event contains one or more event records sent by the service, either to an
+// event destination or through the Server-Sent Events stream.
+type Event struct {
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataID is the odata identifier, only sent by some services.
+	ODataID string `json:"@odata.id"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// ID uniquely identifies the event.
+	ID string `json:"Id"`
+	// Name is the name of the event.
+	Name string
+	// Context shall contain a client supplied context for the event
+	// destination to which this event is being sent.
+	Context string
+	// Description provides a description of this resource.
+	Description string
+	// Events shall contain an array of objects that represent the occurrence
+	// of one or more events.
+	Events []EventRecord
+	// Oem shall contain the OEM extensions of the event.
+	Oem json.RawMessage
+}
+
+// UnmarshalJSON unmarshals an Event object from the raw JSON.
+func (event *Event) UnmarshalJSON(b []byte) error {
+	type temp Event
+	var t struct {
+		temp
+		Events json.RawMessage
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*event = Event(t.temp)
+
+	// Some services send a single record instead of an array.
+	records := bytes.TrimSpace(t.Events)
+	if len(records) > 0 && records[0] == '{' {
+		var record EventRecord
+		err = json.Unmarshal(records, &record)
+		if err != nil {
+			return err
+		}
+		event.Events = []EventRecord{record}
+	} else if len(records) > 0 {
+		err = json.Unmarshal(records, &event.Events)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The context may only be set on the records.
+	if event.Context == "" && len(event.Events) > 0 {
+		event.Context = event.Events[0].Context
+	}
+
+	return nil
+}
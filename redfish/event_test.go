@@ -0,0 +1,232 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var eventBody = `{
+		"@odata.type": "#Event.v1_3_0.Event",
+		"Id": "1",
+		"Name": "Event Array",
+		"Context": "ContextData",
+		"Events": [
+			{
+				"EventType": "Alert",
+				"EventId": "4593",
+				"EventTimestamp": "2019-08-22T10:35:16-06:00",
+				"Severity": "Warning",
+				"Message": "The LAN has been disconnected",
+				"MessageId": "Alert.1.0.LanDisconnect",
+				"MessageArgs": [
+					"EthernetInterface 1",
+					"/redfish/v1/Systems/1"
+				],
+				"OriginOfCondition": {
+					"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"
+				},
+				"MessageSeverity": "Warning"
+			}
+		]
+	}`
+
+// TestEvent tests the parsing of Event objects.
+func TestEvent(t *testing.T) {
+	var result Event
+	err := json.NewDecoder(strings.NewReader(eventBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.Context != "ContextData" {
+		t.Errorf("Received invalid Context: %s", result.Context)
+	}
+
+	if len(result.Events) != 1 {
+		t.Fatalf("Expected 1 event record, got: %d", len(result.Events))
+	}
+
+	record := result.Events[0]
+	if record.EventType != AlertEventType {
+		t.Errorf("Invalid EventType: %s", record.EventType)
+	}
+
+	if record.MessageID != "Alert.1.0.LanDisconnect" {
+		t.Errorf("Invalid MessageID: %s", record.MessageID)
+	}
+
+	if len(record.MessageArgs) != 2 {
+		t.Errorf("Invalid MessageArgs: %v", record.MessageArgs)
+	}
+
+	if record.OriginOfCondition != "/redfish/v1/Systems/1/EthernetInterfaces/1" {
+		t.Errorf("Invalid OriginOfCondition: %s", record.OriginOfCondition)
+	}
+
+	if record.MessageSeverity != common.WarningHealth {
+		t.Errorf("Invalid MessageSeverity: %s", record.MessageSeverity)
+	}
+}
+
+// iDRAC sends offsets without a colon and MessageIds without a registry
+// prefix.
+var idracEventBody = `{
+		"@odata.context": "/redfish/v1/$metadata#Event.Event",
+		"@odata.id": "/redfish/v1/EventService/Events/5e004f5a-e3d1-11eb-ae9c-3448edf18a38",
+		"@odata.type": "#Event.v1_3_0.Event",
+		"Context": "",
+		"Description": "iDRAC Event",
+		"Events": [
+			{
+				"@odata.type": "#Event.v1_3_0.EventRecord",
+				"EventId": "2162",
+				"EventTimestamp": "2021-07-13T15:07:59-0500",
+				"EventType": "Alert",
+				"MemberId": "7e675c8e-127a-11e8-a3ee-6c2b59a42c21",
+				"Message": "The system inlet temperature is less than the lower warning threshold.",
+				"MessageArgs": ["System Board Inlet Temp"],
+				"MessageArgs@odata.count": 1,
+				"MessageId": "TMP0118",
+				"MessageSeverity": "Warning",
+				"OriginOfCondition": {
+					"@odata.id": "/redfish/v1/Chassis/System.Embedded.1"
+				},
+				"Severity": "Warning"
+			}
+		],
+		"Id": "5e004f5a-e3d1-11eb-ae9c-3448edf18a38",
+		"Name": "Event Array"
+	}`
+
+// iLO sends the origin as a plain URI, numeric message arguments and OEM
+// extensions.
+var iloEventBody = `{
+		"@odata.context": "/redfish/v1/$metadata#Event.Event",
+		"@odata.type": "#Event.v1_0_0.Event",
+		"Events": [
+			{
+				"Context": "ilo-subscription",
+				"EventId": "f2bd0d9a-cb4c-4b5e-9f62-4c4b0e30a6a4",
+				"EventTimestamp": "2021-07-13T20:07:59Z",
+				"EventType": "ResourceUpdated",
+				"MessageArgs": [2, true],
+				"MessageId": "iLOEvents.2.1.ServerPoweredOff",
+				"Oem": {
+					"Hpe": {
+						"@odata.type": "#HpeEvent.v2_1_0.HpeEvent",
+						"Resource": "/redfish/v1/Systems/1/"
+					}
+				},
+				"OriginOfCondition": "/redfish/v1/Systems/1/"
+			}
+		],
+		"Id": "1",
+		"Name": "Events"
+	}`
+
+// OpenBMC no longer sends EventType, and other services send a single record
+// with a string group ID instead of an array.
+var openBMCEventBody = `{
+		"@odata.type": "#Event.v1_4_0.Event",
+		"Context": "bmcweb",
+		"Events": {
+			"EventGroupId": "7",
+			"EventId": "1626206879",
+			"EventTimestamp": "2021-07-13T20:07:59",
+			"MemberId": "0",
+			"Message": "Host system DC power is off",
+			"MessageArgs": [],
+			"MessageId": "OpenBMC.0.1.DCPowerOff",
+			"MessageSeverity": "OK",
+			"OriginOfCondition": "/redfish/v1/Systems/system"
+		},
+		"Id": "1",
+		"Name": "Event Log"
+	}`
+
+// TestEventVendorPayloads tests the parsing of events as sent by different
+// services.
+func TestEventVendorPayloads(t *testing.T) {
+	var idrac Event
+	err := json.Unmarshal([]byte(idracEventBody), &idrac)
+	if err != nil {
+		t.Fatalf("Error decoding iDRAC event: %s", err)
+	}
+
+	record := idrac.Events[0]
+	expected := time.Date(2021, 7, 13, 20, 7, 59, 0, time.UTC)
+	if !record.Timestamp.Equal(expected) {
+		t.Errorf("Invalid iDRAC timestamp: %s", record.Timestamp)
+	}
+
+	if record.OriginOfCondition != "/redfish/v1/Chassis/System.Embedded.1" {
+		t.Errorf("Invalid iDRAC OriginOfCondition: %s", record.OriginOfCondition)
+	}
+
+	var ilo Event
+	err = json.Unmarshal([]byte(iloEventBody), &ilo)
+	if err != nil {
+		t.Fatalf("Error decoding iLO event: %s", err)
+	}
+
+	if ilo.Context != "ilo-subscription" {
+		t.Errorf("Context should be taken from the record: %s", ilo.Context)
+	}
+
+	record = ilo.Events[0]
+	if !record.Timestamp.Equal(expected) {
+		t.Errorf("Invalid iLO timestamp: %s", record.Timestamp)
+	}
+
+	if record.OriginOfCondition != "/redfish/v1/Systems/1/" {
+		t.Errorf("Invalid iLO OriginOfCondition: %s", record.OriginOfCondition)
+	}
+
+	if strings.Join(record.MessageArgs, ",") != "2,true" {
+		t.Errorf("Invalid iLO MessageArgs: %v", record.MessageArgs)
+	}
+
+	if !strings.Contains(string(record.Oem), "HpeEvent") {
+		t.Errorf("Invalid iLO Oem: %s", record.Oem)
+	}
+
+	var openBMC Event
+	err = json.Unmarshal([]byte(openBMCEventBody), &openBMC)
+	if err != nil {
+		t.Fatalf("Error decoding OpenBMC event: %s", err)
+	}
+
+	if len(openBMC.Events) != 1 {
+		t.Fatalf("Expected a single record, got: %d", len(openBMC.Events))
+	}
+
+	record = openBMC.Events[0]
+	if record.EventType != OtherEventType {
+		t.Errorf("Missing EventType should be Other: %s", record.EventType)
+	}
+
+	if record.EventGroupID != 7 {
+		t.Errorf("Invalid EventGroupID: %d", record.EventGroupID)
+	}
+
+	if !record.Timestamp.Equal(expected) {
+		t.Errorf("Invalid OpenBMC timestamp: %s", record.Timestamp)
+	}
+
+	if record.MessageSeverity != common.OKHealth {
+		t.Errorf("Invalid MessageSeverity: %s", record.MessageSeverity)
+	}
+}
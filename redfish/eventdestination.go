@@ -214,6 +214,8 @@ type EventDestination struct {
 	// this property is not present, the SubscriptionType shall be assumed to be
 	// RedfishEvent.
 	SubscriptionType SubscriptionType
+	// resumeSubscriptionTarget is the URL to send ResumeSubscription actions.
+	resumeSubscriptionTarget string
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -221,8 +223,14 @@ type EventDestination struct {
 // UnmarshalJSON unmarshals a EventDestination object from the raw JSON.
 func (eventdestination *EventDestination) UnmarshalJSON(b []byte) error {
 	type temp EventDestination
+	type Actions struct {
+		ResumeSubscription struct {
+			Target string
+		} `json:"#EventDestination.ResumeSubscription"`
+	}
 	var t struct {
 		temp
+		Actions Actions
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -232,6 +240,7 @@ func (eventdestination *EventDestination) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	*eventdestination = EventDestination(t.temp)
+	eventdestination.resumeSubscriptionTarget = t.Actions.ResumeSubscription.Target
 
 	// This is a read/write object, so we need to save the raw object data for later
 	eventdestination.rawData = b
@@ -258,6 +267,17 @@ func (eventdestination *EventDestination) Update(ctx context.Context) error {
 	return eventdestination.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
 }
 
+// ResumeSubscription resumes a suspended event subscription, delivering any
+// events that were queued while it was suspended.
+func (eventdestination *EventDestination) ResumeSubscription(ctx context.Context) error {
+	if eventdestination.resumeSubscriptionTarget == "" {
+		return fmt.Errorf("ResumeSubscription is not supported by this event destination")
+	}
+
+	_, err := eventdestination.Client.Post(ctx, eventdestination.resumeSubscriptionTarget, struct{}{})
+	return err
+}
+
 // GetEventDestination will get a EventDestination instance from the service.
 func GetEventDestination(ctx context.Context, c common.Client, uri string) (*EventDestination, error) {
 	// validate uri
@@ -283,12 +303,25 @@ func GetEventDestination(ctx context.Context, c common.Client, uri string) (*Eve
 
 // subscriptionPayload is the payload to create the event subscription
 type subscriptionPayload struct {
-	Destination string                   `json:"Destination"`
-	EventTypes  []EventType              `json:"EventTypes"`
-	HTTPHeaders map[string]string        `json:"HttpHeaders,omitempty"`
-	Oem         interface{}              `json:"Oem,omitempty"`
-	Protocol    EventDestinationProtocol `json:"Protocol,omitempty"`
-	Context     string                   `json:"Context,omitempty"`
+	Destination         string                   `json:"Destination"`
+	EventTypes          []EventType              `json:"EventTypes,omitempty"`
+	RegistryPrefixes    []string                 `json:"RegistryPrefixes,omitempty"`
+	ResourceTypes       []string                 `json:"ResourceTypes,omitempty"`
+	DeliveryRetryPolicy DeliveryRetryPolicy      `json:"DeliveryRetryPolicy,omitempty"`
+	HTTPHeaders         map[string]string        `json:"HttpHeaders,omitempty"`
+	SNMP                *snmpPayload             `json:"SNMP,omitempty"`
+	Oem                 interface{}              `json:"Oem,omitempty"`
+	Protocol            EventDestinationProtocol `json:"Protocol,omitempty"`
+	Context             string                   `json:"Context,omitempty"`
+}
+
+// snmpPayload is the SNMP settings sent to create an SNMP subscription.
+type snmpPayload struct {
+	AuthenticationKey      string                      `json:"AuthenticationKey,omitempty"`
+	AuthenticationProtocol SNMPAuthenticationProtocols `json:"AuthenticationProtocol,omitempty"`
+	EncryptionKey          string                      `json:"EncryptionKey,omitempty"`
+	EncryptionProtocol     SNMPEncryptionProtocols     `json:"EncryptionProtocol,omitempty"`
+	TrapCommunity          string                      `json:"TrapCommunity,omitempty"`
 }
 
 // validateCreateEventDestinationParams will validate
@@ -296,6 +329,7 @@ type subscriptionPayload struct {
 func validateCreateEventDestinationParams(
 	uri string,
 	destination string,
+	scheme string,
 	eventTypes []EventType,
 ) error {
 	// validate uri
@@ -308,8 +342,8 @@ func validateCreateEventDestinationParams(
 		return fmt.Errorf("empty destination is not valid")
 	}
 
-	if !strings.HasPrefix(destination, "http") {
-		return fmt.Errorf("destination should start with http")
+	if !strings.HasPrefix(destination, scheme) {
+		return fmt.Errorf("destination should start with %s", scheme)
 	}
 
 	// validate event types
@@ -354,6 +388,7 @@ func CreateEventDestination(
 	err := validateCreateEventDestinationParams(
 		uri,
 		destination,
+		"http",
 		eventTypes,
 	)
 
@@ -379,6 +414,130 @@ func CreateEventDestination(
 		s.Oem = oem
 	}
 
+	return postEventDestination(ctx, c, uri, s)
+}
+
+// CreateEventDestinationSNMP will create an EventDestination instance sending
+// the events as SNMP traps.
+// destination should contain the trap receiver, in the form snmp://host:port.
+// eventTypes is a list of EventType to subscribe to.
+// protocol should be the SNMP version, SNMPv1EventDestinationProtocol,
+// SNMPv2cEventDestinationProtocol or SNMPv3EventDestinationProtocol.
+// snmp holds the community string for SNMPv1 and SNMPv2c, or the
+// authentication and encryption settings for SNMPv3.
+// context is a required client-supplied string that is sent with the event notifications
+// oem is optional and gives the opportunity to specify any OEM specific properties.
+// It returns the new subscription URI if the event subscription is created
+// with success or any error encountered.
+func CreateEventDestinationSNMP(
+	ctx context.Context,
+	c common.Client,
+	uri string,
+	destination string,
+	eventTypes []EventType,
+	protocol EventDestinationProtocol,
+	snmp SNMPSettings,
+	context string,
+	oem interface{},
+) (string, error) {
+	err := validateCreateEventDestinationParams(uri, destination, "snmp://", eventTypes)
+	if err != nil {
+		return "", err
+	}
+
+	err = validateSNMPSettings(protocol, snmp)
+	if err != nil {
+		return "", err
+	}
+
+	s := &subscriptionPayload{
+		Destination: destination,
+		EventTypes:  eventTypes,
+		Protocol:    protocol,
+		Context:     context,
+		SNMP: &snmpPayload{
+			AuthenticationKey:      snmp.AuthenticationKey,
+			AuthenticationProtocol: snmp.AuthenticationProtocol,
+			EncryptionKey:          snmp.EncryptionKey,
+			EncryptionProtocol:     snmp.EncryptionProtocol,
+			TrapCommunity:          snmp.TrapCommunity,
+		},
+		Oem: oem,
+	}
+
+	return postEventDestination(ctx, c, uri, s)
+}
+
+// validateSNMPSettings makes sure the SNMP settings are consistent with the
+// SNMP version.
+func validateSNMPSettings(protocol EventDestinationProtocol, snmp SNMPSettings) error {
+	switch protocol {
+	case SNMPv1EventDestinationProtocol, SNMPv2cEventDestinationProtocol:
+		if snmp.AuthenticationProtocol != "" && snmp.AuthenticationProtocol != CommunityStringSNMPAuthenticationProtocols {
+			return fmt.Errorf("%s only supports community string authentication", protocol)
+		}
+		if snmp.EncryptionProtocol != "" && snmp.EncryptionProtocol != NoneSNMPEncryptionProtocols {
+			return fmt.Errorf("%s does not support encryption", protocol)
+		}
+	case SNMPv3EventDestinationProtocol:
+		if snmp.AuthenticationProtocol == CommunityStringSNMPAuthenticationProtocols {
+			return fmt.Errorf("%s does not support community string authentication", protocol)
+		}
+		authenticated := snmp.AuthenticationProtocol != "" && snmp.AuthenticationProtocol != NoneSNMPAuthenticationProtocols
+		if authenticated && snmp.AuthenticationKey == "" {
+			return fmt.Errorf("an authentication key is required for %s authentication", snmp.AuthenticationProtocol)
+		}
+		if snmp.EncryptionProtocol != "" && snmp.EncryptionProtocol != NoneSNMPEncryptionProtocols {
+			if !authenticated {
+				return fmt.Errorf("SNMPv3 encryption requires authentication")
+			}
+			if snmp.EncryptionKey == "" {
+				return fmt.Errorf("an encryption key is required for %s encryption", snmp.EncryptionProtocol)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid SNMP protocol: %s", protocol)
+	}
+
+	return nil
+}
+
+// CreateEventDestinationSMTP will create an EventDestination instance sending
+// the events by email, using the SMTP settings of the event service.
+// destination should contain the recipient, in the form mailto:user@example.com.
+// eventTypes is a list of EventType to subscribe to.
+// context is a required client-supplied string that is sent with the event notifications
+// oem is optional and gives the opportunity to specify any OEM specific properties.
+// It returns the new subscription URI if the event subscription is created
+// with success or any error encountered.
+func CreateEventDestinationSMTP(
+	ctx context.Context,
+	c common.Client,
+	uri string,
+	destination string,
+	eventTypes []EventType,
+	context string,
+	oem interface{},
+) (string, error) {
+	err := validateCreateEventDestinationParams(uri, destination, "mailto:", eventTypes)
+	if err != nil {
+		return "", err
+	}
+
+	s := &subscriptionPayload{
+		Destination: destination,
+		EventTypes:  eventTypes,
+		Protocol:    SMTPEventDestinationProtocol,
+		Context:     context,
+		Oem:         oem,
+	}
+
+	return postEventDestination(ctx, c, uri, s)
+}
+
+// postEventDestination sends the subscription payload to the subscription
+// collection and returns the URI of the new subscription.
+func postEventDestination(ctx context.Context, c common.Client, uri string, s *subscriptionPayload) (string, error) {
 	resp, err := c.Post(ctx, uri, s)
 	if err != nil {
 		return "", err
@@ -5,10 +5,14 @@
 package redfish
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,6 +46,10 @@ const (
 	ResourceUpdatedEventType EventType = "ResourceUpdated"
 	// StatusChangeEventType indicates the status of this resource has changed.
 	StatusChangeEventType EventType = "StatusChange"
+	// OtherEventType indicates the event is based on a registry or resource
+	// rather than an EventType, which is deprecated as of Redfish
+	// Specification v1.6.
+	OtherEventType EventType = "Other"
 )
 
 // IsValidEventType will check if it is a valid EventType
@@ -49,7 +57,7 @@ func (et EventType) IsValidEventType() bool {
 	switch et {
 	case AlertEventType, ResourceAddedEventType,
 		ResourceRemovedEventType, ResourceUpdatedEventType,
-		StatusChangeEventType:
+		StatusChangeEventType, OtherEventType:
 		return true
 	}
 	return false
@@ -62,6 +70,7 @@ var SupportedEventTypes = map[string]EventType{
 	"ResourceRemovedEventType": ResourceRemovedEventType,
 	"ResourceUpdated":          ResourceUpdatedEventType,
 	"StatusChange":             StatusChangeEventType,
+	"Other":                    OtherEventType,
 }
 
 // SMTPAuthenticationMethods is
@@ -221,7 +230,28 @@ func (eventservice *EventService) Update(ctx context.Context) error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(eventservice).Elem()
 
-	return eventservice.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+	err := eventservice.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+	if err != nil {
+		return err
+	}
+
+	// The SMTP settings are a nested object, so the changed settings are
+	// sent on their own.
+	smtp := make(map[string]interface{})
+	originalSMTP := reflect.ValueOf(original.SMTP)
+	currentSMTP := reflect.ValueOf(eventservice.SMTP)
+	for i := 0; i < originalSMTP.NumField(); i++ {
+		if originalSMTP.Field(i).Interface() != currentSMTP.Field(i).Interface() {
+			smtp[originalSMTP.Type().Field(i).Name] = currentSMTP.Field(i).Interface()
+		}
+	}
+
+	if len(smtp) > 0 {
+		payload := map[string]interface{}{"SMTP": smtp}
+		_, err = eventservice.Client.Patch(ctx, eventservice.ODataID, payload)
+	}
+
+	return err
 }
 
 // GetEventService will get a EventService instance from the service.
@@ -318,6 +348,62 @@ func (eventservice *EventService) CreateEventSubscription(
 	)
 }
 
+// CreateEventSubscriptionSNMP creates a subscription sending the events as
+// SNMP traps to destination, in the form snmp://host:port. protocol is the SNMP
+// version and snmp holds the community string or SNMPv3 credentials.
+// It returns the new subscription URI.
+func (eventservice *EventService) CreateEventSubscriptionSNMP(
+	ctx context.Context,
+	destination string,
+	eventTypes []EventType,
+	protocol EventDestinationProtocol,
+	snmp SNMPSettings,
+	context string,
+	oem interface{},
+) (string, error) {
+	if len(strings.TrimSpace(eventservice.subscriptions)) == 0 {
+		return "", fmt.Errorf("empty subscription link in the event service")
+	}
+
+	return CreateEventDestinationSNMP(
+		ctx,
+		eventservice.Client,
+		eventservice.subscriptions,
+		destination,
+		eventTypes,
+		protocol,
+		snmp,
+		context,
+		oem,
+	)
+}
+
+// CreateEventSubscriptionSMTP creates a subscription sending the events by
+// email to destination, in the form mailto:user@example.com. The email is sent
+// using the SMTP settings of the event service, which can be changed through
+// the SMTP property and Update. It returns the new subscription URI.
+func (eventservice *EventService) CreateEventSubscriptionSMTP(
+	ctx context.Context,
+	destination string,
+	eventTypes []EventType,
+	context string,
+	oem interface{},
+) (string, error) {
+	if len(strings.TrimSpace(eventservice.subscriptions)) == 0 {
+		return "", fmt.Errorf("empty subscription link in the event service")
+	}
+
+	return CreateEventDestinationSMTP(
+		ctx,
+		eventservice.Client,
+		eventservice.subscriptions,
+		destination,
+		eventTypes,
+		context,
+		oem,
+	)
+}
+
 // DeleteEventSubscription deletes a specific subscription using the event service.
 func (eventservice *EventService) DeleteEventSubscription(ctx context.Context, uri string) error {
 	return DeleteEventDestination(ctx, eventservice.Client, uri)
@@ -353,6 +439,309 @@ func (eventservice *EventService) SubmitTestEvent(ctx context.Context, message s
 	return err
 }
 
+// defaultSSERetryInterval is the time to wait before reconnecting to the
+// Server-Sent Events stream when the service did not provide a retry time.
+const defaultSSERetryInterval = 5 * time.Second
+
+// maxSSERetryInterval is the longest time to wait before reconnecting to the
+// Server-Sent Events stream after failed attempts, unless the service asked
+// for a longer retry time.
+const maxSSERetryInterval = 5 * time.Minute
+
+// maxSSELineLength is the longest line accepted from the Server-Sent Events
+// stream.
+const maxSSELineLength = 1024 * 1024
+
+// SSEFilter selects the events received through the Server-Sent Events
+// stream. Events matching any value of a property are received, and all the
+// properties that are set must match.
+type SSEFilter struct {
+	// EventTypes limits the stream to events of these types.
+	EventTypes []EventType
+	// MessageIDs limits the stream to events with these MessageIds.
+	MessageIDs []string
+	// OriginResources limits the stream to events originating from these
+	// resources.
+	OriginResources []string
+	// RegistryPrefixes limits the stream to events with MessageIds from
+	// message registries with these prefixes.
+	RegistryPrefixes []string
+	// ResourceTypes limits the stream to events originating from resources
+	// of these types.
+	ResourceTypes []string
+}
+
+// query builds the $filter expression for the filter, making sure the
+// service supports filtering on every property used.
+func (filter *SSEFilter) query(supported SSEFilterPropertiesSupported) (string, error) {
+	if filter == nil {
+		return "", nil
+	}
+
+	var eventTypes []string
+	for _, eventType := range filter.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	properties := []struct {
+		name      string
+		values    []string
+		supported bool
+	}{
+		{"EventType", eventTypes, true},
+		{"MessageId", filter.MessageIDs, supported.MessageID},
+		{"OriginResource", filter.OriginResources, supported.OriginResource},
+		{"RegistryPrefix", filter.RegistryPrefixes, supported.RegistryPrefix},
+		{"ResourceType", filter.ResourceTypes, supported.ResourceType},
+	}
+
+	var expressions []string
+	for _, property := range properties {
+		if len(property.values) == 0 {
+			continue
+		}
+		if !property.supported {
+			return "", fmt.Errorf("the service does not support filtering events by %s", property.name)
+		}
+
+		expressions = append(expressions, filterAnyOf(property.name, property.values))
+	}
+
+	return strings.Join(expressions, " and "), nil
+}
+
+// filterAnyOf returns a $filter expression matching any of the values of a
+// property, such as "(Severity eq 'Warning' or Severity eq 'Critical')".
+func filterAnyOf(property string, values []string) string {
+	var terms []string
+	for _, value := range values {
+		terms = append(terms, fmt.Sprintf("%s eq %s", property, filterLiteral(value)))
+	}
+
+	expression := strings.Join(terms, " or ")
+	if len(terms) > 1 {
+		expression = "(" + expression + ")"
+	}
+	return expression
+}
+
+// filterLiteral quotes a string for a $filter expression.
+func filterLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// Subscribe opens the Server-Sent Events stream of the event service and
+// returns a channel receiving the events matching the filter. filter is
+// optional, when nil all events are received. If the stream is interrupted
+// it is reopened, using the ID of the last event received so the service can
+// replay the events that were missed. Reconnections that fail are retried
+// with an exponential backoff. onError is optional, it is called with the
+// errors reading or reopening the stream, which are dropped when it is nil.
+// The channel is closed once the context is done.
+func (eventservice *EventService) Subscribe(ctx context.Context, filter *SSEFilter, onError func(error)) (<-chan Event, error) {
+	if eventservice.ServerSentEventURI == "" {
+		return nil, fmt.Errorf("the event service does not provide a Server-Sent Events stream")
+	}
+
+	client, ok := eventservice.Client.(common.HeaderClient)
+	if !ok {
+		return nil, fmt.Errorf("the client does not support Server-Sent Events")
+	}
+
+	query, err := filter.query(eventservice.SSEFilterPropertiesSupported)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := eventservice.ServerSentEventURI
+	if parsed, err := url.Parse(uri); err == nil && parsed.Host != "" {
+		uri = parsed.RequestURI()
+	}
+	if query != "" {
+		separator := "?"
+		if strings.Contains(uri, "?") {
+			separator = "&"
+		}
+		uri += separator + "$filter=" + strings.ReplaceAll(url.QueryEscape(query), "+", "%20")
+	}
+
+	stream := &sseStream{
+		client:        client,
+		uri:           uri,
+		retryInterval: defaultSSERetryInterval,
+		onError:       onError,
+	}
+
+	body, err := stream.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event)
+	go stream.run(ctx, body, events)
+
+	return events, nil
+}
+
+// sseStream holds the state of a Server-Sent Events stream across
+// reconnections.
+type sseStream struct {
+	client        common.HeaderClient
+	uri           string
+	lastEventID   string
+	retryInterval time.Duration
+	// failures is the number of reconnections that failed in a row.
+	failures int
+	// onError is called with the errors of the stream, if set.
+	onError func(error)
+}
+
+// open connects to the event stream, resuming after the last event received.
+func (stream *sseStream) open(ctx context.Context) (io.ReadCloser, error) {
+	headers := map[string]string{
+		"Accept":        "text/event-stream",
+		"Cache-Control": "no-cache",
+	}
+	if stream.lastEventID != "" {
+		headers["Last-Event-ID"] = stream.lastEventID
+	}
+
+	resp, err := stream.client.GetWithHeaders(ctx, stream.uri, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil {
+		return nil, fmt.Errorf("no event stream received from %s", stream.uri)
+	}
+
+	return resp.Body, nil
+}
+
+// run reads the events from the stream, reconnecting whenever the stream is
+// interrupted, until the context is done.
+func (stream *sseStream) run(ctx context.Context, body io.ReadCloser, events chan<- Event) {
+	defer close(events)
+
+	for {
+		if body != nil {
+			err := stream.read(ctx, body, events)
+			body.Close()
+			body = nil
+			if err != nil && ctx.Err() == nil {
+				stream.report(fmt.Errorf("error reading the event stream: %v", err))
+			}
+		}
+
+		timer := time.NewTimer(stream.backoff())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		var err error
+		body, err = stream.open(ctx)
+		if err != nil {
+			body = nil
+			if ctx.Err() == nil {
+				stream.failures++
+				stream.report(fmt.Errorf("error reopening the event stream: %v", err))
+			}
+			continue
+		}
+		stream.failures = 0
+	}
+}
+
+// backoff returns the time to wait before reconnecting. It is the retry time
+// of the service, doubled for each reconnection that failed in a row up to
+// maxSSERetryInterval.
+func (stream *sseStream) backoff() time.Duration {
+	interval := stream.retryInterval
+	if stream.failures == 0 {
+		return interval
+	}
+
+	if interval <= 0 {
+		interval = defaultSSERetryInterval
+	}
+	for i := 0; i < stream.failures && interval < maxSSERetryInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxSSERetryInterval && stream.retryInterval < maxSSERetryInterval {
+		interval = maxSSERetryInterval
+	}
+	return interval
+}
+
+// report passes an error of the stream to the error handler, if any.
+func (stream *sseStream) report(err error) {
+	if stream.onError != nil {
+		stream.onError(err)
+	}
+}
+
+// read parses the events from the stream and sends them to the channel until
+// the stream ends or the context is done. Data that does not contain an Event
+// payload is ignored. It returns the error that interrupted the stream, if
+// any.
+func (stream *sseStream) read(ctx context.Context, body io.Reader, events chan<- Event) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 4096), maxSSELineLength)
+
+	var data []string
+	var eventID string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			// An empty line dispatches the event.
+			if eventID != "" {
+				stream.lastEventID = eventID
+			}
+			if len(data) > 0 {
+				var event Event
+				if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &event); err == nil {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return nil
+					}
+				}
+			}
+			data = nil
+			eventID = ""
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			// Comments are used by services to keep the connection alive.
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				eventID = value
+			}
+		case "retry":
+			if milliseconds, err := strconv.Atoi(value); err == nil && milliseconds >= 0 {
+				stream.retryInterval = time.Duration(milliseconds) * time.Millisecond
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
 // SSEFilterPropertiesSupported shall contain a set of properties that indicate
 // which properties are supported in the $filter query parameter for the URI
 // indicated by the ServerSentEventUri property.
@@ -8,11 +8,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jacobweinstock/gophish/common"
 )
@@ -660,3 +662,348 @@ func TestEventServiceGetEventSubscriptionsEmptySubscriptionsLink(t *testing.T) {
 			expectedError)
 	}
 }
+
+// TestEventServiceSSEFilter tests building the $filter of the event stream.
+func TestEventServiceSSEFilter(t *testing.T) {
+	supported := SSEFilterPropertiesSupported{
+		MessageID:      true,
+		RegistryPrefix: true,
+	}
+
+	filter := &SSEFilter{
+		EventTypes:       []EventType{AlertEventType, StatusChangeEventType},
+		RegistryPrefixes: []string{"Base"},
+	}
+	query, err := filter.query(supported)
+	if err != nil {
+		t.Errorf("Error building filter: %s", err)
+	}
+	expected := "(EventType eq 'Alert' or EventType eq 'StatusChange') and RegistryPrefix eq 'Base'"
+	if query != expected {
+		t.Errorf("Unexpected filter: %s", query)
+	}
+
+	filter = &SSEFilter{ResourceTypes: []string{"Chassis"}}
+	_, err = filter.query(supported)
+	if err == nil {
+		t.Error("Filtering on an unsupported property should fail")
+	}
+
+	filter = nil
+	query, err = filter.query(supported)
+	if err != nil || query != "" {
+		t.Errorf("Nil filter should be empty, got: %s %v", query, err)
+	}
+}
+
+// TestEventServiceSubscribe tests receiving events from the Server-Sent
+// Events stream and resuming it after an interruption.
+func TestEventServiceSubscribe(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	firstStream := ": keep-alive\n\n" +
+		"retry: 10\n" +
+		"id: 1\n" +
+		`data: {"@odata.type": "#Event.v1_4_0.Event", "Id": "1", "Name": "Event Array",` + "\n" +
+		`data: "Events": [{"EventType": "Alert", "MessageId": "Base.1.0.Success", "OriginOfCondition": {"@odata.id": "/redfish/v1/Systems/1"}}]}` + "\n\n" +
+		"id: 2\n" +
+		`data: {"Id": "2", "Name": "Event Array", "Events": [{"MessageId": "Base.1.0.GeneralError"}]}` + "\n\n"
+	secondStream := "retry: 60000\n" +
+		"id: 3\n" +
+		`data: {"Id": "3", "Name": "Event Array", "Events": [{"MessageId": "Base.1.0.ResourceCreated"}]}` + "\n\n"
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(firstStream)),
+				},
+				&http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(secondStream)),
+				},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := result.Subscribe(ctx, &SSEFilter{MessageIDs: []string{"Base.1.0.Success"}}, nil)
+	if err != nil {
+		t.Fatalf("Error making Subscribe call: %s", err)
+	}
+
+	var received []Event
+	for event := range events {
+		received = append(received, event)
+		if len(received) == 3 {
+			cancel()
+		}
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("Expected 3 events, got: %d", len(received))
+	}
+
+	if received[0].Events[0].MessageID != "Base.1.0.Success" {
+		t.Errorf("Invalid MessageID: %s", received[0].Events[0].MessageID)
+	}
+
+	if received[0].Events[0].OriginOfCondition != "/redfish/v1/Systems/1" {
+		t.Errorf("Invalid OriginOfCondition: %s", received[0].Events[0].OriginOfCondition)
+	}
+
+	if received[2].ID != "3" {
+		t.Errorf("Invalid ID for resumed event: %s", received[2].ID)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got: %d", len(calls))
+	}
+
+	if calls[0].URL != "/events?$filter=MessageId%20eq%20%27Base.1.0.Success%27" {
+		t.Errorf("Unexpected stream URL: %s", calls[0].URL)
+	}
+
+	if !strings.Contains(calls[0].Payload, "Accept:text/event-stream") {
+		t.Errorf("Unexpected stream headers: %s", calls[0].Payload)
+	}
+
+	if strings.Contains(calls[0].Payload, "Last-Event-ID") {
+		t.Errorf("Unexpected Last-Event-ID when opening stream: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[1].Payload, "Last-Event-ID:2") {
+		t.Errorf("Expected Last-Event-ID on reconnect: %s", calls[1].Payload)
+	}
+}
+
+// TestEventServiceSubscribeErrors tests reporting the errors reopening the
+// Server-Sent Events stream.
+func TestEventServiceSubscribeErrors(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	stream := func(body string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString(body))}
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				stream("retry: 1\n\n"),
+				errors.New("503: service unavailable"),
+				errors.New("503: service unavailable"),
+				stream(`data: {"Id": "1", "Name": "Event Array", "Events": [{"MessageId": "Base.1.0.Success"}]}` + "\n\n"),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	reported := make(chan error, 10)
+	onError := func(err error) {
+		reported <- err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := result.Subscribe(ctx, nil, onError)
+	if err != nil {
+		t.Fatalf("Error making Subscribe call: %s", err)
+	}
+
+	event := <-events
+	cancel()
+	if event.ID != "1" {
+		t.Errorf("Unexpected event: %v", event)
+	}
+
+	if len(reported) != 2 {
+		t.Fatalf("Expected 2 reported errors, got: %d", len(reported))
+	}
+	if err := <-reported; !strings.Contains(err.Error(), "503: service unavailable") {
+		t.Errorf("Unexpected reported error: %s", err)
+	}
+}
+
+// TestSSEStreamBackoff tests the time waited before reconnecting.
+func TestSSEStreamBackoff(t *testing.T) {
+	stream := &sseStream{retryInterval: time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	for failures, interval := range expected {
+		stream.failures = failures
+		if backoff := stream.backoff(); backoff != interval {
+			t.Errorf("Unexpected backoff after %d failures: %s", failures, backoff)
+		}
+	}
+
+	stream.failures = 20
+	if backoff := stream.backoff(); backoff != maxSSERetryInterval {
+		t.Errorf("Backoff should be limited: %s", backoff)
+	}
+
+	// A longer retry time asked by the service is kept.
+	stream.retryInterval = 10 * time.Minute
+	if backoff := stream.backoff(); backoff != 10*time.Minute {
+		t.Errorf("Unexpected backoff with a long retry time: %s", backoff)
+	}
+}
+
+// TestEventServiceUpdateSMTP tests updating the SMTP settings.
+func TestEventServiceUpdateSMTP(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.SMTP.ServerAddress = "smtp.example.com"
+	result.SMTP.Port = 587
+	result.SMTP.ConnectionProtocol = StartTLSSMTPConnectionProtocol
+	err = result.Update(context.Background())
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 call, got %d", len(calls))
+	}
+
+	if calls[0].Payload != "map[SMTP:map[ConnectionProtocol:StartTLS Port:587 ServerAddress:smtp.example.com]]" {
+		t.Errorf("Unexpected SMTP update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestEventServiceCreateEventSubscriptionSNMP tests creating SNMP
+// subscriptions.
+func TestEventServiceCreateEventSubscriptionSNMP(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header: http.Header{
+						"Location": []string{"/redfish/v1/EventService/Subscriptions/SNMP"},
+					},
+				},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	subscriptionURI, err := result.CreateEventSubscriptionSNMP(
+		context.Background(),
+		"snmp://trap.example.com:162",
+		[]EventType{AlertEventType},
+		SNMPv3EventDestinationProtocol,
+		SNMPSettings{
+			AuthenticationProtocol: HMACSHA96SNMPAuthenticationProtocols,
+			AuthenticationKey:      "authkey",
+			EncryptionProtocol:     CFB128AES128SNMPEncryptionProtocols,
+			EncryptionKey:          "privkey",
+		},
+		"noc",
+		nil,
+	)
+	if err != nil {
+		t.Errorf("Error making CreateEventSubscriptionSNMP call: %s", err)
+	}
+
+	if subscriptionURI != "/redfish/v1/EventService/Subscriptions/SNMP" {
+		t.Errorf("Unexpected subscription URI: %s", subscriptionURI)
+	}
+
+	calls := testClient.CapturedCalls()
+	if !strings.Contains(calls[0].Payload, "Protocol:SNMPv3") ||
+		!strings.Contains(calls[0].Payload, "SNMP:map[AuthenticationKey:authkey AuthenticationProtocol:HMAC_SHA96 EncryptionKey:privkey EncryptionProtocol:CFB128_AES128]") {
+		t.Errorf("Unexpected CreateEventSubscriptionSNMP payload: %s", calls[0].Payload)
+	}
+
+	invalid := []struct {
+		destination string
+		protocol    EventDestinationProtocol
+		snmp        SNMPSettings
+	}{
+		{"https://trap.example.com", SNMPv2cEventDestinationProtocol, SNMPSettings{TrapCommunity: "public"}},
+		{"snmp://trap.example.com:162", RedfishEventDestinationProtocol, SNMPSettings{}},
+		{"snmp://trap.example.com:162", SNMPv2cEventDestinationProtocol, SNMPSettings{AuthenticationProtocol: HMACMD5SNMPAuthenticationProtocols}},
+		{"snmp://trap.example.com:162", SNMPv3EventDestinationProtocol, SNMPSettings{AuthenticationProtocol: HMACMD5SNMPAuthenticationProtocols}},
+		{"snmp://trap.example.com:162", SNMPv3EventDestinationProtocol, SNMPSettings{EncryptionProtocol: CBCDESSNMPEncryptionProtocols, EncryptionKey: "key"}},
+	}
+	for _, test := range invalid {
+		_, err = result.CreateEventSubscriptionSNMP(context.Background(), test.destination,
+			[]EventType{AlertEventType}, test.protocol, test.snmp, "noc", nil)
+		if err == nil {
+			t.Errorf("Expected an error for %s %s %+v", test.destination, test.protocol, test.snmp)
+		}
+	}
+}
+
+// TestEventServiceCreateEventSubscriptionSMTP tests creating SMTP
+// subscriptions.
+func TestEventServiceCreateEventSubscriptionSMTP(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header: http.Header{
+						"Location": []string{"/redfish/v1/EventService/Subscriptions/SMTP"},
+					},
+				},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	_, err = result.CreateEventSubscriptionSMTP(context.Background(), "https://noc.example.com",
+		[]EventType{AlertEventType}, "noc", nil)
+	if err == nil {
+		t.Error("Expected an error for a destination that is not an email address")
+	}
+
+	subscriptionURI, err := result.CreateEventSubscriptionSMTP(context.Background(), "mailto:noc@example.com",
+		[]EventType{AlertEventType}, "noc", nil)
+	if err != nil {
+		t.Errorf("Error making CreateEventSubscriptionSMTP call: %s", err)
+	}
+
+	if subscriptionURI != "/redfish/v1/EventService/Subscriptions/SMTP" {
+		t.Errorf("Unexpected subscription URI: %s", subscriptionURI)
+	}
+
+	calls := testClient.CapturedCalls()
+	if !strings.Contains(calls[0].Payload, "Destination:mailto:noc@example.com") ||
+		!strings.Contains(calls[0].Payload, "Protocol:SMTP") {
+		t.Errorf("Unexpected CreateEventSubscriptionSMTP payload: %s", calls[0].Payload)
+	}
+}
@@ -0,0 +1,209 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// EventSubscriptionSpec describes the desired state of an event
+// subscription. The destination identifies the subscription: the service is
+// expected to have a single subscription for it.
+type EventSubscriptionSpec struct {
+	// Destination is the URL the events are sent to.
+	Destination string
+	// Context is the client supplied context sent with the events.
+	Context string
+	// EventTypes are the types of events to subscribe to. If empty, any
+	// event types configured on an existing subscription are accepted.
+	EventTypes []EventType
+	// RegistryPrefixes are the prefixes of the message registries to
+	// subscribe to. If empty, any prefixes configured on an existing
+	// subscription are accepted.
+	RegistryPrefixes []string
+	// ResourceTypes are the types of the resources to receive events from.
+	// If empty, any resource types configured on an existing subscription are
+	// accepted.
+	ResourceTypes []string
+	// DeliveryRetryPolicy is the optional policy applied when the events
+	// cannot be delivered.
+	DeliveryRetryPolicy DeliveryRetryPolicy
+	// HTTPHeaders are the optional headers sent with every event. They are
+	// not returned by the service so they are only sent when the
+	// subscription is created.
+	HTTPHeaders map[string]string
+	// Protocol is the protocol of the destination, Redfish if not set.
+	Protocol EventDestinationProtocol
+}
+
+// EventSubscriptionReconciliation describes the changes made to bring the
+// event subscriptions of the service to the desired state.
+type EventSubscriptionReconciliation struct {
+	// URI is the URI of the subscription matching the desired state.
+	URI string
+	// Created is true if the subscription had to be created.
+	Created bool
+	// Updated is true if the context or retry policy of an existing
+	// subscription was updated.
+	Updated bool
+	// Resumed is true if an existing subscription was suspended and has been
+	// resumed.
+	Resumed bool
+	// Deleted holds the URIs of the duplicate or outdated subscriptions that
+	// were removed.
+	Deleted []string
+}
+
+// ReconcileEventSubscription makes sure the service has exactly one
+// subscription matching spec. Subscriptions to the same destination are
+// looked up: one whose event types, registry prefixes and resource types
+// match is kept, its context and retry policy are updated if they drifted and
+// it is resumed if it was suspended, a subscription is created if none
+// matched. Every other subscription to the destination is deleted afterwards,
+// so events keep being delivered if creating or updating fails.
+func (eventservice *EventService) ReconcileEventSubscription(ctx context.Context, spec EventSubscriptionSpec) (*EventSubscriptionReconciliation, error) {
+	if len(strings.TrimSpace(spec.Destination)) == 0 {
+		return nil, fmt.Errorf("empty destination is not valid")
+	}
+	for _, et := range spec.EventTypes {
+		if !et.IsValidEventType() {
+			return nil, fmt.Errorf("invalid event type: %s", et)
+		}
+	}
+	if spec.Protocol == "" {
+		spec.Protocol = RedfishEventDestinationProtocol
+	}
+
+	subscriptions, err := eventservice.GetEventSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept *EventDestination
+	var stale []*EventDestination
+	for _, subscription := range subscriptions {
+		if !sameDestination(subscription.Destination, spec.Destination) {
+			continue
+		}
+		// Prefer a subscription that is not suspended.
+		if spec.matches(subscription) && (kept == nil ||
+			(kept.Status.State == common.StandbyOfflineState && subscription.Status.State != common.StandbyOfflineState)) {
+			if kept != nil {
+				stale = append(stale, kept)
+			}
+			kept = subscription
+			continue
+		}
+		stale = append(stale, subscription)
+	}
+
+	result := &EventSubscriptionReconciliation{}
+	err = eventservice.reconcileKept(ctx, spec, kept, result)
+	if err != nil {
+		return result, err
+	}
+
+	for _, subscription := range stale {
+		err = DeleteEventDestination(ctx, eventservice.Client, subscription.ODataID)
+		if err != nil {
+			return result, err
+		}
+		result.Deleted = append(result.Deleted, subscription.ODataID)
+	}
+
+	return result, nil
+}
+
+// reconcileKept creates the subscription if none matched the spec, or else
+// updates and resumes the kept one.
+func (eventservice *EventService) reconcileKept(ctx context.Context, spec EventSubscriptionSpec, kept *EventDestination, result *EventSubscriptionReconciliation) (err error) {
+	if kept == nil {
+		result.URI, err = postEventDestination(ctx, eventservice.Client, eventservice.subscriptions, &subscriptionPayload{
+			Destination:         spec.Destination,
+			EventTypes:          spec.EventTypes,
+			RegistryPrefixes:    spec.RegistryPrefixes,
+			ResourceTypes:       spec.ResourceTypes,
+			DeliveryRetryPolicy: spec.DeliveryRetryPolicy,
+			HTTPHeaders:         spec.HTTPHeaders,
+			Protocol:            spec.Protocol,
+			Context:             spec.Context,
+		})
+		result.Created = err == nil
+		return err
+	}
+
+	result.URI = kept.ODataID
+	if kept.Context != spec.Context ||
+		(spec.DeliveryRetryPolicy != "" && kept.DeliveryRetryPolicy != spec.DeliveryRetryPolicy) {
+		kept.Context = spec.Context
+		if spec.DeliveryRetryPolicy != "" {
+			kept.DeliveryRetryPolicy = spec.DeliveryRetryPolicy
+		}
+		err = kept.Update(ctx)
+		if err != nil {
+			return err
+		}
+		result.Updated = true
+	}
+
+	if kept.Status.State == common.StandbyOfflineState {
+		err = kept.ResumeSubscription(ctx)
+		if err != nil {
+			return err
+		}
+		result.Resumed = true
+	}
+
+	return nil
+}
+
+// matches returns whether the properties of the subscription that cannot be
+// updated match the spec.
+func (spec *EventSubscriptionSpec) matches(subscription *EventDestination) bool {
+	if subscription.Protocol != "" && subscription.Protocol != spec.Protocol {
+		return false
+	}
+
+	var wanted, got []string
+	for _, et := range spec.EventTypes {
+		wanted = append(wanted, string(et))
+	}
+	for _, et := range subscription.EventTypes {
+		got = append(got, string(et))
+	}
+
+	return sameSet(wanted, got) &&
+		sameSet(spec.RegistryPrefixes, subscription.RegistryPrefixes) &&
+		sameSet(spec.ResourceTypes, subscription.ResourceTypes)
+}
+
+// sameDestination compares two destination URLs, ignoring a trailing slash.
+func sameDestination(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// sameSet returns whether got holds the same values as wanted, in any order.
+// An empty wanted list matches anything.
+func sameSet(wanted, got []string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(stringSet(wanted), stringSet(got))
+}
+
+// stringSet returns the distinct values of a list.
+func stringSet(values []string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+	return result
+}
@@ -0,0 +1,171 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var subscriptionsBody = `{
+		"@odata.id": "/redfish/v1/EventService/Subscriptions",
+		"Name": "Event Subscriptions",
+		"Members": [
+			{"@odata.id": "/redfish/v1/EventService/Subscriptions/1"},
+			{"@odata.id": "/redfish/v1/EventService/Subscriptions/2"},
+			{"@odata.id": "/redfish/v1/EventService/Subscriptions/3"},
+			{"@odata.id": "/redfish/v1/EventService/Subscriptions/4"}
+		],
+		"Members@odata.count": 4
+	}`
+
+func subscriptionBody(id, destination, context, state string, eventTypes string) string {
+	return fmt.Sprintf(`{
+		"@odata.id": "/redfish/v1/EventService/Subscriptions/%[1]s",
+		"Id": "%[1]s",
+		"Name": "Subscription %[1]s",
+		"Destination": "%[2]s",
+		"Context": "%[3]s",
+		"Protocol": "Redfish",
+		"EventTypes": [%[5]s],
+		"DeliveryRetryPolicy": "SuspendRetries",
+		"Status": {"State": "%[4]s"},
+		"Actions": {
+			"#EventDestination.ResumeSubscription": {
+				"target": "/redfish/v1/EventService/Subscriptions/%[1]s/Actions/EventDestination.ResumeSubscription"
+			}
+		}
+	}`, id, destination, context, state, eventTypes)
+}
+
+func testResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Header:     make(http.Header),
+	}
+}
+
+// TestReconcileEventSubscription tests updating, resuming and removing
+// duplicates of an existing subscription.
+func TestReconcileEventSubscription(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(subscriptionsBody),
+				testResponse(subscriptionBody("1", "https://other.example.com/events", "Other", "Enabled", `"Alert"`)),
+				testResponse(subscriptionBody("2", "https://agent.example.com/events", "Old", "StandbyOffline", `"StatusChange", "Alert"`)),
+				testResponse(subscriptionBody("3", "https://agent.example.com/events/", "Old", "StandbyOffline", `"Alert", "StatusChange"`)),
+				testResponse(subscriptionBody("4", "https://agent.example.com/events", "Agent", "Enabled", `"Alert"`)),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	reconciliation, err := result.ReconcileEventSubscription(context.Background(), EventSubscriptionSpec{
+		Destination:         "https://agent.example.com/events",
+		Context:             "Agent",
+		EventTypes:          []EventType{AlertEventType, StatusChangeEventType},
+		DeliveryRetryPolicy: SuspendRetriesDeliveryRetryPolicy,
+	})
+	if err != nil {
+		t.Fatalf("Error reconciling subscription: %s", err)
+	}
+
+	if reconciliation.URI != "/redfish/v1/EventService/Subscriptions/2" {
+		t.Errorf("Unexpected subscription kept: %s", reconciliation.URI)
+	}
+
+	if reconciliation.Created || !reconciliation.Updated || !reconciliation.Resumed {
+		t.Errorf("Unexpected reconciliation: %+v", reconciliation)
+	}
+
+	if strings.Join(reconciliation.Deleted, ",") != "/redfish/v1/EventService/Subscriptions/3,/redfish/v1/EventService/Subscriptions/4" {
+		t.Errorf("Unexpected deleted subscriptions: %v", reconciliation.Deleted)
+	}
+
+	calls := testClient.CapturedCalls()
+	var actions []string
+	for _, call := range calls[5:] {
+		actions = append(actions, call.Action+" "+call.URL)
+	}
+	expected := []string{
+		"PATCH /redfish/v1/EventService/Subscriptions/2",
+		"POST /redfish/v1/EventService/Subscriptions/2/Actions/EventDestination.ResumeSubscription",
+		"DELETE /redfish/v1/EventService/Subscriptions/3",
+		"DELETE /redfish/v1/EventService/Subscriptions/4",
+	}
+	if strings.Join(actions, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected calls:\n%s", strings.Join(actions, "\n"))
+	}
+
+	if calls[5].Payload != "map[Context:Agent]" {
+		t.Errorf("Unexpected update payload: %s", calls[5].Payload)
+	}
+}
+
+// TestReconcileEventSubscriptionCreate tests creating a missing subscription.
+func TestReconcileEventSubscriptionCreate(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	created := testResponse("")
+	created.StatusCode = 201
+	created.Header.Set("Location", "https://bmc.example.com/redfish/v1/EventService/Subscriptions/5")
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(`{"Members": [{"@odata.id": "/redfish/v1/EventService/Subscriptions/1"}], "Members@odata.count": 1}`),
+				testResponse(subscriptionBody("1", "https://agent.example.com/events", "Agent", "Enabled", `"Alert"`)),
+			},
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	reconciliation, err := result.ReconcileEventSubscription(context.Background(), EventSubscriptionSpec{
+		Destination:      "https://agent.example.com/events",
+		Context:          "Agent",
+		RegistryPrefixes: []string{"Base"},
+	})
+	if err != nil {
+		t.Fatalf("Error reconciling subscription: %s", err)
+	}
+
+	if !reconciliation.Created || reconciliation.URI != "/redfish/v1/EventService/Subscriptions/5" {
+		t.Errorf("Unexpected reconciliation: %+v", reconciliation)
+	}
+
+	if len(reconciliation.Deleted) != 1 {
+		t.Errorf("Outdated subscription should be deleted: %v", reconciliation.Deleted)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[2].Action != http.MethodPost || calls[3].Action != http.MethodDelete {
+		t.Errorf("Subscription should be created before the outdated one is deleted: %s, %s", calls[2].Action, calls[3].Action)
+	}
+	payload := calls[2].Payload
+	if !strings.Contains(payload, "RegistryPrefixes:[Base]") || strings.Contains(payload, "EventTypes") {
+		t.Errorf("Unexpected create payload: %s", payload)
+	}
+}
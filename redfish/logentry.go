@@ -7,6 +7,10 @@ package redfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 
 	"github.com/jacobweinstock/gophish/common"
 )
@@ -314,10 +318,19 @@ type LogEntry struct {
 	ODataContext string `json:"@odata.context"`
 	// ODataType is the odata type.
 	ODataType string `json:"@odata.type"`
+	// AdditionalDataSizeBytes shall contain the size of the additional data
+	// referenced by the AdditionalDataURI property.
+	AdditionalDataSizeBytes int64
+	// AdditionalDataURI shall contain the URI of the additional data, such
+	// as diagnostic data, associated with this log entry.
+	AdditionalDataURI string
 	// Created shall be the time at which the log entry was created.
 	Created string
 	// Description provides a description of this resource.
 	Description string
+	// DiagnosticDataType shall contain the type of diagnostic data available
+	// at the AdditionalDataURI property.
+	DiagnosticDataType LogDiagnosticDataTypes
 	// EntryCode shall be present if the EntryType value is
 	// SEL. These enumerations are the values from tables 42-1 and 42-2 of
 	// the IPMI specification.
@@ -364,6 +377,10 @@ type LogEntry struct {
 	// entry was last modified. This property shall not appear if the log
 	// entry has not been modified since it was created.
 	Modified string
+	// OEMDiagnosticDataType shall contain the OEM-defined type of
+	// diagnostic data available at the AdditionalDataURI property, when
+	// DiagnosticDataType is OEM.
+	OEMDiagnosticDataType string
 	// OemLogEntryCode shall represent the OEM
 	// specific Log Entry Code type of the Entry. This property shall only
 	// be present if the value of EntryType is SEL and the value of
@@ -417,6 +434,40 @@ func (logentry *LogEntry) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// OriginOfCondition gets the URI of the resource the log entry is about.
+func (logentry *LogEntry) OriginOfCondition() string {
+	return logentry.originOfCondition
+}
+
+// DownloadAdditionalData streams the additional data of the log entry, such as
+// a crash dump, to the writer. The data is often binary, so it is requested
+// with any content type when the client supports it.
+func (logentry *LogEntry) DownloadAdditionalData(ctx context.Context, w io.Writer) error {
+	if logentry.AdditionalDataURI == "" {
+		return fmt.Errorf("the log entry has no additional data")
+	}
+
+	uri := logentry.AdditionalDataURI
+	if parsed, err := url.Parse(uri); err == nil && parsed.Host != "" {
+		uri = parsed.RequestURI()
+	}
+
+	var resp *http.Response
+	var err error
+	if client, ok := logentry.Client.(common.HeaderClient); ok {
+		resp, err = client.GetWithHeaders(ctx, uri, map[string]string{"Accept": "*/*"})
+	} else {
+		resp, err = logentry.Client.Get(ctx, uri)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
 // GetLogEntry will get a LogEntry instance from the service.
 func GetLogEntry(ctx context.Context, c common.Client, uri string) (*LogEntry, error) {
 	resp, err := c.Get(ctx, uri)
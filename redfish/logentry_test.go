@@ -5,9 +5,15 @@
 package redfish
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
 )
 
 var logEntryBody = strings.NewReader(
@@ -68,3 +74,51 @@ func TestLogEntry(t *testing.T) {
 		t.Errorf("Received log severity %s", result.Severity)
 	}
 }
+
+// TestLogEntryDownloadAdditionalData tests downloading the attachment of an
+// entry.
+func TestLogEntryDownloadAdditionalData(t *testing.T) {
+	var result LogEntry
+	err := json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Managers/BMC/LogServices/Dump/Entries/1",
+		"Id": "1",
+		"AdditionalDataURI": "https://bmc.example.com/redfish/v1/Managers/BMC/LogServices/Dump/Entries/1/attachment",
+		"AdditionalDataSizeBytes": 4,
+		"DiagnosticDataType": "Manager"
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	if result.DiagnosticDataType != ManagerLogDiagnosticDataTypes {
+		t.Errorf("Invalid diagnostic data type: %s", result.DiagnosticDataType)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("\x7fELF")),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	var buffer bytes.Buffer
+	err = result.DownloadAdditionalData(context.Background(), &buffer)
+	if err != nil {
+		t.Fatalf("Error downloading additional data: %s", err)
+	}
+
+	if buffer.String() != "\x7fELF" {
+		t.Errorf("Unexpected additional data: %q", buffer.String())
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Managers/BMC/LogServices/Dump/Entries/1/attachment" {
+		t.Errorf("Invalid download URI: %s", calls[0].URL)
+	}
+	if !strings.Contains(calls[0].Payload, "Accept:*/*") {
+		t.Errorf("Invalid download headers: %s", calls[0].Payload)
+	}
+}
@@ -7,11 +7,20 @@ package redfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/jacobweinstock/gophish/common"
 )
 
+// defaultLogTailInterval is how often Tail polls the log entries if no
+// interval is given.
+const defaultLogTailInterval = 30 * time.Second
+
 // LogEntryTypes is the type of log entry.
 type LogEntryTypes string
 
@@ -42,6 +51,22 @@ const (
 	NeverOverWritesOverWritePolicy OverWritePolicy = "NeverOverWrites"
 )
 
+// LogDiagnosticDataTypes is the type of diagnostic data collected by a log
+// service.
+type LogDiagnosticDataTypes string
+
+const (
+	// ManagerLogDiagnosticDataTypes is the diagnostic data of the manager.
+	ManagerLogDiagnosticDataTypes LogDiagnosticDataTypes = "Manager"
+	// PreOSLogDiagnosticDataTypes is the pre-OS diagnostic data.
+	PreOSLogDiagnosticDataTypes LogDiagnosticDataTypes = "PreOS"
+	// OSLogDiagnosticDataTypes is the operating system diagnostic data.
+	OSLogDiagnosticDataTypes LogDiagnosticDataTypes = "OS"
+	// OEMLogDiagnosticDataTypes is OEM diagnostic data, its type is given by
+	// the OEMDiagnosticDataType.
+	OEMLogDiagnosticDataTypes LogDiagnosticDataTypes = "OEM"
+)
+
 // LogService is used to represent a log service for a Redfish
 // implementation.
 type LogService struct {
@@ -83,8 +108,14 @@ type LogService struct {
 	ServiceEnabled bool
 	// Status shall contain any status or health properties of the resource.
 	Status common.Status
+	// AllowedDiagnosticDataTypes are the types of diagnostic data the
+	// service allows to collect, if it reports them.
+	AllowedDiagnosticDataTypes []LogDiagnosticDataTypes
 	// clearLogTarget is the URL to send ClearLog actions to.
 	clearLogTarget string
+	// collectDiagnosticDataTarget is the URL to send CollectDiagnosticData
+	// actions to.
+	collectDiagnosticDataTarget string
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -96,6 +127,10 @@ func (logservice *LogService) UnmarshalJSON(b []byte) error {
 		ClearLog struct {
 			Target string
 		} `json:"#LogService.ClearLog"`
+		CollectDiagnosticData struct {
+			AllowedDiagnosticDataTypes []LogDiagnosticDataTypes `json:"DiagnosticDataType@Redfish.AllowableValues"`
+			Target                     string
+		} `json:"#LogService.CollectDiagnosticData"`
 	}
 	var t struct {
 		temp
@@ -112,6 +147,8 @@ func (logservice *LogService) UnmarshalJSON(b []byte) error {
 	*logservice = LogService(t.temp)
 	logservice.entries = string(t.Entries)
 	logservice.clearLogTarget = t.Actions.ClearLog.Target
+	logservice.AllowedDiagnosticDataTypes = t.Actions.CollectDiagnosticData.AllowedDiagnosticDataTypes
+	logservice.collectDiagnosticDataTarget = t.Actions.CollectDiagnosticData.Target
 
 	// This is a read/write object, so we need to save the raw object data for later
 	logservice.rawData = b
@@ -198,3 +235,400 @@ func (logservice *LogService) ClearLog(ctx context.Context) error {
 	_, err := logservice.Client.Post(ctx, logservice.clearLogTarget, t)
 	return err
 }
+
+// CollectDiagnosticData asks the service to collect diagnostic data, such as
+// a crash dump of the manager or the operating system. The oemType names the
+// type of data to collect when dataType is OEM, and is ignored otherwise. The
+// data is added to the log as an entry whose additional data can be
+// downloaded with DownloadAdditionalData. The task monitoring the collection
+// is returned, or nil if the service collected the data right away.
+func (logservice *LogService) CollectDiagnosticData(ctx context.Context, dataType LogDiagnosticDataTypes, oemType string) (*Task, error) {
+	if logservice.collectDiagnosticDataTarget == "" {
+		return nil, fmt.Errorf("the log service does not support collecting diagnostic data")
+	}
+
+	if len(logservice.AllowedDiagnosticDataTypes) > 0 {
+		allowed := false
+		for _, allowedType := range logservice.AllowedDiagnosticDataTypes {
+			if dataType == allowedType {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("diagnostic data type '%s' is not supported by this service", dataType)
+		}
+	}
+
+	t := struct {
+		DiagnosticDataType    LogDiagnosticDataTypes
+		OEMDiagnosticDataType string `json:",omitempty"`
+	}{DiagnosticDataType: dataType}
+	if dataType == OEMLogDiagnosticDataTypes {
+		if oemType == "" {
+			return nil, fmt.Errorf("an OEM diagnostic data type is required to collect OEM diagnostic data")
+		}
+		t.OEMDiagnosticDataType = oemType
+	}
+
+	resp, err := logservice.Client.Post(ctx, logservice.collectDiagnosticDataTarget, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return TaskFromResponse(ctx, logservice.Client, resp)
+}
+
+// LogEntryFilter selects log entries. Entries must match every criterion that
+// is set, and any of the values of a criterion.
+type LogEntryFilter struct {
+	// Since selects the entries created at or after this time.
+	Since time.Time
+	// Until selects the entries created at or before this time.
+	Until time.Time
+	// Severities selects the entries with one of these severities.
+	Severities []EventSeverity
+	// EntryTypes selects the entries of one of these types.
+	EntryTypes []LogEntryType
+	// MessageIDs selects the entries with one of these message IDs.
+	MessageIDs []string
+	// SensorTypes selects the SEL entries of one of these sensor types.
+	SensorTypes []SensorType
+	// Skip is the number of matching entries to skip.
+	Skip int
+	// Top is the maximum number of matching entries to return, all of them
+	// if zero.
+	Top int
+}
+
+// query returns the $filter expression of the filter.
+func (filter *LogEntryFilter) query() string {
+	var expressions []string
+	if !filter.Since.IsZero() {
+		expressions = append(expressions, "Created ge "+filterLiteral(filter.Since.Format(time.RFC3339)))
+	}
+	if !filter.Until.IsZero() {
+		expressions = append(expressions, "Created le "+filterLiteral(filter.Until.Format(time.RFC3339)))
+	}
+
+	var severities, entryTypes, sensorTypes []string
+	for _, severity := range filter.Severities {
+		severities = append(severities, string(severity))
+	}
+	for _, entryType := range filter.EntryTypes {
+		entryTypes = append(entryTypes, string(entryType))
+	}
+	for _, sensorType := range filter.SensorTypes {
+		sensorTypes = append(sensorTypes, string(sensorType))
+	}
+
+	properties := []struct {
+		name   string
+		values []string
+	}{
+		{"Severity", severities},
+		{"EntryType", entryTypes},
+		{"MessageId", filter.MessageIDs},
+		{"SensorType", sensorTypes},
+	}
+	for _, property := range properties {
+		if len(property.values) > 0 {
+			expressions = append(expressions, filterAnyOf(property.name, property.values))
+		}
+	}
+
+	return strings.Join(expressions, " and ")
+}
+
+// matches returns whether a log entry matches the filter. Entries without a
+// valid creation time cannot be placed in time, so they are not filtered out
+// by Since and Until.
+func (filter *LogEntryFilter) matches(entry *LogEntry) bool {
+	if !filter.Since.IsZero() || !filter.Until.IsZero() {
+		created, err := parseEventTimestamp(entry.Created)
+		if err == nil &&
+			((!filter.Since.IsZero() && created.Before(filter.Since)) ||
+				(!filter.Until.IsZero() && created.After(filter.Until))) {
+			return false
+		}
+	}
+
+	var severities, entryTypes, sensorTypes []string
+	for _, severity := range filter.Severities {
+		severities = append(severities, string(severity))
+	}
+	for _, entryType := range filter.EntryTypes {
+		entryTypes = append(entryTypes, string(entryType))
+	}
+	for _, sensorType := range filter.SensorTypes {
+		sensorTypes = append(sensorTypes, string(sensorType))
+	}
+
+	return anyOf(severities, string(entry.Severity)) &&
+		anyOf(entryTypes, string(entry.EntryType)) &&
+		anyOf(filter.MessageIDs, entry.MessageID) &&
+		anyOf(sensorTypes, string(entry.SensorType))
+}
+
+// anyOf returns whether value is one of the wanted values. An empty wanted
+// list matches anything.
+func anyOf(wanted []string, value string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	return stringSet(wanted)[value]
+}
+
+// QueryEntries gets the log entries matching the filter. The filter is sent
+// to the service with the $filter and $top query parameters, while Skip is
+// applied by the client as services may ignore $skip. If the service rejects
+// the query, or ignores it and returns entries not matching the filter, all
+// the entries are read and filtered by the client instead.
+func (logservice *LogService) QueryEntries(ctx context.Context, filter LogEntryFilter) ([]*LogEntry, error) {
+	entries, _, err := logservice.queryEntries(ctx, filter, false)
+	return entries, err
+}
+
+// queryEntries gets the log entries matching the filter, only reading the
+// first page of the collection if firstPageOnly is set. It returns whether
+// only the first page should be read by later queries, as the service was
+// found to ignore $skip in its next links.
+func (logservice *LogService) queryEntries(ctx context.Context, filter LogEntryFilter, firstPageOnly bool) ([]*LogEntry, bool, error) {
+	if logservice.entries == "" {
+		return nil, firstPageOnly, nil
+	}
+
+	params := url.Values{}
+	if query := filter.query(); query != "" {
+		params.Set("$filter", query)
+	}
+	if filter.Top > 0 {
+		params.Set("$top", fmt.Sprint(filter.Skip+filter.Top))
+	}
+
+	var entries []*LogEntry
+	queried := false
+	if len(params) > 0 {
+		separator := "?"
+		if strings.Contains(logservice.entries, "?") {
+			separator = "&"
+		}
+		uri := logservice.entries + separator + strings.ReplaceAll(params.Encode(), "+", "%20")
+
+		result, ignoresSkip, err := getLogEntries(ctx, logservice.Client, uri, firstPageOnly)
+		firstPageOnly = firstPageOnly || ignoresSkip
+		if err == nil {
+			queried = true
+			for _, entry := range result {
+				if !filter.matches(entry) {
+					queried = false
+					break
+				}
+			}
+			entries = result
+		}
+	}
+
+	if !queried {
+		result, ignoresSkip, err := getLogEntries(ctx, logservice.Client, logservice.entries, firstPageOnly)
+		firstPageOnly = firstPageOnly || ignoresSkip
+		if err != nil {
+			return nil, firstPageOnly, err
+		}
+		entries = result
+	}
+
+	var result []*LogEntry
+	skipped := 0
+	for _, entry := range entries {
+		if !filter.matches(entry) {
+			continue
+		}
+		if skipped < filter.Skip {
+			skipped++
+			continue
+		}
+		result = append(result, entry)
+		if filter.Top > 0 && len(result) == filter.Top {
+			break
+		}
+	}
+
+	return result, firstPageOnly, nil
+}
+
+// getLogEntries reads the log entries of a collection, following the next
+// links of paged collections unless firstPageOnly is set. Members included in
+// the collection are used as they are, the others are read one by one. If a
+// next page starts with the same entry as the first page the service ignored
+// $skip, paging stops there and ignoresSkip is returned.
+func getLogEntries(ctx context.Context, c common.Client, uri string, firstPageOnly bool) (result []*LogEntry, ignoresSkip bool, err error) {
+	var first string
+	for uri != "" {
+		resp, err := c.Get(ctx, uri)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var page struct {
+			Members  []json.RawMessage
+			NextLink string `json:"Members@odata.nextLink"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, false, err
+		}
+
+		for i, member := range page.Members {
+			var entry LogEntry
+			err = json.Unmarshal(member, &entry)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if i == 0 {
+				key := logEntryKey(&entry)
+				if first == "" {
+					first = key
+				} else if key == first {
+					return result, true, nil
+				}
+			}
+
+			if entry.ID == "" && entry.ODataID != "" {
+				fetched, err := GetLogEntry(ctx, c, entry.ODataID)
+				if err != nil {
+					return nil, false, err
+				}
+				entry = *fetched
+			}
+
+			entry.SetClient(c)
+			result = append(result, &entry)
+		}
+
+		if firstPageOnly {
+			break
+		}
+		uri = page.NextLink
+	}
+
+	return result, false, nil
+}
+
+// logEntryKey returns what identifies a log entry, its URI or else its ID.
+func logEntryKey(entry *LogEntry) string {
+	if entry.ODataID != "" {
+		return entry.ODataID
+	}
+	return entry.ID
+}
+
+// Tail polls the log entries every interval, 30 seconds if zero, and returns a
+// channel receiving the entries created at or after since, in the order they
+// were created. Entries already sent are tracked by their creation time and
+// ID, so each entry is only sent once. Entries without a valid creation time
+// are sent after the others, once per ID. The first poll is done before returning so
+// its error is reported, later polls that fail are retried at the next
+// interval. The channel is closed once the context is done.
+func (logservice *LogService) Tail(ctx context.Context, since time.Time, interval time.Duration) (<-chan *LogEntry, error) {
+	tail := &logTail{last: since, seen: make(map[string]bool), undated: make(map[string]bool)}
+	entries, err := tail.poll(ctx, logservice)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		interval = defaultLogTailInterval
+	}
+
+	result := make(chan *LogEntry)
+	go func() {
+		defer close(result)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			for _, entry := range entries {
+				select {
+				case result <- entry:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+
+			entries, _ = tail.poll(ctx, logservice)
+		}
+	}()
+
+	return result, nil
+}
+
+// logTail tracks the log entries already sent by Tail.
+type logTail struct {
+	// last is the creation time of the newest entry sent.
+	last time.Time
+	// seen holds the IDs of the entries created at last that were sent.
+	seen map[string]bool
+	// undated holds the IDs of the entries without a valid creation time
+	// that were sent.
+	undated map[string]bool
+	// firstPageOnly is set once the service returned the first page of the
+	// entries again for a next link, only the first page is read from then
+	// on.
+	firstPageOnly bool
+}
+
+// poll returns the entries created since the last poll, oldest first.
+func (tail *logTail) poll(ctx context.Context, logservice *LogService) ([]*LogEntry, error) {
+	entries, firstPageOnly, err := logservice.queryEntries(ctx, LogEntryFilter{Since: tail.last}, tail.firstPageOnly)
+	tail.firstPageOnly = firstPageOnly
+	if err != nil {
+		return nil, err
+	}
+
+	type dated struct {
+		entry   *LogEntry
+		created time.Time
+	}
+	var fresh []dated
+	var undated []*LogEntry
+	for _, entry := range entries {
+		created, err := parseEventTimestamp(entry.Created)
+		if err != nil {
+			if !tail.undated[entry.ID] {
+				tail.undated[entry.ID] = true
+				undated = append(undated, entry)
+			}
+			continue
+		}
+		if created.Before(tail.last) || (created.Equal(tail.last) && tail.seen[entry.ID]) {
+			continue
+		}
+		fresh = append(fresh, dated{entry, created})
+	}
+
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].created.Before(fresh[j].created)
+	})
+
+	result := make([]*LogEntry, 0, len(fresh)+len(undated))
+	for _, item := range fresh {
+		if item.created.After(tail.last) {
+			tail.last = item.created
+			tail.seen = make(map[string]bool)
+		}
+		tail.seen[item.entry.ID] = true
+		result = append(result, item.entry)
+	}
+
+	return append(result, undated...), nil
+}
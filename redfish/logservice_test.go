@@ -5,10 +5,16 @@
 package redfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jacobweinstock/gophish/common"
 )
@@ -35,6 +41,10 @@ var logServiceBody = `{
 		"Actions": {
 			"#LogService.ClearLog": {
 				"target": "/redfish/v1/Managers/BMC/LogServices/Log/Actions/LogService.ClearLog"
+			},
+			"#LogService.CollectDiagnosticData": {
+				"target": "/redfish/v1/Managers/BMC/LogServices/Log/Actions/LogService.CollectDiagnosticData",
+				"DiagnosticDataType@Redfish.AllowableValues": ["Manager", "OEM"]
 			}
 		}
 	}`
@@ -106,3 +116,304 @@ func TestLogServiceUpdate(t *testing.T) {
 		t.Errorf("Unexpected ServiceEnabled update payload: %s", calls[0].Payload)
 	}
 }
+
+// logEntriesResponse returns a log entry collection response holding the
+// entries.
+func logEntriesResponse(entries ...string) *http.Response {
+	body := `{"Members@odata.count": ` + strconv.Itoa(len(entries)) +
+		`, "Members": [` + strings.Join(entries, ",") + `]}`
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+var (
+	criticalLogEntry = `{"@odata.id": "/redfish/v1/LogEntryCollection/1", "Id": "1",
+		"Created": "2021-03-01T10:00:00Z", "EntryType": "Event", "Severity": "Critical", "MessageId": "Base.1.8.Failure"}`
+	warningLogEntry = `{"@odata.id": "/redfish/v1/LogEntryCollection/2", "Id": "2",
+		"Created": "2021-03-01T11:00:00Z", "EntryType": "Event", "Severity": "Warning", "MessageId": "Base.1.8.Warning"}`
+	okLogEntry = `{"@odata.id": "/redfish/v1/LogEntryCollection/3", "Id": "3",
+		"Created": "2021-03-01T12:00:00Z", "EntryType": "Event", "Severity": "OK", "MessageId": "Base.1.8.Success"}`
+	laterOKLogEntry = `{"@odata.id": "/redfish/v1/LogEntryCollection/4", "Id": "4",
+		"Created": "2021-03-01T12:00:00Z", "EntryType": "Event", "Severity": "OK", "MessageId": "Base.1.8.Success"}`
+	undatedLogEntry = `{"@odata.id": "/redfish/v1/LogEntryCollection/5", "Id": "5",
+		"EntryType": "Event", "Severity": "OK", "MessageId": "Base.1.8.Success"}`
+)
+
+// TestLogEntryFilterQuery tests the $filter expression of a filter.
+func TestLogEntryFilterQuery(t *testing.T) {
+	filter := LogEntryFilter{
+		Since:      time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+		Severities: []EventSeverity{WarningEventSeverity, CriticalEventSeverity},
+		MessageIDs: []string{"Base.1.8.Failure"},
+	}
+
+	expected := "Created ge '2021-03-01T10:00:00Z' and (Severity eq 'Warning' or Severity eq 'Critical')" +
+		" and MessageId eq 'Base.1.8.Failure'"
+	if query := filter.query(); query != expected {
+		t.Errorf("Invalid filter query: %s", query)
+	}
+}
+
+// TestLogServiceQueryEntries tests sending the filter to the service.
+func TestLogServiceQueryEntries(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {logEntriesResponse(criticalLogEntry)},
+		},
+	}
+	result.SetClient(testClient)
+
+	entries, err := result.QueryEntries(context.Background(), LogEntryFilter{
+		Severities: []EventSeverity{CriticalEventSeverity},
+		Top:        5,
+	})
+	if err != nil {
+		t.Errorf("Error querying entries: %s", err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != "1" {
+		t.Errorf("Unexpected entries: %v", entries)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Unexpected calls: %v", calls)
+	}
+	uri, err := url.Parse(calls[0].URL)
+	if err != nil {
+		t.Fatalf("Invalid request URI: %s", calls[0].URL)
+	}
+	if uri.Query().Get("$filter") != "Severity eq 'Critical'" || uri.Query().Get("$top") != "5" {
+		t.Errorf("Invalid query: %s", calls[0].URL)
+	}
+	if strings.Contains(calls[0].URL, "+") {
+		t.Errorf("Spaces should be escaped as %%20: %s", calls[0].URL)
+	}
+}
+
+// TestLogServiceQueryEntriesIgnoredFilter tests filtering the entries on the
+// client when the service ignores the filter.
+func TestLogServiceQueryEntriesIgnoredFilter(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				logEntriesResponse(criticalLogEntry, warningLogEntry, okLogEntry),
+				logEntriesResponse(criticalLogEntry, warningLogEntry, okLogEntry),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	entries, err := result.QueryEntries(context.Background(), LogEntryFilter{
+		Since: time.Date(2021, 3, 1, 10, 30, 0, 0, time.UTC),
+		Skip:  1,
+		Top:   1,
+	})
+	if err != nil {
+		t.Errorf("Error querying entries: %s", err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != "3" {
+		t.Errorf("Unexpected entries: %v", entries)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[1].URL != "/redfish/v1/LogEntryCollection" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+// TestLogServiceQueryEntriesSkip tests skipping entries on the client, as
+// the service may apply the filter but ignore $skip.
+func TestLogServiceQueryEntriesSkip(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {logEntriesResponse(criticalLogEntry, warningLogEntry)},
+		},
+	}
+	result.SetClient(testClient)
+
+	entries, err := result.QueryEntries(context.Background(), LogEntryFilter{
+		Severities: []EventSeverity{WarningEventSeverity, CriticalEventSeverity},
+		Skip:       1,
+	})
+	if err != nil {
+		t.Errorf("Error querying entries: %s", err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != "2" {
+		t.Errorf("Unexpected entries: %v", entries)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || strings.Contains(calls[0].URL, "skip") {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+// TestLogServiceQueryEntriesIgnoredSkip tests that paging stops when the
+// service returns the first page again for a next link.
+func TestLogServiceQueryEntriesIgnoredSkip(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	page := func() *http.Response {
+		body := `{"Members@odata.count": 4, "Members": [` + criticalLogEntry + `,` + warningLogEntry + `],
+			"Members@odata.nextLink": "/redfish/v1/LogEntryCollection?$skip=2"}`
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString(body))}
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {page(), page(), page(), page()},
+		},
+	}
+	result.SetClient(testClient)
+
+	for i := 0; i < 2; i++ {
+		entries, err := result.QueryEntries(context.Background(), LogEntryFilter{})
+		if err != nil {
+			t.Fatalf("Error querying entries: %s", err)
+		}
+		if len(entries) != 2 || entries[0].ID != "1" || entries[1].ID != "2" {
+			t.Errorf("Unexpected entries: %v", entries)
+		}
+	}
+
+	// Each query follows the next link once to find out the service ignores
+	// $skip.
+	if calls := testClient.CapturedCalls(); len(calls) != 4 {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+// TestLogEntryFilterUndated tests that entries without a creation time are
+// not filtered out by time.
+func TestLogEntryFilterUndated(t *testing.T) {
+	var entry LogEntry
+	err := json.Unmarshal([]byte(undatedLogEntry), &entry)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	filter := LogEntryFilter{Since: time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)}
+	if !filter.matches(&entry) {
+		t.Error("Undated entry should match a time filter")
+	}
+	filter.Severities = []EventSeverity{CriticalEventSeverity}
+	if filter.matches(&entry) {
+		t.Error("Undated entry should still be filtered by severity")
+	}
+}
+
+// TestLogServiceTail tests that tailing sends each new entry once.
+func TestLogServiceTail(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	responses := []interface{}{
+		logEntriesResponse(okLogEntry, undatedLogEntry, warningLogEntry),
+		logEntriesResponse(okLogEntry, undatedLogEntry, laterOKLogEntry),
+	}
+	// The service may be polled again before the tail is canceled.
+	for i := 0; i < 10; i++ {
+		responses = append(responses, logEntriesResponse())
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{http.MethodGet: responses},
+	}
+	result.SetClient(testClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	entries, err := result.Tail(ctx, time.Date(2021, 3, 1, 11, 0, 0, 0, time.UTC), time.Millisecond)
+	if err != nil {
+		t.Fatalf("Error tailing entries: %s", err)
+	}
+
+	var ids []string
+	for len(ids) < 4 {
+		ids = append(ids, (<-entries).ID)
+	}
+	cancel()
+
+	if strings.Join(ids, ",") != "2,3,5,4" {
+		t.Errorf("Unexpected entries: %v", ids)
+	}
+}
+
+// TestLogServiceCollectDiagnosticData tests the CollectDiagnosticData call.
+func TestLogServiceCollectDiagnosticData(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {acceptedResponse(
+				`{"@odata.id": "/redfish/v1/TaskService/Tasks/3", "Id": "3", "TaskState": "New"}`,
+				"/redfish/v1/TaskService/TaskMonitors/3")},
+		},
+	}
+	result.SetClient(testClient)
+
+	task, err := result.CollectDiagnosticData(context.Background(), OEMLogDiagnosticDataTypes, "CrashDump")
+	if err != nil {
+		t.Fatalf("Error making CollectDiagnosticData call: %s", err)
+	}
+
+	if task.ID != "3" || task.TaskMonitor != "/redfish/v1/TaskService/TaskMonitors/3" {
+		t.Errorf("Unexpected task: %v", task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Managers/BMC/LogServices/Log/Actions/LogService.CollectDiagnosticData" {
+		t.Errorf("Invalid CollectDiagnosticData target: %s", calls[0].URL)
+	}
+	if !strings.Contains(calls[0].Payload, "DiagnosticDataType:OEM") ||
+		!strings.Contains(calls[0].Payload, "OEMDiagnosticDataType:CrashDump") {
+		t.Errorf("Unexpected CollectDiagnosticData payload: %s", calls[0].Payload)
+	}
+
+	_, err = result.CollectDiagnosticData(context.Background(), OSLogDiagnosticDataTypes, "")
+	if err == nil {
+		t.Error("Expected an error for a data type the service does not allow")
+	}
+
+	_, err = result.CollectDiagnosticData(context.Background(), OEMLogDiagnosticDataTypes, "")
+	if err == nil {
+		t.Error("Expected an error for OEM data without OEM type")
+	}
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Errorf("Invalid requests should not be sent: %v", testClient.CapturedCalls())
+	}
+}
@@ -0,0 +1,203 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// MessageRegistryMessage shall define a message of a message registry.
+type MessageRegistryMessage struct {
+	// Description shall indicate how and when the message is returned by the
+	// Redfish service.
+	Description string
+	// Message shall contain the message to display. If a %integer is
+	// included in part of the string, it shall represent a string
+	// substitution for any MessageArgs that accompany the message, in order.
+	Message string
+	// NumberOfArgs shall contain the number of arguments that are substituted
+	// for the locations marked with %<integer> in the message.
+	NumberOfArgs int
+	// ParamTypes shall contain an ordered array of argument data types that
+	// match the data types of the MessageArgs.
+	ParamTypes []string
+	// Resolution shall contain an override of the resolution of the message
+	// in the message registry, if present.
+	Resolution string
+	// Severity shall contain the severity of the condition resulting in the
+	// message.
+	Severity string
+}
+
+// MessageRegistry shall contain the set of messages of a registry, such as
+// the Base registry, used to resolve the MessageId of events and log entries
+// to text.
+type MessageRegistry struct {
+	common.Entity
+
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Language shall contain an RFC5646-conformant language code.
+	Language string
+	// Messages shall contain the message keys contained in the message
+	// registry.
+	Messages map[string]MessageRegistryMessage
+	// OwningEntity shall represent the publisher of this message registry.
+	OwningEntity string
+	// RegistryPrefix shall contain the Redfish Specification-defined prefix
+	// used in forming and decoding MessageIds that uniquely identifies all
+	// messages that belong to this message registry.
+	RegistryPrefix string
+	// RegistryVersion shall contain the version of this message registry.
+	RegistryVersion string
+}
+
+// GetMessageRegistry will get a MessageRegistry instance from the service.
+func GetMessageRegistry(ctx context.Context, c common.Client, uri string) (*MessageRegistry, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var messageregistry MessageRegistry
+	err = json.NewDecoder(resp.Body).Decode(&messageregistry)
+	if err != nil {
+		return nil, err
+	}
+
+	messageregistry.SetClient(c)
+	return &messageregistry, nil
+}
+
+// ListReferencedMessageRegistries downloads the message registries of the
+// registries collection found at registriesLink, in the provided language.
+// Registries of other kinds, such as attribute registries, are skipped.
+func ListReferencedMessageRegistries(ctx context.Context, c common.Client, registriesLink string, language string) (MessageRegistries, error) {
+	var result MessageRegistries
+
+	files, err := ListReferencedMessageRegistryFiles(ctx, c, registriesLink)
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		uri := file.LocationURI(language)
+		if uri == "" {
+			continue
+		}
+
+		registry, err := GetMessageRegistry(ctx, c, uri)
+		if err != nil {
+			return result, err
+		}
+		if len(registry.Messages) == 0 {
+			continue
+		}
+		result = append(result, registry)
+	}
+
+	return result, nil
+}
+
+// Format returns the text of a message of the registry, with the arguments
+// substituted for the %1, %2... placeholders. The key is the last part of a
+// MessageId, such as ResourceAtUriUnauthorized. It returns false if the
+// registry does not define the message.
+func (messageregistry *MessageRegistry) Format(key string, args []string) (string, bool) {
+	message, ok := messageregistry.Messages[key]
+	if !ok {
+		return "", false
+	}
+
+	var builder strings.Builder
+	text := message.Message
+	for i := 0; i < len(text); i++ {
+		if text[i] != '%' {
+			builder.WriteByte(text[i])
+			continue
+		}
+
+		end := i + 1
+		for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+			end++
+		}
+		index, err := strconv.Atoi(text[i+1 : end])
+		if err != nil || index < 1 || index > len(args) {
+			builder.WriteByte(text[i])
+			continue
+		}
+
+		builder.WriteString(args[index-1])
+		i = end - 1
+	}
+
+	return builder.String(), true
+}
+
+// MessageRegistries are the message registries of a service.
+type MessageRegistries []*MessageRegistry
+
+// Format returns the text of a message from its MessageId, such as
+// Base.1.8.ResourceAtUriUnauthorized, with the arguments substituted. The
+// registry is found by the prefix of the MessageId and the newest registry
+// with that prefix is used if the version does not match. It returns false if
+// no registry defines the message.
+func (registries MessageRegistries) Format(messageID string, args []string) (string, bool) {
+	parts := strings.Split(messageID, ".")
+	if len(parts) < 2 {
+		return "", false
+	}
+	prefix := parts[0]
+	key := parts[len(parts)-1]
+	version := strings.Join(parts[1:len(parts)-1], ".")
+
+	var fallback *MessageRegistry
+	for _, registry := range registries {
+		if registry.RegistryPrefix != prefix {
+			continue
+		}
+		if version != "" && strings.HasPrefix(registry.RegistryVersion+".", version+".") {
+			if text, ok := registry.Format(key, args); ok {
+				return text, true
+			}
+		}
+		if fallback == nil || compareVersions(registry.RegistryVersion, fallback.RegistryVersion) > 0 {
+			fallback = registry
+		}
+	}
+
+	if fallback == nil {
+		return "", false
+	}
+	return fallback.Format(key, args)
+}
+
+// compareVersions compares two dotted version strings, such as 1.8.0 and
+// 1.10.1, number by number.
+func compareVersions(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numberA, numberB int
+		if i < len(partsA) {
+			numberA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numberB, _ = strconv.Atoi(partsB[i])
+		}
+		if numberA != numberB {
+			return numberA - numberB
+		}
+	}
+	return 0
+}
@@ -0,0 +1,101 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var messageRegistryBody = `{
+		"@odata.type": "#MessageRegistry.v1_4_1.MessageRegistry",
+		"Id": "Base.1.8.1",
+		"Name": "Base Message Registry",
+		"Language": "en",
+		"OwningEntity": "DMTF",
+		"RegistryPrefix": "Base",
+		"RegistryVersion": "1.8.1",
+		"Messages": {
+			"ResourceAtUriUnauthorized": {
+				"Description": "Indicates that the attempt to access the resource/file/image at the URI was unauthorized.",
+				"Message": "While accessing the resource at %1, the service received an authorization error %2.",
+				"NumberOfArgs": 2,
+				"ParamTypes": ["string", "string"],
+				"Resolution": "Ensure that the appropriate access is provided for the service.",
+				"Severity": "Critical"
+			},
+			"Success": {
+				"Description": "Indicates that all conditions of a successful operation have been met.",
+				"Message": "Successfully Completed Request",
+				"NumberOfArgs": 0,
+				"Resolution": "None",
+				"Severity": "OK"
+			}
+		}
+	}`
+
+// TestMessageRegistry tests the parsing of MessageRegistry objects.
+func TestMessageRegistry(t *testing.T) {
+	var result MessageRegistry
+	err := json.NewDecoder(strings.NewReader(messageRegistryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.RegistryPrefix != "Base" {
+		t.Errorf("Invalid registry prefix: %s", result.RegistryPrefix)
+	}
+
+	if result.Messages["ResourceAtUriUnauthorized"].NumberOfArgs != 2 {
+		t.Errorf("Invalid number of arguments: %d", result.Messages["ResourceAtUriUnauthorized"].NumberOfArgs)
+	}
+}
+
+// TestMessageRegistriesFormat tests resolving message IDs to text.
+func TestMessageRegistriesFormat(t *testing.T) {
+	var registry MessageRegistry
+	err := json.Unmarshal([]byte(messageRegistryBody), &registry)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	registries := MessageRegistries{&registry}
+
+	tests := []struct {
+		messageID string
+		args      []string
+		expected  string
+		ok        bool
+	}{
+		{"Base.1.8.ResourceAtUriUnauthorized", []string{"/redfish/v1/Systems/1", "401"},
+			"While accessing the resource at /redfish/v1/Systems/1, the service received an authorization error 401.", true},
+		// The newest registry is used for another version.
+		{"Base.1.4.Success", nil, "Successfully Completed Request", true},
+		// Missing arguments are left as placeholders.
+		{"Base.ResourceAtUriUnauthorized", []string{"/redfish/v1"},
+			"While accessing the resource at /redfish/v1, the service received an authorization error %2.", true},
+		{"Base.1.8.Unknown", nil, "", false},
+		{"Other.1.0.Success", nil, "", false},
+		{"Success", nil, "", false},
+	}
+
+	for _, test := range tests {
+		text, ok := registries.Format(test.messageID, test.args)
+		if ok != test.ok || text != test.expected {
+			t.Errorf("Unexpected text for %s: %q %v", test.messageID, text, ok)
+		}
+	}
+}
+
+// TestCompareVersions tests the ordering of registry versions.
+func TestCompareVersions(t *testing.T) {
+	if compareVersions("1.10.0", "1.8.1") <= 0 {
+		t.Error("1.10.0 should be newer than 1.8.1")
+	}
+	if compareVersions("1.8", "1.8.0") != 0 {
+		t.Error("1.8 should equal 1.8.0")
+	}
+}
@@ -0,0 +1,118 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// MessageRegistryFileLocation shall contain the location information for a
+// registry file.
+type MessageRegistryFileLocation struct {
+	// ArchiveFile shall contain the file name of the individual registry file
+	// within the archive file specified by the ArchiveURI property.
+	ArchiveFile string
+	// ArchiveURI shall contain a URI that is colocated with the Redfish
+	// service that specifies the location of the registry file, which can be
+	// retrieved using the Redfish protocol and authentication methods.
+	ArchiveURI string `json:"ArchiveUri"`
+	// Language shall contain an RFC5646-conformant language code or
+	// `default`.
+	Language string
+	// PublicationURI shall contain a URI not colocated with the Redfish
+	// service that specifies the canonical location of the registry file.
+	PublicationURI string `json:"PublicationUri"`
+	// URI shall contain a URI colocated with the Redfish service that
+	// specifies the location of the registry file, which can be retrieved
+	// using the Redfish protocol and authentication methods.
+	URI string `json:"Uri"`
+}
+
+// MessageRegistryFile describes a registry file (message registry, attribute
+// registry, etc.) that is available from the service.
+type MessageRegistryFile struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Languages shall contain a string array of RFC5646-conformant language
+	// codes.
+	Languages []string
+	// Location shall contain the location information for this registry
+	// file.
+	Location []MessageRegistryFileLocation
+	// Registry shall contain the registry name and its major and minor
+	// versions, such as BiosAttributeRegistryP89.v1_0.
+	Registry string
+}
+
+// GetMessageRegistryFile will get a MessageRegistryFile instance from the
+// service.
+func GetMessageRegistryFile(ctx context.Context, c common.Client, uri string) (*MessageRegistryFile, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var messageregistryfile MessageRegistryFile
+	err = json.NewDecoder(resp.Body).Decode(&messageregistryfile)
+	if err != nil {
+		return nil, err
+	}
+
+	messageregistryfile.SetClient(c)
+	return &messageregistryfile, nil
+}
+
+// ListReferencedMessageRegistryFiles gets the collection of
+// MessageRegistryFile from a provided reference.
+func ListReferencedMessageRegistryFiles(ctx context.Context, c common.Client, link string) ([]*MessageRegistryFile, error) {
+	var result []*MessageRegistryFile
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(ctx, c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, messageregistryfileLink := range links.ItemLinks {
+		messageregistryfile, err := GetMessageRegistryFile(ctx, c, messageregistryfileLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, messageregistryfile)
+	}
+
+	return result, nil
+}
+
+// LocationURI returns the URI, colocated with the service, of the registry
+// file for the given language. If the language is not available the English
+// or first available location is used.
+func (messageregistryfile *MessageRegistryFile) LocationURI(language string) string {
+	var fallback string
+	for _, location := range messageregistryfile.Location {
+		if location.URI == "" {
+			continue
+		}
+		if location.Language == language {
+			return location.URI
+		}
+		if fallback == "" || location.Language == "en" {
+			fallback = location.URI
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,82 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var baseRegistryFileBody = `{
+		"@odata.id": "/redfish/v1/Registries/Base.1.0.0",
+		"@odata.type": "#MessageRegistryFile.v1_1_0.MessageRegistryFile",
+		"Id": "Base.1.0.0",
+		"Name": "Base Message Registry File",
+		"Registry": "Base.1.0",
+		"Languages": ["en"],
+		"Location": [
+			{
+				"Language": "en",
+				"PublicationUri": "https://redfish.dmtf.org/registries/Base.1.0.0.json"
+			}
+		]
+	}`
+
+var messageRegistryFileBody = `{
+		"@odata.context": "/redfish/v1/$metadata#MessageRegistryFile.MessageRegistryFile",
+		"@odata.id": "/redfish/v1/Registries/BiosAttributeRegistryP89.v1_0_0",
+		"@odata.type": "#MessageRegistryFile.v1_1_0.MessageRegistryFile",
+		"Id": "BiosAttributeRegistryP89.v1_0_0",
+		"Name": "Bios Attribute Registry File",
+		"Description": "Bios Attribute Registry File locations",
+		"Languages": ["de", "en"],
+		"Registry": "BiosAttributeRegistryP89.v1_0",
+		"Location": [
+			{
+				"Language": "de",
+				"Uri": "/redfish/v1/Registries/BiosAttributeRegistryP89.v1_0_0/BiosAttributeRegistryP89.de.json"
+			},
+			{
+				"Language": "en",
+				"Uri": "/redfish/v1/Registries/BiosAttributeRegistryP89.v1_0_0/BiosAttributeRegistryP89.json",
+				"PublicationUri": "http://www.contoso.com/Registries/BiosAttributeRegistryP89.v1_0_0.json"
+			}
+		]
+	}`
+
+// TestMessageRegistryFile tests the parsing of MessageRegistryFile objects.
+func TestMessageRegistryFile(t *testing.T) {
+	var result MessageRegistryFile
+	err := json.NewDecoder(strings.NewReader(messageRegistryFileBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "BiosAttributeRegistryP89.v1_0_0" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.Registry != "BiosAttributeRegistryP89.v1_0" {
+		t.Errorf("Received invalid Registry: %s", result.Registry)
+	}
+
+	if len(result.Location) != 2 {
+		t.Errorf("Invalid number of locations: %d", len(result.Location))
+	}
+
+	if result.Location[1].PublicationURI != "http://www.contoso.com/Registries/BiosAttributeRegistryP89.v1_0_0.json" {
+		t.Errorf("Invalid publication URI: %s", result.Location[1].PublicationURI)
+	}
+
+	if result.LocationURI("de") != "/redfish/v1/Registries/BiosAttributeRegistryP89.v1_0_0/BiosAttributeRegistryP89.de.json" {
+		t.Errorf("Invalid German location: %s", result.LocationURI("de"))
+	}
+
+	if result.LocationURI("fr") != "/redfish/v1/Registries/BiosAttributeRegistryP89.v1_0_0/BiosAttributeRegistryP89.json" {
+		t.Errorf("Invalid fallback location: %s", result.LocationURI("fr"))
+	}
+}
@@ -258,6 +258,10 @@ func GetNetworkAdapter(ctx context.Context, c common.Client, uri string) (*Netwo
 // ListReferencedNetworkAdapter gets the collection of Chassis from a provided reference.
 func ListReferencedNetworkAdapter(ctx context.Context, c common.Client, link string) ([]*NetworkAdapter, error) {
 	var result []*NetworkAdapter
+	if link == "" {
+		return result, nil
+	}
+
 	links, err := common.GetCollection(ctx, c, link)
 	if err != nil {
 		return result, err
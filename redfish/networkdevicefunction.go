@@ -289,6 +289,8 @@ type NetworkDeviceFunction struct {
 	// device function is currently assigned to. This value shall be one of the
 	// AssignablePhysicalPorts array members.
 	physicalPortAssignment string
+	// settings is the @Redfish.Settings annotation of this resource.
+	settings common.Settings
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -304,6 +306,7 @@ func (networkdevicefunction *NetworkDeviceFunction) UnmarshalJSON(b []byte) erro
 	}
 	var t struct {
 		temp
+		Settings                common.Settings `json:"@Redfish.Settings"`
 		Links                   links
 		AssignablePhysicalPorts common.Links
 	}
@@ -314,6 +317,7 @@ func (networkdevicefunction *NetworkDeviceFunction) UnmarshalJSON(b []byte) erro
 	}
 
 	*networkdevicefunction = NetworkDeviceFunction(t.temp)
+	networkdevicefunction.settings = t.Settings
 
 	// Extract the links to other entities for later
 	networkdevicefunction.endpoints = t.Links.Endpoints.ToStrings()
@@ -481,3 +485,9 @@ type ISCSIBoot struct {
 	// netmask should be obtained from DHCP.
 	TargetInfoViaDHCP bool
 }
+
+// PendingSettings gives access to the settings that will be applied to this
+// network device function, following the @Redfish.Settings pattern.
+func (networkdevicefunction *NetworkDeviceFunction) PendingSettings() *common.PendingSettings {
+	return common.NewPendingSettings(networkdevicefunction.Client, networkdevicefunction.ODataID, networkdevicefunction.settings, networkdevicefunction.rawData)
+}
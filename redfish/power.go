@@ -7,6 +7,7 @@ package redfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 
@@ -146,6 +147,152 @@ type Power struct {
 	VoltagesCount int `json:"Voltages@odata.count"`
 }
 
+// UnmarshalJSON unmarshals a Power object from the raw JSON.
+func (power *Power) UnmarshalJSON(b []byte) error {
+	type temp Power
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*power = Power(t.temp)
+
+	// Power control settings and voltage thresholds are changed through the
+	// power resource, so they need to know where they are.
+	for i := range power.PowerControl {
+		power.PowerControl[i].powerURI = power.ODataID
+		power.PowerControl[i].index = i
+	}
+	for i := range power.Voltages {
+		power.Voltages[i].powerURI = power.ODataID
+		power.Voltages[i].index = i
+	}
+
+	return nil
+}
+
+// SetClient sets the API client connection to use for accessing the power
+// resource and its power controls, power supplies and voltages.
+func (power *Power) SetClient(c common.Client) {
+	power.Entity.SetClient(c)
+	for i := range power.PowerControl {
+		power.PowerControl[i].SetClient(c)
+	}
+	for i := range power.PowerSupplies {
+		power.PowerSupplies[i].SetClient(c)
+	}
+	for i := range power.Voltages {
+		power.Voltages[i].SetClient(c)
+	}
+}
+
+// chassisPowerContexts are the physical contexts of the power controls
+// reporting the power of the whole chassis, rather than one of its parts,
+// the preferred ones first.
+var chassisPowerContexts = []common.PhysicalContext{
+	"",
+	common.ChassisPhysicalContext,
+	common.IntakePhysicalContext,
+	common.PowerSupplyPhysicalContext,
+	common.PowerSubsystemPhysicalContext,
+}
+
+// chassisPowerControl returns the power control covering the whole chassis,
+// the first one with the most preferred physical context, or nil if there is
+// none. Several of them report the same power from different places, so only
+// one is used.
+func (power *Power) chassisPowerControl() *PowerControl {
+	for _, physicalContext := range chassisPowerContexts {
+		for i := range power.PowerControl {
+			if power.PowerControl[i].PhysicalContext == physicalContext {
+				return &power.PowerControl[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ConsumedWatts returns the power consumed by the chassis. It is the reading
+// of the power control covering the whole chassis or, if there is none, the
+// sum of the input power of the power supplies. Power supplies that do not
+// report their input power are counted by their last output power instead.
+// Power controls covering only a part of the chassis, such as the processors,
+// are ignored as their consumption is already included.
+func (power *Power) ConsumedWatts() float32 {
+	if control := power.chassisPowerControl(); control != nil {
+		return control.PowerConsumedWatts
+	}
+
+	var consumed float32
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if supply.PowerInputWatts > 0 {
+			consumed += supply.PowerInputWatts
+		} else {
+			consumed += supply.LastPowerOutputWatts
+		}
+	}
+	return consumed
+}
+
+// CapacityWatts returns the power capacity of the chassis. It is the capacity
+// of the power control covering the whole chassis or, if there is none, the
+// sum of the capacity of the power supplies.
+func (power *Power) CapacityWatts() float32 {
+	if control := power.chassisPowerControl(); control != nil {
+		return control.PowerCapacityWatts
+	}
+
+	var capacity float32
+	for i := range power.PowerSupplies {
+		capacity += power.PowerSupplies[i].PowerCapacityWatts
+	}
+	return capacity
+}
+
+// PowerConsumption is the power consumption of a chassis.
+type PowerConsumption struct {
+	// Chassis is the URI of the chassis.
+	Chassis string
+	// ConsumedWatts is the power consumed by the chassis.
+	ConsumedWatts float32
+	// CapacityWatts is the power capacity of the chassis.
+	CapacityWatts float32
+}
+
+// ChassisPowerConsumption reads the power consumption of each chassis and
+// returns it along with the total consumed. Chassis that do not report power
+// information are skipped. Chassis contained in other chassis of the list
+// are counted twice, so only the outermost or innermost chassis should be
+// provided.
+func ChassisPowerConsumption(ctx context.Context, chassis []*Chassis) ([]PowerConsumption, float32, error) {
+	var result []PowerConsumption
+	var total float32
+	for _, c := range chassis {
+		power, err := c.Power(ctx)
+		if err != nil {
+			return result, total, err
+		}
+		if power == nil {
+			continue
+		}
+
+		consumption := PowerConsumption{
+			Chassis:       c.ODataID,
+			ConsumedWatts: power.ConsumedWatts(),
+			CapacityWatts: power.CapacityWatts(),
+		}
+		result = append(result, consumption)
+		total += consumption.ConsumedWatts
+	}
+
+	return result, total, nil
+}
+
 // GetPower will get a Power instance from the service.
 func GetPower(ctx context.Context, c common.Client, uri string) (*Power, error) {
 	resp, err := c.Get(ctx, uri)
@@ -225,6 +372,13 @@ type PowerControl struct {
 	// Status shall contain any status or health properties
 	// of the resource.
 	Status common.Status
+	// powerURI is the URI of the power resource holding the power control.
+	powerURI string
+	// index is the position of the power control in the power resource.
+	index int
+	// defined holds the properties the service did not send as null, so
+	// missing readings can be told apart from zero values.
+	defined map[string]bool
 }
 
 // UnmarshalJSON unmarshals a PowerControl object from the raw JSON.
@@ -256,10 +410,73 @@ func (powercontrol *PowerControl) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	*powercontrol = PowerControl(t.temp)
+	powercontrol.defined = nonNullProperties(b)
+
+	return nil
+}
+
+// SetPowerLimit caps the power consumed by the resource to watts.
+// exceptionAction is the action taken if the consumption cannot be kept below
+// the limit, and correctionMs the time allowed for the consumption to go
+// below the limit. They are optional and are left unchanged when empty or
+// zero.
+func (powercontrol *PowerControl) SetPowerLimit(ctx context.Context, watts float32, exceptionAction PowerLimitException, correctionMs int64) error {
+	if watts <= 0 {
+		return fmt.Errorf("the power limit must be positive")
+	}
+
+	limit := map[string]interface{}{
+		"LimitInWatts": watts,
+	}
+	if exceptionAction != "" {
+		limit["LimitException"] = exceptionAction
+	}
+	if correctionMs > 0 {
+		limit["CorrectionInMs"] = correctionMs
+	}
+
+	err := powercontrol.patchPowerLimit(ctx, limit)
+	if err != nil {
+		return err
+	}
+
+	powercontrol.PowerLimit.LimitInWatts = watts
+	if exceptionAction != "" {
+		powercontrol.PowerLimit.LimitException = exceptionAction
+	}
+	if correctionMs > 0 {
+		powercontrol.PowerLimit.CorrectionInMs = correctionMs
+	}
 
 	return nil
 }
 
+// DisablePowerLimit removes the power cap of the resource.
+func (powercontrol *PowerControl) DisablePowerLimit(ctx context.Context) error {
+	err := powercontrol.patchPowerLimit(ctx, map[string]interface{}{
+		"LimitInWatts": nil,
+	})
+	if err != nil {
+		return err
+	}
+
+	powercontrol.PowerLimit.LimitInWatts = 0
+	return nil
+}
+
+// patchPowerLimit sends the power limit settings to the power resource.
+func (powercontrol *PowerControl) patchPowerLimit(ctx context.Context, limit map[string]interface{}) error {
+	if powercontrol.powerURI == "" {
+		return fmt.Errorf("the power control is not part of a power resource")
+	}
+
+	_, err := common.PatchArrayMember(ctx, powercontrol.Client, powercontrol.powerURI, "PowerControl", powercontrol.index,
+		map[string]interface{}{
+			"PowerLimit": limit,
+		})
+	return err
+}
+
 // PowerLimit shall contain power limit status and
 // configuration information for this chassis.
 type PowerLimit struct {
@@ -385,6 +602,11 @@ type PowerSupply struct {
 	// Status shall contain any status or health properties
 	// of the resource.
 	Status common.Status
+	// SupportedResetTypes, if provided, is the reset types this power supply
+	// supports.
+	SupportedResetTypes []ResetType
+	// resetTarget is the URL to send Reset actions to.
+	resetTarget string
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -392,9 +614,16 @@ type PowerSupply struct {
 // UnmarshalJSON unmarshals a PowerSupply object from the raw JSON.
 func (powersupply *PowerSupply) UnmarshalJSON(b []byte) error {
 	type temp PowerSupply
+	type Actions struct {
+		Reset struct {
+			AllowedResetTypes []ResetType `json:"ResetType@Redfish.AllowableValues"`
+			Target            string
+		} `json:"#PowerSupply.Reset"`
+	}
 	var t struct {
 		temp
 		Assembly common.Link
+		Actions  Actions
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -405,6 +634,8 @@ func (powersupply *PowerSupply) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	*powersupply = PowerSupply(t.temp)
 	powersupply.assembly = string(t.Assembly)
+	powersupply.SupportedResetTypes = t.Actions.Reset.AllowedResetTypes
+	powersupply.resetTarget = t.Actions.Reset.Target
 
 	// This is a read/write object, so we need to save the raw object data for later
 	powersupply.rawData = b
@@ -430,6 +661,27 @@ func (powersupply *PowerSupply) Update(ctx context.Context) error {
 	return powersupply.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
 }
 
+// Reset resets the power supply. Not all power supplies support this action.
+func (powersupply *PowerSupply) Reset(ctx context.Context, resetType ResetType) error {
+	if powersupply.resetTarget == "" {
+		return fmt.Errorf("Reset is not supported by this power supply")
+	}
+
+	if err := validateResetType(resetType, powersupply.SupportedResetTypes); err != nil {
+		return err
+	}
+
+	type temp struct {
+		ResetType ResetType
+	}
+	t := temp{
+		ResetType: resetType,
+	}
+
+	_, err := powersupply.Client.Post(ctx, powersupply.resetTarget, t)
+	return err
+}
+
 // Voltage is a voltage representation.
 type Voltage struct {
 	common.Entity
@@ -483,6 +735,13 @@ type Voltage struct {
 	// the present reading is above the normal range but is not critical.
 	// Units shall use the same units as the related ReadingVolts property.
 	UpperThresholdNonCritical float32
+	// defined holds the properties the service did not send as null, so
+	// missing readings and thresholds can be told apart from zero values.
+	defined map[string]bool
+	// powerURI is the URI of the power resource holding the voltage.
+	powerURI string
+	// index is the position of the voltage in the power resource.
+	index int
 }
 
 // UnmarshalJSON unmarshals a Voltage object from the raw JSON.
@@ -514,6 +773,7 @@ func (voltage *Voltage) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	*voltage = Voltage(t.temp)
+	voltage.defined = nonNullProperties(b)
 
 	return nil
 }
@@ -5,7 +5,11 @@
 package redfish
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -559,3 +563,207 @@ func TestNonconformingPower(t *testing.T) {
 		t.Errorf("Expected first Voltage MemberID to be '218': %s", voltage.MemberID)
 	}
 }
+
+var powerCappingBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"Name": "Power",
+		"PowerControl": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerControl/0",
+				"MemberId": "0",
+				"Name": "System Power Control",
+				"PhysicalContext": "Intake",
+				"PowerCapacityWatts": 800,
+				"PowerConsumedWatts": 344
+			},
+			{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerControl/1",
+				"MemberId": "1",
+				"Name": "CPU Power Control",
+				"PhysicalContext": "CPU",
+				"PowerCapacityWatts": 400,
+				"PowerConsumedWatts": 120
+			}
+		],
+		"PowerSupplies": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0",
+				"MemberId": "0",
+				"PowerCapacityWatts": 1200,
+				"PowerInputWatts": 360,
+				"Actions": {
+					"#PowerSupply.Reset": {
+						"target": "/redfish/v1/Chassis/1/Power/PowerSupplies/0/Actions/PowerSupply.Reset",
+						"ResetType@Redfish.AllowableValues": ["ForceRestart"]
+					}
+				}
+			},
+			{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/1",
+				"MemberId": "1",
+				"PowerCapacityWatts": 1200,
+				"LastPowerOutputWatts": 20
+			}
+		]
+	}`
+
+// TestPowerControlSetPowerLimit tests setting and removing power caps.
+func TestPowerControlSetPowerLimit(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(powerCappingBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	powerControl := &result.PowerControl[1]
+	err = powerControl.SetPowerLimit(context.Background(), 250, LogEventOnlyPowerLimitException, 5000)
+	if err != nil {
+		t.Errorf("Error making SetPowerLimit call: %s", err)
+	}
+
+	if powerControl.PowerLimit.LimitInWatts != 250 {
+		t.Errorf("Power limit not updated: %f", powerControl.PowerLimit.LimitInWatts)
+	}
+
+	err = powerControl.SetPowerLimit(context.Background(), 0, "", 0)
+	if err == nil {
+		t.Error("A zero power limit should be rejected")
+	}
+
+	err = result.PowerControl[0].DisablePowerLimit(context.Background())
+	if err != nil {
+		t.Errorf("Error making DisablePowerLimit call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got %d", len(calls))
+	}
+
+	if calls[0].URL != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Unexpected power limit URL: %s", calls[0].URL)
+	}
+
+	if calls[0].Payload != "map[PowerControl:[map[] map[PowerLimit:map[CorrectionInMs:5000 LimitException:LogEventOnly LimitInWatts:250]]]]" {
+		t.Errorf("Unexpected power limit payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Payload != "map[PowerControl:[map[PowerLimit:map[LimitInWatts:<nil>]]]]" {
+		t.Errorf("Unexpected disable power limit payload: %s", calls[1].Payload)
+	}
+}
+
+// TestPowerSupplyReset tests resetting power supplies.
+func TestPowerSupplyReset(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(powerCappingBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.PowerSupplies[0].Reset(context.Background(), GracefulRestartResetType)
+	if err == nil {
+		t.Error("Unsupported reset type should be rejected")
+	}
+
+	err = result.PowerSupplies[0].Reset(context.Background(), ForceRestartResetType)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	err = result.PowerSupplies[1].Reset(context.Background(), ForceRestartResetType)
+	if err == nil {
+		t.Error("Reset should fail on a power supply without the action")
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Chassis/1/Power/PowerSupplies/0/Actions/PowerSupply.Reset" {
+		t.Errorf("Unexpected reset calls: %v", calls)
+	}
+}
+
+// TestPowerConsumption tests computing the chassis power consumption.
+func TestPowerConsumption(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(powerCappingBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ConsumedWatts() != 344 {
+		t.Errorf("Unexpected consumed watts: %f", result.ConsumedWatts())
+	}
+
+	if result.CapacityWatts() != 800 {
+		t.Errorf("Unexpected capacity watts: %f", result.CapacityWatts())
+	}
+
+	// A chassis power control is preferred over the ones of its intake.
+	chassisControl := result.PowerControl[0]
+	chassisControl.PhysicalContext = common.ChassisPhysicalContext
+	chassisControl.PowerConsumedWatts = 350
+	chassisControl.PowerCapacityWatts = 900
+	result.PowerControl = append(result.PowerControl, chassisControl)
+	if result.ConsumedWatts() != 350 || result.CapacityWatts() != 900 {
+		t.Errorf("Unexpected chassis power control: %f, %f", result.ConsumedWatts(), result.CapacityWatts())
+	}
+
+	// Without chassis level power controls the power supplies are used, by
+	// their input power or, for the second one, by its output power.
+	result.PowerControl = result.PowerControl[1:2]
+	if result.ConsumedWatts() != 380 {
+		t.Errorf("Unexpected power supply consumed watts: %f", result.ConsumedWatts())
+	}
+
+	if result.CapacityWatts() != 2400 {
+		t.Errorf("Unexpected power supply capacity watts: %f", result.CapacityWatts())
+	}
+
+	result.PowerSupplies[0].PowerInputWatts = 0
+	result.PowerSupplies[0].LastPowerOutputWatts = 330
+	if result.ConsumedWatts() != 350 {
+		t.Errorf("Unexpected power supply output watts: %f", result.ConsumedWatts())
+	}
+
+	result.PowerSupplies[1].PowerInputWatts = 25
+	if result.ConsumedWatts() != 355 {
+		t.Errorf("Unexpected power supply output watts: %f", result.ConsumedWatts())
+	}
+
+	chassis := []*Chassis{
+		{Entity: common.Entity{ODataID: "/redfish/v1/Chassis/1"}, power: "/redfish/v1/Chassis/1/Power"},
+		{Entity: common.Entity{ODataID: "/redfish/v1/Chassis/2"}},
+		{Entity: common.Entity{ODataID: "/redfish/v1/Chassis/3"}, power: "/redfish/v1/Chassis/3/Power"},
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(powerCappingBody))},
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(powerCappingBody))},
+			},
+		},
+	}
+	for _, c := range chassis {
+		c.SetClient(testClient)
+	}
+
+	consumption, total, err := ChassisPowerConsumption(context.Background(), chassis)
+	if err != nil {
+		t.Errorf("Error making ChassisPowerConsumption call: %s", err)
+	}
+
+	if len(consumption) != 2 || consumption[1].Chassis != "/redfish/v1/Chassis/3" {
+		t.Errorf("Unexpected consumption: %+v", consumption)
+	}
+
+	if total != 688 {
+		t.Errorf("Unexpected total consumption: %f", total)
+	}
+}
@@ -0,0 +1,292 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// PowerAllocation shall contain the set of properties describing the
+// allocation of power for a subsystem.
+type PowerAllocation struct {
+	// AllocatedWatts shall contain the total power currently allocated to
+	// the subsystem, in watts.
+	AllocatedWatts float32
+	// RequestedWatts shall contain the amount of power, in watts, that the
+	// subsystem currently requests to be allocated for future use.
+	RequestedWatts float32
+}
+
+// PowerSubsystem shall represent a power subsystem for a Redfish
+// implementation. It replaces the Power resource as of Redfish 2020.4.
+type PowerSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Allocation shall contain the set of properties describing the
+	// allocation of power for this subsystem.
+	Allocation PowerAllocation
+	// CapacityWatts shall represent the total power capacity that can be
+	// allocated to this subsystem.
+	CapacityWatts float32
+	// Description provides a description of this resource.
+	Description string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// powerSupplies is the link to the collection of power supplies.
+	powerSupplies string
+}
+
+// UnmarshalJSON unmarshals a PowerSubsystem object from the raw JSON.
+func (powersubsystem *PowerSubsystem) UnmarshalJSON(b []byte) error {
+	type temp PowerSubsystem
+	var t struct {
+		temp
+		PowerSupplies common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powersubsystem = PowerSubsystem(t.temp)
+
+	// Extract the links to other entities for later
+	powersubsystem.powerSupplies = string(t.PowerSupplies)
+
+	return nil
+}
+
+// PowerSupplies gets the power supplies of this subsystem.
+func (powersubsystem *PowerSubsystem) PowerSupplies(ctx context.Context) ([]*PowerSupplyUnit, error) {
+	return ListReferencedPowerSupplyUnits(ctx, powersubsystem.Client, powersubsystem.powerSupplies)
+}
+
+// GetPowerSubsystem will get a PowerSubsystem instance from the service.
+func GetPowerSubsystem(ctx context.Context, c common.Client, uri string) (*PowerSubsystem, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var powersubsystem PowerSubsystem
+	err = json.NewDecoder(resp.Body).Decode(&powersubsystem)
+	if err != nil {
+		return nil, err
+	}
+
+	powersubsystem.SetClient(c)
+	return &powersubsystem, nil
+}
+
+// PowerSupplyEfficiencyRating shall describe an efficiency rating for a
+// power supply.
+type PowerSupplyEfficiencyRating struct {
+	// EfficiencyPercent shall contain the rated efficiency, as a percentage,
+	// of this power supply at the specified load.
+	EfficiencyPercent float32
+	// LoadPercent shall contain the load, as a percentage, of this power
+	// supply at which this efficiency rating is valid.
+	LoadPercent float32
+}
+
+// PowerSupplyUnit shall represent a power supply unit of a PowerSubsystem.
+// It is named to not clash with the PowerSupply of the Power resource.
+type PowerSupplyUnit struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// EfficiencyRatings shall contain an array of efficiency ratings for this
+	// power supply.
+	EfficiencyRatings []PowerSupplyEfficiencyRating
+	// FirmwareVersion shall contain the firmware version as defined by the
+	// manufacturer for this power supply.
+	FirmwareVersion string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its current
+	// operational state.
+	HotPluggable bool
+	// InputNominalVoltageType shall contain the nominal voltage type that is
+	// detected on the line input to this power supply.
+	InputNominalVoltageType string
+	// LineInputStatus shall contain the status of the power line input for
+	// this power supply.
+	LineInputStatus string
+	// Location shall contain the location information of the power supply.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the power supply.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this power supply.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this power supply.
+	PartNumber string
+	// PowerCapacityWatts shall contain the maximum amount of power, in watts,
+	// that this power supply is rated to deliver.
+	PowerCapacityWatts float32
+	// PowerSupplyType shall contain the input power type (AC or DC) of this
+	// power supply.
+	PowerSupplyType string
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this power supply.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this power supply.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// SupportedResetTypes, if provided, is the reset types this power supply
+	// supports.
+	SupportedResetTypes []ResetType
+	// metrics is the link to the metrics of the power supply.
+	metrics string
+	// resetTarget is the URL to send Reset actions to.
+	resetTarget string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals a PowerSupplyUnit object from the raw JSON.
+func (powersupply *PowerSupplyUnit) UnmarshalJSON(b []byte) error {
+	type temp PowerSupplyUnit
+	type Actions struct {
+		Reset struct {
+			AllowedResetTypes []ResetType `json:"ResetType@Redfish.AllowableValues"`
+			Target            string
+		} `json:"#PowerSupply.Reset"`
+	}
+	var t struct {
+		temp
+		Metrics common.Link
+		Actions Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powersupply = PowerSupplyUnit(t.temp)
+
+	// Extract the links to other entities for later
+	powersupply.metrics = string(t.Metrics)
+	powersupply.SupportedResetTypes = t.Actions.Reset.AllowedResetTypes
+	powersupply.resetTarget = t.Actions.Reset.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	powersupply.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (powersupply *PowerSupplyUnit) Update(ctx context.Context) error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(PowerSupplyUnit)
+	original.UnmarshalJSON(powersupply.rawData)
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(powersupply).Elem()
+
+	return powersupply.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+}
+
+// Reset resets the power supply. Not all power supplies support this action.
+func (powersupply *PowerSupplyUnit) Reset(ctx context.Context, resetType ResetType) error {
+	if powersupply.resetTarget == "" {
+		return fmt.Errorf("Reset is not supported by this power supply")
+	}
+
+	if err := validateResetType(resetType, powersupply.SupportedResetTypes); err != nil {
+		return err
+	}
+
+	type temp struct {
+		ResetType ResetType
+	}
+	t := temp{
+		ResetType: resetType,
+	}
+
+	_, err := powersupply.Client.Post(ctx, powersupply.resetTarget, t)
+	return err
+}
+
+// Metrics gets the metrics of the power supply.
+func (powersupply *PowerSupplyUnit) Metrics(ctx context.Context) (*PowerSupplyMetrics, error) {
+	if powersupply.metrics == "" {
+		return nil, nil
+	}
+
+	return GetPowerSupplyMetrics(ctx, powersupply.Client, powersupply.metrics)
+}
+
+// GetPowerSupplyUnit will get a PowerSupplyUnit instance from the service.
+func GetPowerSupplyUnit(ctx context.Context, c common.Client, uri string) (*PowerSupplyUnit, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var powersupply PowerSupplyUnit
+	err = json.NewDecoder(resp.Body).Decode(&powersupply)
+	if err != nil {
+		return nil, err
+	}
+
+	powersupply.SetClient(c)
+	return &powersupply, nil
+}
+
+// ListReferencedPowerSupplyUnits gets the collection of PowerSupplyUnit from
+// a provided reference.
+func ListReferencedPowerSupplyUnits(ctx context.Context, c common.Client, link string) ([]*PowerSupplyUnit, error) {
+	var result []*PowerSupplyUnit
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(ctx, c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, powersupplyLink := range links.ItemLinks {
+		powersupply, err := GetPowerSupplyUnit(ctx, c, powersupplyLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, powersupply)
+	}
+
+	return result, nil
+}
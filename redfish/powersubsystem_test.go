@@ -0,0 +1,165 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var powerSubsystemBody = `{
+		"@odata.type": "#PowerSubsystem.v1_1_0.PowerSubsystem",
+		"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem",
+		"Id": "PowerSubsystem",
+		"Name": "Power Subsystem for Chassis",
+		"CapacityWatts": 2000,
+		"Allocation": {
+			"AllocatedWatts": 1000,
+			"RequestedWatts": 1500
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"PowerSupplies": {
+			"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies"
+		}
+	}`
+
+var powerSupplyUnitBody = `{
+		"@odata.type": "#PowerSupply.v1_5_0.PowerSupply",
+		"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/Bay1",
+		"Id": "Bay1",
+		"Name": "Power Supply Bay 1",
+		"Status": {
+			"State": "Enabled",
+			"Health": "Warning"
+		},
+		"LineInputStatus": "Normal",
+		"Model": "RKS-440DC",
+		"Manufacturer": "Contoso Power",
+		"FirmwareVersion": "1.00",
+		"SerialNumber": "3488247",
+		"PartNumber": "23456-133",
+		"PowerCapacityWatts": 400,
+		"PowerSupplyType": "AC",
+		"HotPluggable": true,
+		"LocationIndicatorActive": false,
+		"EfficiencyRatings": [{
+			"LoadPercent": 50,
+			"EfficiencyPercent": 94
+		}],
+		"Actions": {
+			"#PowerSupply.Reset": {
+				"target": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/Bay1/Actions/PowerSupply.Reset",
+				"ResetType@Redfish.AllowableValues": ["ForceRestart"]
+			}
+		}
+	}`
+
+// TestPowerSubsystem tests the parsing of PowerSubsystem objects.
+func TestPowerSubsystem(t *testing.T) {
+	var result PowerSubsystem
+	err := json.NewDecoder(strings.NewReader(powerSubsystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.CapacityWatts != 2000 {
+		t.Errorf("Invalid capacity: %f", result.CapacityWatts)
+	}
+
+	if result.Allocation.RequestedWatts != 1500 {
+		t.Errorf("Invalid requested watts: %f", result.Allocation.RequestedWatts)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"Members": [{"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/Bay1"}],
+					"Members@odata.count": 1
+				}`))},
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(powerSupplyUnitBody))},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	supplies, err := result.PowerSupplies(context.Background())
+	if err != nil {
+		t.Errorf("Error getting power supplies: %s", err)
+	}
+
+	if len(supplies) != 1 || supplies[0].PowerCapacityWatts != 400 {
+		t.Errorf("Unexpected power supplies: %v", supplies)
+	}
+}
+
+// TestPowerSupplyUnit tests the parsing of PowerSupplyUnit objects.
+func TestPowerSupplyUnit(t *testing.T) {
+	var result PowerSupplyUnit
+	err := json.NewDecoder(strings.NewReader(powerSupplyUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Bay1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if len(result.EfficiencyRatings) != 1 || result.EfficiencyRatings[0].EfficiencyPercent != 94 {
+		t.Errorf("Invalid efficiency ratings: %v", result.EfficiencyRatings)
+	}
+
+	if result.resetTarget != "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/Bay1/Actions/PowerSupply.Reset" {
+		t.Errorf("Invalid reset target: %s", result.resetTarget)
+	}
+}
+
+// TestPowerSupplyUnitUpdate tests the Update call.
+func TestPowerSupplyUnitUpdate(t *testing.T) {
+	var result PowerSupplyUnit
+	err := json.NewDecoder(strings.NewReader(powerSupplyUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.LocationIndicatorActive = true
+	err = result.Update(context.Background())
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "LocationIndicatorActive:true") {
+		t.Errorf("Unexpected LocationIndicatorActive update payload: %s", calls[0].Payload)
+	}
+
+	err = result.Reset(context.Background(), GracefulRestartResetType)
+	if err == nil {
+		t.Error("Unsupported reset type should be rejected")
+	}
+
+	err = result.Reset(context.Background(), ForceRestartResetType)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+}
@@ -0,0 +1,58 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// PowerSupplyMetrics shall represent the metrics of a power supply of a
+// PowerSubsystem.
+type PowerSupplyMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// InputCurrentAmps shall contain the input current, in ampere units, of
+	// the power supply.
+	InputCurrentAmps SensorExcerpt
+	// InputPowerWatts shall contain the input power, in watt units, of the
+	// power supply.
+	InputPowerWatts SensorPowerExcerpt
+	// InputVoltage shall contain the input voltage, in volt units, of the
+	// power supply.
+	InputVoltage SensorExcerpt
+	// OutputPowerWatts shall contain the total output power, in watt units,
+	// of the power supply.
+	OutputPowerWatts SensorPowerExcerpt
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+}
+
+// GetPowerSupplyMetrics will get a PowerSupplyMetrics instance from the
+// service.
+func GetPowerSupplyMetrics(ctx context.Context, c common.Client, uri string) (*PowerSupplyMetrics, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var powersupplymetrics PowerSupplyMetrics
+	err = json.NewDecoder(resp.Body).Decode(&powersupplymetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	powersupplymetrics.SetClient(c)
+	return &powersupplymetrics, nil
+}
@@ -456,6 +456,10 @@ func GetProcessor(ctx context.Context, c common.Client, uri string) (*Processor,
 // ListReferencedProcessors gets the collection of Processor from a provided reference.
 func ListReferencedProcessors(ctx context.Context, c common.Client, link string) ([]*Processor, error) {
 	var result []*Processor
+	if link == "" {
+		return result, nil
+	}
+
 	links, err := common.GetCollection(ctx, c, link)
 	if err != nil {
 		return result, err
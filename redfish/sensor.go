@@ -0,0 +1,310 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// ReadingType is the type of a sensor reading.
+type ReadingType string
+
+const (
+	// TemperatureReadingType shall indicate a temperature measurement and
+	// the ReadingUnits value shall be `Cel`.
+	TemperatureReadingType ReadingType = "Temperature"
+	// HumidityReadingType shall indicate a relative humidity measurement and
+	// the ReadingUnits value shall be `%`.
+	HumidityReadingType ReadingType = "Humidity"
+	// PowerReadingType shall indicate the arithmetic mean of product terms
+	// of instantaneous voltage and current values measured over integer
+	// number of line cycles for a circuit, in watts.
+	PowerReadingType ReadingType = "Power"
+	// EnergykWhReadingType shall indicate the energy, integral of real power
+	// over time, of the monitored item, in kilowatt-hours.
+	EnergykWhReadingType ReadingType = "EnergykWh"
+	// VoltageReadingType shall indicate a measurement of the root mean
+	// square (RMS) of instantaneous voltage, in volts.
+	VoltageReadingType ReadingType = "Voltage"
+	// CurrentReadingType shall indicate a measurement of the root mean
+	// square (RMS) of instantaneous current, in amperes.
+	CurrentReadingType ReadingType = "Current"
+	// FrequencyReadingType shall indicate a frequency measurement, in hertz.
+	FrequencyReadingType ReadingType = "Frequency"
+	// PressureReadingType shall indicate a measurement of force applied
+	// perpendicular to the surface of an object, in pascals.
+	PressureReadingType ReadingType = "Pressure"
+	// RotationalReadingType shall indicate a measurement of rotational
+	// frequency, in revolutions per minute.
+	RotationalReadingType ReadingType = "Rotational"
+	// AirFlowReadingType shall indicate a measurement of a volume of gas per
+	// unit of time, in cubic feet per minute.
+	AirFlowReadingType ReadingType = "AirFlow"
+	// LiquidFlowReadingType shall indicate a measurement of a volume of
+	// liquid per unit of time, in liters per second.
+	LiquidFlowReadingType ReadingType = "LiquidFlow"
+	// PercentReadingType shall indicate a percentage measurement, such as a
+	// fan speed or a utilization.
+	PercentReadingType ReadingType = "Percent"
+)
+
+// ThresholdActivation is the direction of crossing that activates a
+// threshold.
+type ThresholdActivation string
+
+const (
+	// IncreasingThresholdActivation shall indicate the threshold is activated
+	// when the reading changes from a value lower than the threshold to a
+	// value higher than the threshold.
+	IncreasingThresholdActivation ThresholdActivation = "Increasing"
+	// DecreasingThresholdActivation shall indicate the threshold is activated
+	// when the reading changes from a value higher than the threshold to a
+	// value lower than the threshold.
+	DecreasingThresholdActivation ThresholdActivation = "Decreasing"
+	// EitherThresholdActivation shall indicate the threshold is activated
+	// when either the Increasing or Decreasing conditions are met.
+	EitherThresholdActivation ThresholdActivation = "Either"
+)
+
+// Threshold shall contain the properties for an individual threshold for
+// this sensor.
+type Threshold struct {
+	// Activation shall indicate the direction of crossing of the reading for
+	// this sensor that activates the threshold.
+	Activation ThresholdActivation `json:",omitempty"`
+	// DwellTime shall indicate the duration the sensor value must violate
+	// the threshold before the threshold is activated.
+	DwellTime string `json:",omitempty"`
+	// Reading shall indicate the reading for this sensor that activates the
+	// threshold. It is nil if the threshold is not defined.
+	Reading *float32
+}
+
+// Thresholds shall contain the set of thresholds that derive a sensor's
+// health and operational range.
+type Thresholds struct {
+	// LowerCaution shall contain the value at which the reading is below
+	// normal range.
+	LowerCaution Threshold
+	// LowerCritical shall contain the value at which the reading is below
+	// normal range but not yet fatal.
+	LowerCritical Threshold
+	// LowerFatal shall contain the value at which the reading is below
+	// normal range and fatal.
+	LowerFatal Threshold
+	// UpperCaution shall contain the value at which the reading is above
+	// normal range.
+	UpperCaution Threshold
+	// UpperCritical shall contain the value at which the reading is above
+	// normal range but not yet fatal.
+	UpperCritical Threshold
+	// UpperFatal shall contain the value at which the reading is above
+	// normal range and fatal.
+	UpperFatal Threshold
+}
+
+// SensorExcerpt shall contain the reading of a sensor, as included in
+// another resource.
+type SensorExcerpt struct {
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// data for this sensor.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value. It is nil if the reading is
+	// not available.
+	Reading *float32
+}
+
+// SensorArrayExcerpt shall contain the reading of one of several sensors,
+// as included in another resource.
+type SensorArrayExcerpt struct {
+	SensorExcerpt
+	// DeviceName shall contain the name of the device associated with this
+	// sensor.
+	DeviceName string
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalContext common.PhysicalContext
+}
+
+// SensorPowerExcerpt shall contain the reading of a power sensor, as
+// included in another resource.
+type SensorPowerExcerpt struct {
+	SensorExcerpt
+	// ApparentVA shall contain the product of voltage (RMS) multiplied by
+	// current (RMS) for a circuit.
+	ApparentVA *float32
+	// PowerFactor shall identify the quotient of real power (W) and apparent
+	// power (VA) for a circuit.
+	PowerFactor *float32
+	// ReactiveVAR shall contain the arithmetic mean of product terms of
+	// instantaneous voltage and quadrature current measurements.
+	ReactiveVAR *float32
+}
+
+// SensorEnergykWhExcerpt shall contain the reading of an energy sensor, as
+// included in another resource.
+type SensorEnergykWhExcerpt struct {
+	SensorExcerpt
+	// LifetimeReading shall contain the total accumulation of the Reading
+	// property over the sensor's lifetime.
+	LifetimeReading *float32
+}
+
+// SensorFanExcerpt shall contain the reading of a fan sensor, as included in
+// another resource.
+type SensorFanExcerpt struct {
+	SensorExcerpt
+	// SpeedRPM shall contain a reading of the rotational speed of the device
+	// in revolutions per minute (RPM) units.
+	SpeedRPM *float32
+}
+
+// SensorFanArrayExcerpt shall contain the reading of one of several fan
+// sensors, as included in another resource.
+type SensorFanArrayExcerpt struct {
+	SensorFanExcerpt
+	// DeviceName shall contain the name of the device associated with this
+	// sensor.
+	DeviceName string
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalContext common.PhysicalContext
+}
+
+// Sensor shall represent a sensor for a Redfish implementation.
+type Sensor struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Accuracy shall contain the percent error +/- of the measured versus
+	// actual values of the Reading property.
+	Accuracy float32
+	// Description provides a description of this resource.
+	Description string
+	// Implementation shall contain the implementation of the sensor.
+	Implementation string
+	// Location shall indicate the location information for this sensor.
+	Location common.PartLocation
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalContext common.PhysicalContext
+	// PhysicalSubContext shall contain a description of the usage or
+	// sub-region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalSubContext string
+	// Precision shall contain the number of significant digits in the
+	// Reading property.
+	Precision float32
+	// Reading shall contain the sensor value. It is nil if the reading is
+	// not available.
+	Reading *float32
+	// ReadingRangeMax shall indicate the maximum possible value of the
+	// Reading property for this sensor.
+	ReadingRangeMax *float32
+	// ReadingRangeMin shall indicate the minimum possible value of the
+	// Reading property for this sensor.
+	ReadingRangeMin *float32
+	// ReadingType shall contain the type of the sensor.
+	ReadingType ReadingType
+	// ReadingUnits shall contain the units of the sensor's reading and
+	// thresholds.
+	ReadingUnits string
+	// SensingInterval shall contain the time interval between readings of
+	// data from the sensor.
+	SensingInterval string
+	// SpeedRPM shall contain a reading of the rotational speed of the device
+	// in revolutions per minute (RPM) units.
+	SpeedRPM *float32
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Thresholds shall contain the set of thresholds that derive a sensor's
+	// health and operational range.
+	Thresholds Thresholds
+	// relatedItem shall contain an array of links to resources or objects
+	// that this sensor services.
+	relatedItem []string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals a Sensor object from the raw JSON.
+func (sensor *Sensor) UnmarshalJSON(b []byte) error {
+	type temp Sensor
+	var t struct {
+		temp
+		RelatedItem common.Links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*sensor = Sensor(t.temp)
+
+	// Extract the links to other entities for later
+	sensor.relatedItem = t.RelatedItem.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	sensor.rawData = b
+
+	return nil
+}
+
+// RelatedItem gets the URIs of the resources this sensor services.
+func (sensor *Sensor) RelatedItem() []string {
+	return sensor.relatedItem
+}
+
+// GetSensor will get a Sensor instance from the service.
+func GetSensor(ctx context.Context, c common.Client, uri string) (*Sensor, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var sensor Sensor
+	err = json.NewDecoder(resp.Body).Decode(&sensor)
+	if err != nil {
+		return nil, err
+	}
+
+	sensor.SetClient(c)
+	return &sensor, nil
+}
+
+// ListReferencedSensors gets the collection of Sensor from a provided
+// reference.
+func ListReferencedSensors(ctx context.Context, c common.Client, link string) ([]*Sensor, error) {
+	var result []*Sensor
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(ctx, c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, sensorLink := range links.ItemLinks {
+		sensor, err := GetSensor(ctx, c, sensorLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, sensor)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,92 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var sensorBody = `{
+		"@odata.type": "#Sensor.v1_2_0.Sensor",
+		"@odata.id": "/redfish/v1/Chassis/1/Sensors/CPU1Temp",
+		"Id": "CPU1Temp",
+		"Name": "CPU #1 Temperature",
+		"ReadingType": "Temperature",
+		"Reading": 62.5,
+		"ReadingUnits": "Cel",
+		"ReadingRangeMin": 0,
+		"ReadingRangeMax": 120,
+		"PhysicalContext": "CPU",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Thresholds": {
+			"UpperCaution": {
+				"Reading": 80
+			},
+			"UpperCritical": {
+				"Reading": 90,
+				"Activation": "Increasing",
+				"DwellTime": "PT5S"
+			},
+			"UpperFatal": {
+				"Reading": null
+			}
+		},
+		"RelatedItem": [{
+			"@odata.id": "/redfish/v1/Systems/1/Processors/1"
+		}]
+	}`
+
+// TestSensor tests the parsing of Sensor objects.
+func TestSensor(t *testing.T) {
+	var result Sensor
+	err := json.NewDecoder(strings.NewReader(sensorBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "CPU1Temp" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ReadingType != TemperatureReadingType {
+		t.Errorf("Invalid reading type: %s", result.ReadingType)
+	}
+
+	if result.Reading == nil || *result.Reading != 62.5 {
+		t.Errorf("Invalid reading: %v", result.Reading)
+	}
+
+	if result.ReadingRangeMin == nil || *result.ReadingRangeMin != 0 {
+		t.Errorf("Invalid reading range min: %v", result.ReadingRangeMin)
+	}
+
+	if result.PhysicalContext != common.CPUPhysicalContext {
+		t.Errorf("Invalid physical context: %s", result.PhysicalContext)
+	}
+
+	if result.Thresholds.UpperCritical.Reading == nil || *result.Thresholds.UpperCritical.Reading != 90 {
+		t.Errorf("Invalid upper critical threshold: %v", result.Thresholds.UpperCritical.Reading)
+	}
+
+	if result.Thresholds.UpperCritical.Activation != IncreasingThresholdActivation {
+		t.Errorf("Invalid upper critical activation: %s", result.Thresholds.UpperCritical.Activation)
+	}
+
+	if result.Thresholds.UpperFatal.Reading != nil || result.Thresholds.LowerCaution.Reading != nil {
+		t.Error("Missing thresholds should be nil")
+	}
+
+	if len(result.RelatedItem()) != 1 || result.RelatedItem()[0] != "/redfish/v1/Systems/1/Processors/1" {
+		t.Errorf("Invalid related item: %v", result.RelatedItem())
+	}
+}
@@ -0,0 +1,388 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// SensorReading is a sensor reading normalized across the Sensor resource, the
+// ThermalSubsystem and PowerSubsystem resources and the temperatures, fans,
+// voltages and power controls of the deprecated Thermal and Power resources.
+// Values the service did not report are nil.
+type SensorReading struct {
+	// Name is the name of the sensor.
+	Name string
+	// URI is the URI of the Sensor, or of the resource or Thermal or Power
+	// member the reading comes from.
+	URI string
+	// ReadingType is the type of the reading.
+	ReadingType ReadingType
+	// Reading is the value of the sensor.
+	Reading *float32
+	// ReadingUnits are the units of the reading and thresholds, such as
+	// `Cel`, `V`, `W`, `RPM` or `%`.
+	ReadingUnits string
+	// PhysicalContext is the component or region the reading applies to.
+	PhysicalContext common.PhysicalContext
+	// Status is the status reported by the service for the sensor.
+	Status common.Status
+	// ReadingRangeMin is the lowest possible value of the reading.
+	ReadingRangeMin *float32
+	// ReadingRangeMax is the highest possible value of the reading.
+	ReadingRangeMax *float32
+	// Thresholds are the thresholds of the sensor. The NonCritical
+	// thresholds of the deprecated resources are reported as Caution.
+	Thresholds Thresholds
+}
+
+// SensorReading returns the normalized reading of the sensor.
+func (sensor *Sensor) SensorReading() SensorReading {
+	reading := SensorReading{
+		Name:            sensor.Name,
+		URI:             sensor.ODataID,
+		ReadingType:     sensor.ReadingType,
+		Reading:         sensor.Reading,
+		ReadingUnits:    sensor.ReadingUnits,
+		PhysicalContext: sensor.PhysicalContext,
+		Status:          sensor.Status,
+		ReadingRangeMin: sensor.ReadingRangeMin,
+		ReadingRangeMax: sensor.ReadingRangeMax,
+		Thresholds:      sensor.Thresholds,
+	}
+
+	// Fan sensors may only report their speed.
+	if reading.Reading == nil && sensor.SpeedRPM != nil {
+		reading.Reading = sensor.SpeedRPM
+		reading.ReadingType = RotationalReadingType
+		reading.ReadingUnits = "RPM"
+	}
+
+	return reading
+}
+
+// SensorReading returns the normalized reading of the temperature sensor.
+func (temperature *Temperature) SensorReading() SensorReading {
+	defined := temperature.defined
+	return SensorReading{
+		Name:            temperature.Name,
+		URI:             temperature.ODataID,
+		ReadingType:     TemperatureReadingType,
+		Reading:         definedValue(defined, "ReadingCelsius", temperature.ReadingCelsius),
+		ReadingUnits:    "Cel",
+		PhysicalContext: common.PhysicalContext(temperature.PhysicalContext),
+		Status:          temperature.Status,
+		ReadingRangeMin: definedValue(defined, "MinReadingRangeTemp", temperature.MinReadingRangeTemp),
+		ReadingRangeMax: definedValue(defined, "MaxReadingRangeTemp", temperature.MaxReadingRangeTemp),
+		Thresholds: Thresholds{
+			LowerCaution:  definedThreshold(defined, "LowerThresholdNonCritical", temperature.LowerThresholdNonCritical),
+			LowerCritical: definedThreshold(defined, "LowerThresholdCritical", temperature.LowerThresholdCritical),
+			LowerFatal:    definedThreshold(defined, "LowerThresholdFatal", temperature.LowerThresholdFatal),
+			UpperCaution:  definedThreshold(defined, "UpperThresholdNonCritical", temperature.UpperThresholdNonCritical),
+			UpperCritical: definedThreshold(defined, "UpperThresholdCritical", temperature.UpperThresholdCritical),
+			UpperFatal:    definedThreshold(defined, "UpperThresholdFatal", temperature.UpperThresholdFatal),
+		},
+	}
+}
+
+// SensorReading returns the normalized reading of the fan.
+func (fan *Fan) SensorReading() SensorReading {
+	defined := fan.defined
+	reading := SensorReading{
+		Name:            fan.Name,
+		URI:             fan.ODataID,
+		ReadingType:     RotationalReadingType,
+		Reading:         definedValue(defined, "Reading", fan.Reading),
+		ReadingUnits:    "RPM",
+		PhysicalContext: common.PhysicalContext(fan.PhysicalContext),
+		Status:          fan.Status,
+		ReadingRangeMin: definedValue(defined, "MinReadingRange", fan.MinReadingRange),
+		ReadingRangeMax: definedValue(defined, "MaxReadingRange", fan.MaxReadingRange),
+		Thresholds: Thresholds{
+			LowerCaution:  definedThreshold(defined, "LowerThresholdNonCritical", fan.LowerThresholdNonCritical),
+			LowerCritical: definedThreshold(defined, "LowerThresholdCritical", fan.LowerThresholdCritical),
+			LowerFatal:    definedThreshold(defined, "LowerThresholdFatal", fan.LowerThresholdFatal),
+			UpperCaution:  definedThreshold(defined, "UpperThresholdNonCritical", fan.UpperThresholdNonCritical),
+			UpperCritical: definedThreshold(defined, "UpperThresholdCritical", fan.UpperThresholdCritical),
+			UpperFatal:    definedThreshold(defined, "UpperThresholdFatal", fan.UpperThresholdFatal),
+		},
+	}
+
+	if fan.ReadingUnits == PercentReadingUnits {
+		reading.ReadingType = PercentReadingType
+		reading.ReadingUnits = "%"
+	}
+
+	return reading
+}
+
+// SensorReading returns the normalized reading of the voltage sensor.
+func (voltage *Voltage) SensorReading() SensorReading {
+	defined := voltage.defined
+	return SensorReading{
+		Name:            voltage.Name,
+		URI:             voltage.ODataID,
+		ReadingType:     VoltageReadingType,
+		Reading:         definedValue(defined, "ReadingVolts", voltage.ReadingVolts),
+		ReadingUnits:    "V",
+		PhysicalContext: common.PhysicalContext(voltage.PhysicalContext),
+		Status:          voltage.Status,
+		ReadingRangeMin: definedValue(defined, "MinReadingRange", voltage.MinReadingRange),
+		ReadingRangeMax: definedValue(defined, "MaxReadingRange", voltage.MaxReadingRange),
+		Thresholds: Thresholds{
+			LowerCaution:  definedThreshold(defined, "LowerThresholdNonCritical", voltage.LowerThresholdNonCritical),
+			LowerCritical: definedThreshold(defined, "LowerThresholdCritical", voltage.LowerThresholdCritical),
+			LowerFatal:    definedThreshold(defined, "LowerThresholdFatal", voltage.LowerThresholdFatal),
+			UpperCaution:  definedThreshold(defined, "UpperThresholdNonCritical", voltage.UpperThresholdNonCritical),
+			UpperCritical: definedThreshold(defined, "UpperThresholdCritical", voltage.UpperThresholdCritical),
+			UpperFatal:    definedThreshold(defined, "UpperThresholdFatal", voltage.UpperThresholdFatal),
+		},
+	}
+}
+
+// SensorReading returns the normalized power consumption of the power
+// control. The capacity of the power control is reported as the maximum of
+// the reading range.
+func (powercontrol *PowerControl) SensorReading() SensorReading {
+	defined := powercontrol.defined
+	return SensorReading{
+		Name:            powercontrol.Name,
+		URI:             powercontrol.ODataID,
+		ReadingType:     PowerReadingType,
+		Reading:         definedValue(defined, "PowerConsumedWatts", powercontrol.PowerConsumedWatts),
+		ReadingUnits:    "W",
+		PhysicalContext: powercontrol.PhysicalContext,
+		Status:          powercontrol.Status,
+		ReadingRangeMax: definedValue(defined, "PowerCapacityWatts", powercontrol.PowerCapacityWatts),
+	}
+}
+
+// SensorReadings returns the normalized readings of the temperature sensors
+// and fans.
+func (thermal *Thermal) SensorReadings() []SensorReading {
+	var result []SensorReading
+	for i := range thermal.Temperatures {
+		result = append(result, thermal.Temperatures[i].SensorReading())
+	}
+	for i := range thermal.Fans {
+		result = append(result, thermal.Fans[i].SensorReading())
+	}
+	return result
+}
+
+// SensorReadings returns the normalized readings of the voltage sensors and
+// power controls.
+func (power *Power) SensorReadings() []SensorReading {
+	var result []SensorReading
+	for i := range power.Voltages {
+		result = append(result, power.Voltages[i].SensorReading())
+	}
+	for i := range power.PowerControl {
+		result = append(result, power.PowerControl[i].SensorReading())
+	}
+	return result
+}
+
+// sensorReading returns the normalized reading of a sensor excerpt. uri is
+// used if the excerpt does not link to the sensor providing its data.
+func (excerpt *SensorExcerpt) sensorReading(name, uri string, readingType ReadingType, units string) SensorReading {
+	if excerpt.DataSourceURI != "" {
+		uri = excerpt.DataSourceURI
+	}
+	return SensorReading{
+		Name:         name,
+		URI:          uri,
+		ReadingType:  readingType,
+		Reading:      excerpt.Reading,
+		ReadingUnits: units,
+	}
+}
+
+// SensorReadings gets the normalized readings of the temperatures of the
+// thermal metrics and of the fans of the thermal subsystem.
+func (thermalsubsystem *ThermalSubsystem) SensorReadings(ctx context.Context) ([]SensorReading, error) {
+	var result []SensorReading
+
+	metrics, err := thermalsubsystem.ThermalMetrics(ctx)
+	if err != nil {
+		return result, err
+	}
+	if metrics != nil {
+		for i := range metrics.TemperatureReadingsCelsius {
+			temperature := &metrics.TemperatureReadingsCelsius[i]
+			name := temperature.DeviceName
+			if name == "" {
+				name = string(temperature.PhysicalContext)
+			}
+			reading := temperature.sensorReading(name,
+				metrics.ODataID+"#/TemperatureReadingsCelsius/"+strconv.Itoa(i), TemperatureReadingType, "Cel")
+			reading.PhysicalContext = temperature.PhysicalContext
+			result = append(result, reading)
+		}
+	}
+
+	fans, err := thermalsubsystem.Fans(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, fan := range fans {
+		reading := fan.SpeedPercent.sensorReading(fan.Name, fan.ODataID, PercentReadingType, "%")
+		// Fans may only report their speed.
+		if reading.Reading == nil && fan.SpeedPercent.SpeedRPM != nil {
+			reading.Reading = fan.SpeedPercent.SpeedRPM
+			reading.ReadingType = RotationalReadingType
+			reading.ReadingUnits = "RPM"
+		}
+		reading.PhysicalContext = fan.PhysicalContext
+		reading.Status = fan.Status
+		result = append(result, reading)
+	}
+
+	return result, nil
+}
+
+// SensorReadings gets the normalized readings of the input power, input
+// voltage and output power of the power supplies of the power subsystem.
+// Readings the power supplies do not report are left out.
+func (powersubsystem *PowerSubsystem) SensorReadings(ctx context.Context) ([]SensorReading, error) {
+	var result []SensorReading
+
+	supplies, err := powersubsystem.PowerSupplies(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, supply := range supplies {
+		metrics, err := supply.Metrics(ctx)
+		if err != nil {
+			return result, err
+		}
+		if metrics == nil {
+			continue
+		}
+
+		excerpts := []struct {
+			name        string
+			property    string
+			excerpt     *SensorExcerpt
+			readingType ReadingType
+			units       string
+		}{
+			{"Input Power", "InputPowerWatts", &metrics.InputPowerWatts.SensorExcerpt, PowerReadingType, "W"},
+			{"Input Voltage", "InputVoltage", &metrics.InputVoltage, VoltageReadingType, "V"},
+			{"Output Power", "OutputPowerWatts", &metrics.OutputPowerWatts.SensorExcerpt, PowerReadingType, "W"},
+		}
+		for _, item := range excerpts {
+			if item.excerpt.Reading == nil {
+				continue
+			}
+			reading := item.excerpt.sensorReading(supply.Name+" "+item.name,
+				metrics.ODataID+"#/"+item.property, item.readingType, item.units)
+			reading.PhysicalContext = common.PowerSupplyPhysicalContext
+			reading.Status = supply.Status
+			result = append(result, reading)
+		}
+	}
+
+	return result, nil
+}
+
+// SensorReadings gets the normalized readings of the sensors of the chassis.
+// The Sensors collection is used if the service provides it. Otherwise the
+// readings are taken from the ThermalSubsystem and PowerSubsystem resources,
+// or from the deprecated Thermal and Power resources they replace.
+func (chassis *Chassis) SensorReadings(ctx context.Context) ([]SensorReading, error) {
+	var result []SensorReading
+
+	if chassis.sensors != "" {
+		sensors, err := chassis.Sensors(ctx)
+		if err != nil {
+			return result, err
+		}
+		for _, sensor := range sensors {
+			result = append(result, sensor.SensorReading())
+		}
+		return result, nil
+	}
+
+	if chassis.thermalSubsystem != "" {
+		thermalsubsystem, err := chassis.ThermalSubsystem(ctx)
+		if err != nil {
+			return result, err
+		}
+		readings, err := thermalsubsystem.SensorReadings(ctx)
+		result = append(result, readings...)
+		if err != nil {
+			return result, err
+		}
+	} else {
+		thermal, err := chassis.Thermal(ctx)
+		if err != nil {
+			return result, err
+		}
+		if thermal != nil {
+			result = append(result, thermal.SensorReadings()...)
+		}
+	}
+
+	if chassis.powerSubsystem != "" {
+		powersubsystem, err := chassis.PowerSubsystem(ctx)
+		if err != nil {
+			return result, err
+		}
+		readings, err := powersubsystem.SensorReadings(ctx)
+		result = append(result, readings...)
+		if err != nil {
+			return result, err
+		}
+	} else {
+		power, err := chassis.Power(ctx)
+		if err != nil {
+			return result, err
+		}
+		if power != nil {
+			result = append(result, power.SensorReadings()...)
+		}
+	}
+
+	return result, nil
+}
+
+// nonNullProperties returns the top level properties of a JSON object that
+// are not null.
+func nonNullProperties(b []byte) map[string]bool {
+	var properties map[string]json.RawMessage
+	if json.Unmarshal(b, &properties) != nil {
+		return nil
+	}
+
+	result := make(map[string]bool, len(properties))
+	for name, value := range properties {
+		if !bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			result[name] = true
+		}
+	}
+	return result
+}
+
+// definedValue returns a pointer to value if the service reported the
+// property. Objects that were not decoded from JSON have all their
+// properties defined.
+func definedValue(defined map[string]bool, name string, value float32) *float32 {
+	if defined != nil && !defined[name] {
+		return nil
+	}
+	return &value
+}
+
+// definedThreshold returns the threshold for a property of the deprecated
+// resources.
+func definedThreshold(defined map[string]bool, name string, value float32) Threshold {
+	return Threshold{Reading: definedValue(defined, name, value)}
+}
@@ -0,0 +1,276 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var legacyThermalBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Thermal",
+		"Id": "Thermal",
+		"Name": "Thermal",
+		"Temperatures": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Thermal#/Temperatures/0",
+			"MemberId": "0",
+			"Name": "Inlet Temp",
+			"ReadingCelsius": 24,
+			"PhysicalContext": "Intake",
+			"LowerThresholdNonCritical": null,
+			"LowerThresholdCritical": null,
+			"UpperThresholdNonCritical": 42,
+			"UpperThresholdCritical": 47,
+			"UpperThresholdFatal": null,
+			"MinReadingRangeTemp": 0,
+			"MaxReadingRangeTemp": 100,
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}],
+		"Fans": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Thermal#/Fans/0",
+			"MemberId": "0",
+			"Name": "Fan 1",
+			"Reading": null,
+			"ReadingUnits": "Percent",
+			"LowerThresholdCritical": 10,
+			"Status": {"State": "Absent"}
+		}]
+	}`
+
+var legacyPowerBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"Name": "Power",
+		"PowerControl": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerControl/0",
+			"MemberId": "0",
+			"Name": "System Power Control",
+			"PowerConsumedWatts": 344,
+			"PowerCapacityWatts": 800
+		}],
+		"Voltages": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/Voltages/0",
+			"MemberId": "0",
+			"Name": "VRM1 Voltage",
+			"ReadingVolts": 12.1,
+			"LowerThresholdCritical": 11,
+			"UpperThresholdCritical": 13,
+			"PhysicalContext": "VoltageRegulator"
+		}]
+	}`
+
+// TestLegacySensorReadings tests normalizing the Thermal and Power readings.
+func TestLegacySensorReadings(t *testing.T) {
+	var thermal Thermal
+	err := json.NewDecoder(strings.NewReader(legacyThermalBody)).Decode(&thermal)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	readings := thermal.SensorReadings()
+	if len(readings) != 2 {
+		t.Fatalf("Unexpected readings: %v", readings)
+	}
+
+	temperature := readings[0]
+	if temperature.ReadingType != TemperatureReadingType || temperature.ReadingUnits != "Cel" {
+		t.Errorf("Invalid temperature type: %s %s", temperature.ReadingType, temperature.ReadingUnits)
+	}
+
+	if temperature.Reading == nil || *temperature.Reading != 24 {
+		t.Errorf("Invalid temperature reading: %v", temperature.Reading)
+	}
+
+	if temperature.Thresholds.UpperCaution.Reading == nil || *temperature.Thresholds.UpperCaution.Reading != 42 {
+		t.Errorf("Invalid upper caution threshold: %v", temperature.Thresholds.UpperCaution.Reading)
+	}
+
+	if temperature.Thresholds.LowerCaution.Reading != nil || temperature.Thresholds.UpperFatal.Reading != nil ||
+		temperature.Thresholds.LowerFatal.Reading != nil {
+		t.Error("Null or missing thresholds should be nil")
+	}
+
+	if temperature.ReadingRangeMin == nil || *temperature.ReadingRangeMin != 0 {
+		t.Errorf("Invalid reading range min: %v", temperature.ReadingRangeMin)
+	}
+
+	fan := readings[1]
+	if fan.ReadingType != PercentReadingType || fan.ReadingUnits != "%" {
+		t.Errorf("Invalid fan type: %s %s", fan.ReadingType, fan.ReadingUnits)
+	}
+
+	if fan.Reading != nil {
+		t.Errorf("Null fan reading should be nil: %v", *fan.Reading)
+	}
+
+	var power Power
+	err = json.NewDecoder(strings.NewReader(legacyPowerBody)).Decode(&power)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	readings = power.SensorReadings()
+	if len(readings) != 2 {
+		t.Fatalf("Unexpected readings: %v", readings)
+	}
+
+	if readings[0].ReadingType != VoltageReadingType || *readings[0].Reading != 12.1 ||
+		*readings[0].Thresholds.LowerCritical.Reading != 11 {
+		t.Errorf("Invalid voltage reading: %+v", readings[0])
+	}
+
+	if readings[1].ReadingType != PowerReadingType || *readings[1].Reading != 344 || *readings[1].ReadingRangeMax != 800 {
+		t.Errorf("Invalid power reading: %+v", readings[1])
+	}
+}
+
+// TestChassisSensorReadings tests getting the readings of a chassis from the
+// Sensors collection or, failing that, the Thermal and Power resources.
+func TestChassisSensorReadings(t *testing.T) {
+	chassis := &Chassis{sensors: "/redfish/v1/Chassis/1/Sensors"}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"Members": [{"@odata.id": "/redfish/v1/Chassis/1/Sensors/CPU1Temp"}],
+					"Members@odata.count": 1
+				}`))},
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(sensorBody))},
+			},
+		},
+	}
+	chassis.SetClient(testClient)
+
+	readings, err := chassis.SensorReadings(context.Background())
+	if err != nil {
+		t.Errorf("Error getting sensor readings: %s", err)
+	}
+
+	if len(readings) != 1 || readings[0].URI != "/redfish/v1/Chassis/1/Sensors/CPU1Temp" || *readings[0].Reading != 62.5 {
+		t.Errorf("Unexpected sensor readings: %+v", readings)
+	}
+
+	chassis = &Chassis{thermal: "/redfish/v1/Chassis/1/Thermal", power: "/redfish/v1/Chassis/1/Power"}
+	testClient = &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(legacyThermalBody))},
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(legacyPowerBody))},
+			},
+		},
+	}
+	chassis.SetClient(testClient)
+
+	readings, err = chassis.SensorReadings(context.Background())
+	if err != nil {
+		t.Errorf("Error getting sensor readings: %s", err)
+	}
+
+	if len(readings) != 4 || readings[3].ReadingType != PowerReadingType {
+		t.Errorf("Unexpected legacy sensor readings: %+v", readings)
+	}
+}
+
+// TestChassisSubsystemSensorReadings tests getting the readings of a chassis
+// from the ThermalSubsystem and PowerSubsystem resources, which are preferred
+// over the Thermal and Power resources.
+func TestChassisSubsystemSensorReadings(t *testing.T) {
+	chassis := &Chassis{
+		thermal:          "/redfish/v1/Chassis/1/Thermal",
+		power:            "/redfish/v1/Chassis/1/Power",
+		thermalSubsystem: "/redfish/v1/Chassis/1/ThermalSubsystem",
+		powerSubsystem:   "/redfish/v1/Chassis/1/PowerSubsystem",
+	}
+	response := func(body string) *http.Response {
+		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(body))}
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				response(`{
+					"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem",
+					"Fans": {"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans"},
+					"ThermalMetrics": {"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/ThermalMetrics"}
+				}`),
+				response(`{
+					"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/ThermalMetrics",
+					"TemperatureReadingsCelsius": [
+						{"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/CPU1Temp", "DeviceName": "CPU1", "PhysicalContext": "CPU", "Reading": 45},
+						{"PhysicalContext": "Intake", "Reading": 24}
+					]
+				}`),
+				response(`{
+					"Members": [{"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans/1"}],
+					"Members@odata.count": 1
+				}`),
+				response(`{
+					"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans/1",
+					"Name": "Fan 1",
+					"SpeedPercent": {"SpeedRPM": 5400}
+				}`),
+				response(`{
+					"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem",
+					"PowerSupplies": {"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies"}
+				}`),
+				response(`{
+					"Members": [{"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/0"}],
+					"Members@odata.count": 1
+				}`),
+				response(`{
+					"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/0",
+					"Name": "PSU 0",
+					"Metrics": {"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/0/Metrics"}
+				}`),
+				response(`{
+					"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/0/Metrics",
+					"InputPowerWatts": {"Reading": 180},
+					"InputVoltage": {"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/PSU0Voltage", "Reading": 230},
+					"OutputPowerWatts": {"Reading": null}
+				}`),
+			},
+		},
+	}
+	chassis.SetClient(testClient)
+
+	readings, err := chassis.SensorReadings(context.Background())
+	if err != nil {
+		t.Fatalf("Error getting sensor readings: %s", err)
+	}
+
+	expected := []struct {
+		name        string
+		uri         string
+		readingType ReadingType
+		reading     float32
+	}{
+		{"CPU1", "/redfish/v1/Chassis/1/Sensors/CPU1Temp", TemperatureReadingType, 45},
+		{"Intake", "/redfish/v1/Chassis/1/ThermalSubsystem/ThermalMetrics#/TemperatureReadingsCelsius/1", TemperatureReadingType, 24},
+		{"Fan 1", "/redfish/v1/Chassis/1/ThermalSubsystem/Fans/1", RotationalReadingType, 5400},
+		{"PSU 0 Input Power", "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/0/Metrics#/InputPowerWatts", PowerReadingType, 180},
+		{"PSU 0 Input Voltage", "/redfish/v1/Chassis/1/Sensors/PSU0Voltage", VoltageReadingType, 230},
+	}
+	if len(readings) != len(expected) {
+		t.Fatalf("Unexpected sensor readings: %+v", readings)
+	}
+	for i, reading := range readings {
+		if reading.Name != expected[i].name || reading.URI != expected[i].uri ||
+			reading.ReadingType != expected[i].readingType || reading.Reading == nil || *reading.Reading != expected[i].reading {
+			t.Errorf("Unexpected sensor reading %d: %+v", i, reading)
+		}
+	}
+
+	for _, call := range testClient.CapturedCalls() {
+		if call.URL == "/redfish/v1/Chassis/1/Thermal" || call.URL == "/redfish/v1/Chassis/1/Power" {
+			t.Errorf("Deprecated resources should not be read: %s", call.URL)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"strings"
 
 	"github.com/jacobweinstock/gophish/common"
 )
@@ -27,6 +28,83 @@ type CacheSummary struct {
 	TotalCacheSizeMiB int
 }
 
+// ControllerRates shall contain all the rate settings available on the
+// controller.
+type ControllerRates struct {
+	// ConsistencyCheckRatePercent shall contain the percentage of controller
+	// resources used for checking data consistency on volumes.
+	ConsistencyCheckRatePercent int
+	// RebuildRatePercent shall contain the percentage of controller resources
+	// used for rebuilding volumes.
+	RebuildRatePercent int
+	// TransformationRatePercent shall contain the percentage of controller
+	// resources used for transforming volumes from one configuration to
+	// another.
+	TransformationRatePercent int
+}
+
+// NVMeControllerType is the type of an NVMe controller.
+type NVMeControllerType string
+
+const (
+	// AdminNVMeControllerType is an NVMe controller that allows privileged
+	// access to an NVMe subsystem.
+	AdminNVMeControllerType NVMeControllerType = "Admin"
+	// DiscoveryNVMeControllerType is an NVMe controller used to discover the
+	// NVMe subsystems of a fabric.
+	DiscoveryNVMeControllerType NVMeControllerType = "Discovery"
+	// IONVMeControllerType is an NVMe controller that reads and writes data.
+	IONVMeControllerType NVMeControllerType = "IO"
+)
+
+// NVMeControllerAttributes shall contain the optional features an NVMe
+// controller supports.
+type NVMeControllerAttributes struct {
+	// ReportsNamespaceGranularity shall indicate whether the controller
+	// supports reporting of namespace granularity.
+	ReportsNamespaceGranularity bool
+	// ReportsUUIDList shall indicate whether the controller supports
+	// reporting of a UUID list.
+	ReportsUUIDList bool
+	// Supports128BitHostID shall indicate whether the controller supports a
+	// 128-bit host identifier.
+	Supports128BitHostID bool `json:"Supports128BitHostId"`
+	// SupportsEnduranceGroups shall indicate whether the controller supports
+	// endurance groups.
+	SupportsEnduranceGroups bool
+	// SupportsNVMSets shall indicate whether the controller supports NVM
+	// sets.
+	SupportsNVMSets bool
+	// SupportsPredictableLatencyMode shall indicate whether the controller
+	// supports predictable latency mode.
+	SupportsPredictableLatencyMode bool
+	// SupportsReadRecoveryLevels shall indicate whether the controller
+	// supports read recovery levels.
+	SupportsReadRecoveryLevels bool
+	// SupportsReservations shall indicate whether the controller supports
+	// reservations.
+	SupportsReservations bool
+	// SupportsSQAssociations shall indicate whether the controller supports
+	// submission queue associations.
+	SupportsSQAssociations bool
+}
+
+// NVMeControllerProperties shall contain the capabilities of an NVMe
+// controller.
+type NVMeControllerProperties struct {
+	// ControllerType shall contain the type of the NVMe controller.
+	ControllerType NVMeControllerType
+	// MaxQueueSize shall contain the maximum individual queue entry size
+	// supported per queue.
+	MaxQueueSize int
+	// NVMeControllerAttributes shall contain the optional features the
+	// controller supports.
+	NVMeControllerAttributes NVMeControllerAttributes
+	// NVMeVersion shall contain the version of the NVMe Base Specification
+	// supported.
+	NVMeVersion string
+}
+
 // Storage is used to represent resources that represent a storage
 // subsystem in the Redfish specification.
 type Storage struct {
@@ -54,6 +132,9 @@ type Storage struct {
 	StorageControllers []StorageController
 	// StorageControllersCount is the number of
 	StorageControllersCount int `json:"StorageControllers@odata.count"`
+	// controllers is the collection of storage controllers of newer
+	// services, replacing the StorageControllers array.
+	controllers string
 	// Volumes is a collection that indicates all the volumes produced by the
 	// storage controllers that this resource represents.
 	volumes string
@@ -64,6 +145,10 @@ type Storage struct {
 	EnclosuresCount int
 	// setEncryptionKeyTarget is the URL to send SetEncryptionKey requests.
 	setEncryptionKeyTarget string
+	// settings is the @Redfish.Settings annotation of this resource.
+	settings common.Settings
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Storage object from the raw JSON.
@@ -80,10 +165,12 @@ func (storage *Storage) UnmarshalJSON(b []byte) error {
 	}
 	var t struct {
 		temp
-		Links   links
-		Drives  common.Links
-		Volumes common.Link
-		Actions actions
+		Settings    common.Settings `json:"@Redfish.Settings"`
+		Links       links
+		Drives      common.Links
+		Volumes     common.Link
+		Controllers common.Link
+		Actions     actions
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -92,13 +179,24 @@ func (storage *Storage) UnmarshalJSON(b []byte) error {
 	}
 
 	*storage = Storage(t.temp)
+	storage.settings = t.Settings
 
 	// Extract the links to other entities for later
 	storage.enclosures = t.Links.Enclosures.ToStrings()
 	storage.EnclosuresCount = t.Links.EnclosuresCount
 	storage.drives = t.Drives.ToStrings()
 	storage.volumes = string(t.Volumes)
+	storage.controllers = string(t.Controllers)
 	storage.setEncryptionKeyTarget = t.Actions.SetEncryptionKey.Target
+	for i := range storage.StorageControllers {
+		if strings.Contains(storage.StorageControllers[i].ODataID, "#") {
+			storage.StorageControllers[i].storageURI = storage.ODataID
+			storage.StorageControllers[i].index = i
+		}
+	}
+
+	// This is a read/write object, so we need to save the raw object data for later
+	storage.rawData = b
 
 	return nil
 }
@@ -172,11 +270,36 @@ func (storage *Storage) Drives(ctx context.Context) ([]*Drive, error) {
 	return result, nil
 }
 
+// Controllers gets the storage controllers of this storage subsystem, from
+// the StorageControllers collection of newer services or else from the
+// StorageControllers array.
+func (storage *Storage) Controllers(ctx context.Context) ([]*StorageController, error) {
+	if storage.controllers != "" {
+		return ListReferencedStorageControllers(ctx, storage.Client, storage.controllers)
+	}
+
+	result := make([]*StorageController, 0, len(storage.StorageControllers))
+	for i := range storage.StorageControllers {
+		controller := &storage.StorageControllers[i]
+		controller.SetClient(storage.Client)
+		result = append(result, controller)
+	}
+	return result, nil
+}
+
 // Volumes gets the volumes associated with this storage subsystem.
 func (storage *Storage) Volumes(ctx context.Context) ([]*Volume, error) {
 	return ListReferencedVolumes(ctx, storage.Client, storage.volumes)
 }
 
+// CreateVolume creates a volume, such as a RAID array, on the drives of the
+// storage subsystem. It returns the URI of the new volume, or the task
+// creating it if the controller creates it asynchronously, in which case the
+// URI is empty.
+func (storage *Storage) CreateVolume(ctx context.Context, spec VolumeSpec) (string, *Task, error) {
+	return createVolume(ctx, storage.Client, storage.volumes, spec)
+}
+
 // SetEncryptionKey shall set the encryption key for the storage subsystem.
 func (storage *Storage) SetEncryptionKey(ctx context.Context, key string) error {
 	type temp struct {
@@ -194,6 +317,12 @@ func (storage *Storage) GetOperationApplyTimeValues(ctx context.Context) ([]comm
 
 }
 
+// PendingSettings gives access to the settings that will be applied to this
+// storage subsystem, following the @Redfish.Settings pattern.
+func (storage *Storage) PendingSettings() *common.PendingSettings {
+	return common.NewPendingSettings(storage.Client, storage.ODataID, storage.settings, storage.rawData)
+}
+
 // StorageController is used to represent a resource that represents a
 // storage controller in the Redfish specification.
 type StorageController struct {
@@ -207,6 +336,9 @@ type StorageController struct {
 	// CacheSummary shall contain properties which describe the cache memory for
 	// the current resource.
 	CacheSummary CacheSummary
+	// ControllerRates shall contain the rates at which the controller
+	// rebuilds, checks the consistency of and transforms its volumes.
+	ControllerRates ControllerRates
 	// FirmwareVersion shall contain the firmware version as defined by the
 	// manufacturer for the associated storage controller.
 	FirmwareVersion string
@@ -226,6 +358,9 @@ type StorageController struct {
 	// Model shall be the name by which the manufacturer generally refers to the
 	// storage controller.
 	Model string
+	// NVMeControllerProperties shall contain the capabilities of the
+	// controller if it is an NVMe controller.
+	NVMeControllerProperties NVMeControllerProperties
 	// PCIeInterface is used to connect this PCIe-based controller to its host.
 	PCIeInterface PCIeInterface
 	// PartNumber shall be a part number assigned by the organization that is
@@ -263,6 +398,12 @@ type StorageController struct {
 	storageServices []string
 	// StorageServicesCount is the number of storage services.
 	StorageServicesCount int
+	// storageURI is the URI of the storage resource holding the controller
+	// if it is a member of the StorageControllers array.
+	storageURI string
+	// index is the position of the controller in the StorageControllers
+	// array.
+	index int
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -291,7 +432,7 @@ func (storagecontroller *StorageController) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	storagecontroller.assembly = string(t.Assembly)
-	storagecontroller.endpoints = t.Links.StorageServices.ToStrings()
+	storagecontroller.endpoints = t.Links.Endpoints.ToStrings()
 	storagecontroller.EndpointsCount = t.Links.EndpointsCount
 	storagecontroller.storageServices = t.Links.StorageServices.ToStrings()
 	storagecontroller.StorageServicesCount = t.Links.StorageServicesCount
@@ -314,10 +455,49 @@ func (storagecontroller *StorageController) Update(ctx context.Context) error {
 		"AssetTag",
 	}
 
+	// The controller rates are a nested object, so the changed rates are
+	// collected on their own.
+	rates := make(map[string]interface{})
+	originalRates := reflect.ValueOf(original.ControllerRates)
+	currentRates := reflect.ValueOf(storagecontroller.ControllerRates)
+	for i := 0; i < originalRates.NumField(); i++ {
+		if originalRates.Field(i).Interface() != currentRates.Field(i).Interface() {
+			rates[originalRates.Type().Field(i).Name] = currentRates.Field(i).Interface()
+		}
+	}
+
+	// Members of the StorageControllers array only exist as part of the
+	// storage resource, so they are updated through it.
+	if storagecontroller.storageURI != "" {
+		values := make(map[string]interface{})
+		if original.AssetTag != storagecontroller.AssetTag {
+			values["AssetTag"] = storagecontroller.AssetTag
+		}
+		if len(rates) > 0 {
+			values["ControllerRates"] = rates
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		_, err := common.PatchArrayMember(ctx, storagecontroller.Client, storagecontroller.storageURI, "StorageControllers",
+			storagecontroller.index, values)
+		return err
+	}
+
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(storagecontroller).Elem()
 
-	return storagecontroller.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+	err := storagecontroller.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+	if err != nil {
+		return err
+	}
+
+	if len(rates) > 0 {
+		payload := map[string]interface{}{"ControllerRates": rates}
+		_, err = storagecontroller.Client.Patch(ctx, storagecontroller.ODataID, payload)
+	}
+
+	return err
 }
 
 // GetStorageController will get a Storage controller instance from the service.
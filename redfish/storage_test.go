@@ -5,8 +5,11 @@
 package redfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -189,3 +192,288 @@ func TestStorageControllerUpdate(t *testing.T) {
 		t.Errorf("Unexpected AssetTag update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestStorageCreateVolume tests the CreateVolume call.
+func TestStorageCreateVolume(t *testing.T) {
+	var result Storage
+	err := json.NewDecoder(strings.NewReader(storageBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	created := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": []string{"https://bmc.example.com/redfish/v1/Volumes/1/2"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, task, err := result.CreateVolume(context.Background(), VolumeSpec{
+		Name:           "os",
+		RAIDType:       RAID1RAIDType,
+		CapacityBytes:  480000000000,
+		Drives:         []string{"/redfish/v1/Drives/0", "/redfish/v1/Drives/1"},
+		StripSizeBytes: 65536,
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateVolume call: %s", err)
+	}
+	if uri != "/redfish/v1/Volumes/1/2" || task != nil {
+		t.Errorf("Unexpected result: %s %v", uri, task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Volumes/1" {
+		t.Errorf("Invalid CreateVolume target: %s", calls[0].URL)
+	}
+	for _, expected := range []string{"RAIDType:RAID1", "CapacityBytes:4.8e+11", "StripSizeBytes:65536",
+		"Links:map[Drives:[map[@odata.id:/redfish/v1/Drives/0] map[@odata.id:/redfish/v1/Drives/1]]]"} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("Missing %s in CreateVolume payload: %s", expected, calls[0].Payload)
+		}
+	}
+	if strings.Contains(calls[0].Payload, "OperationApplyTime") {
+		t.Errorf("Apply time should not be sent when not set: %s", calls[0].Payload)
+	}
+}
+
+// TestStorageCreateVolumeAsync tests creating a volume at the next reset.
+func TestStorageCreateVolumeAsync(t *testing.T) {
+	var result Storage
+	err := json.NewDecoder(strings.NewReader(storageBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	applyTimes := &http.Response{
+		StatusCode: http.StatusOK,
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{
+			"@Redfish.OperationApplyTimeSupport": {"SupportedValues": ["Immediate", "OnReset"]}
+		}`)),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {applyTimes},
+			http.MethodPost: {acceptedResponse(
+				`{"@odata.id": "/redfish/v1/TaskService/Tasks/9", "Id": "9", "TaskState": "Pending"}`, "")},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, task, err := result.CreateVolume(context.Background(), VolumeSpec{
+		RAIDType:  RAID5RAIDType,
+		Drives:    []string{"/redfish/v1/Drives/0", "/redfish/v1/Drives/1", "/redfish/v1/Drives/2"},
+		ApplyTime: common.OnResetOperationApplyTime,
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateVolume call: %s", err)
+	}
+	if uri != "" || task == nil || task.TaskState != PendingTaskState {
+		t.Errorf("Unexpected result: %s %v", uri, task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if !strings.Contains(calls[1].Payload, "@Redfish.OperationApplyTime:OnReset") {
+		t.Errorf("Missing apply time in CreateVolume payload: %s", calls[1].Payload)
+	}
+}
+
+// TestStorageCreateVolumeValidation tests rejecting invalid volumes.
+func TestStorageCreateVolumeValidation(t *testing.T) {
+	var result Storage
+	err := json.NewDecoder(strings.NewReader(storageBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	applyTimes := &http.Response{
+		StatusCode: http.StatusOK,
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{
+			"@Redfish.OperationApplyTimeSupport": {"SupportedValues": ["Immediate"]}
+		}`)),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {applyTimes},
+		},
+	}
+	result.SetClient(testClient)
+
+	specs := []VolumeSpec{
+		{RAIDType: RAID5RAIDType, Drives: []string{"/redfish/v1/Drives/0", "/redfish/v1/Drives/1"}},
+		{RAIDType: RAID10RAIDType, Drives: []string{"a", "b", "c", "d", "e"}},
+		{CapacityBytes: -1},
+		{ApplyTime: common.OnResetOperationApplyTime},
+	}
+	for _, spec := range specs {
+		if _, _, err := result.CreateVolume(context.Background(), spec); err == nil {
+			t.Errorf("Expected an error creating %+v", spec)
+		}
+	}
+
+	for _, call := range testClient.CapturedCalls() {
+		if call.Action == http.MethodPost {
+			t.Errorf("Invalid volumes should not be sent: %v", call)
+		}
+	}
+}
+
+// TestStorageControllers tests reading the controllers from the
+// StorageControllers array and from the Controllers collection.
+func TestStorageControllers(t *testing.T) {
+	var result Storage
+	err := json.NewDecoder(strings.NewReader(storageBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	controllers, err := result.Controllers(context.Background())
+	if err != nil {
+		t.Fatalf("Error getting controllers: %s", err)
+	}
+	if len(controllers) != 1 || controllers[0].Client != testClient {
+		t.Fatalf("Unexpected controllers: %v", controllers)
+	}
+	if controllers[0].ControllerRates.RebuildRatePercent != 5 {
+		t.Errorf("Invalid rebuild rate: %d", controllers[0].ControllerRates.RebuildRatePercent)
+	}
+	if len(controllers[0].endpoints) != 1 || controllers[0].endpoints[0] != "/redfish/v1/Endpoints/1" {
+		t.Errorf("Invalid endpoints: %v", controllers[0].endpoints)
+	}
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Embedded controllers should not be requested: %v", testClient.CapturedCalls())
+	}
+
+	err = json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1",
+		"Controllers": {"@odata.id": "/redfish/v1/Systems/1/Storage/1/Controllers"}
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	testClient = &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"Members": [{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Controllers/0"}],
+					"Members@odata.count": 1
+				}`))},
+				&http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"@odata.id": "/redfish/v1/Systems/1/Storage/1/Controllers/0",
+					"Id": "0",
+					"ControllerRates": {"RebuildRatePercent": 30}
+				}`))},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	controllers, err = result.Controllers(context.Background())
+	if err != nil {
+		t.Fatalf("Error getting controllers: %s", err)
+	}
+	if len(controllers) != 1 || controllers[0].ControllerRates.RebuildRatePercent != 30 {
+		t.Errorf("Unexpected controllers: %v", controllers)
+	}
+}
+
+// TestStorageControllerUpdateRates tests updating the controller rates.
+func TestStorageControllerUpdateRates(t *testing.T) {
+	var result Storage
+	err := json.NewDecoder(strings.NewReader(storageBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	controller := result.StorageControllers[0]
+	testClient := &common.TestClient{}
+	controller.SetClient(testClient)
+
+	controller.ControllerRates.RebuildRatePercent = 60
+	err = controller.Update(context.Background())
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Payload != "map[ControllerRates:map[RebuildRatePercent:60]]" {
+		t.Errorf("Unexpected ControllerRates update: %v", calls)
+	}
+}
+
+// TestStorageControllerUpdateEmbedded tests that controllers of the
+// StorageControllers array are updated through the storage resource.
+func TestStorageControllerUpdateEmbedded(t *testing.T) {
+	var result Storage
+	err := json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1",
+		"StorageControllers": [
+			{
+				"@odata.id": "/redfish/v1/Systems/1/Storage/1#/StorageControllers/0",
+				"MemberId": "0",
+				"AssetTag": "ABC123",
+				"ControllerRates": {"RebuildRatePercent": 5},
+				"NVMeControllerProperties": {
+					"ControllerType": "IO",
+					"NVMeVersion": "1.4",
+					"NVMeControllerAttributes": {"SupportsReservations": true}
+				}
+			},
+			{
+				"@odata.id": "/redfish/v1/Systems/1/Storage/1#/StorageControllers/1",
+				"MemberId": "1",
+				"ControllerRates": {"RebuildRatePercent": 5}
+			}
+		]
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+	controllers, err := result.Controllers(context.Background())
+	if err != nil {
+		t.Fatalf("Error getting controllers: %s", err)
+	}
+
+	nvme := controllers[0].NVMeControllerProperties
+	if nvme.ControllerType != IONVMeControllerType || !nvme.NVMeControllerAttributes.SupportsReservations {
+		t.Errorf("Invalid NVMe controller properties: %+v", nvme)
+	}
+
+	controllers[0].ControllerRates.RebuildRatePercent = 60
+	err = controllers[0].Update(context.Background())
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+	controllers[1].AssetTag = "DEF456"
+	err = controllers[1].Update(context.Background())
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Unexpected calls: %v", calls)
+	}
+	for _, call := range calls {
+		if call.Action != http.MethodPatch || call.URL != "/redfish/v1/Systems/1/Storage/1" {
+			t.Errorf("Controller should be updated through the storage resource: %s %s", call.Action, call.URL)
+		}
+	}
+	if calls[0].Payload != "map[StorageControllers:[map[ControllerRates:map[RebuildRatePercent:60]]]]" {
+		t.Errorf("Unexpected ControllerRates update: %s", calls[0].Payload)
+	}
+	if calls[1].Payload != "map[StorageControllers:[map[] map[AssetTag:DEF456]]]" {
+		t.Errorf("Unexpected AssetTag update: %s", calls[1].Payload)
+	}
+}
@@ -7,6 +7,10 @@ package redfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 
 	"github.com/jacobweinstock/gophish/common"
 )
@@ -193,3 +197,57 @@ func ListReferencedTasks(ctx context.Context, c common.Client, link string) ([]*
 
 	return result, nil
 }
+
+// TaskFromResponse returns the task of an operation the service accepted to
+// run asynchronously, with a 202 Accepted response. The task is read from the
+// body of the response, or else from the task monitor in the Location header.
+// It returns nil if the service completed the operation.
+func TaskFromResponse(ctx context.Context, c common.Client, resp *http.Response) (*Task, error) {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, nil
+	}
+
+	location := resp.Header.Get("Location")
+	if parsed, err := url.Parse(location); err == nil && parsed.Host != "" {
+		location = parsed.RequestURI()
+	}
+
+	var task Task
+	body, err := ioutil.ReadAll(resp.Body)
+	if err == nil && json.Unmarshal(body, &task) == nil && task.ODataID != "" {
+		task.SetClient(c)
+	} else if location != "" {
+		result, err := GetTask(ctx, c, location)
+		if err != nil {
+			return nil, err
+		}
+		task = *result
+	} else {
+		return nil, fmt.Errorf("the operation was accepted without a task to monitor it")
+	}
+
+	if task.TaskMonitor == "" {
+		task.TaskMonitor = location
+	}
+
+	return &task, nil
+}
+
+// LocationOrTask returns the URI of the resource created by a request from the
+// Location header, or the task creating it if the service accepted to create
+// it asynchronously.
+func LocationOrTask(ctx context.Context, c common.Client, resp *http.Response) (string, *Task, error) {
+	if resp.StatusCode == http.StatusAccepted {
+		task, err := TaskFromResponse(ctx, c, resp)
+		return "", task, err
+	}
+	defer resp.Body.Close()
+
+	location := resp.Header.Get("Location")
+	if parsed, err := url.Parse(location); err == nil && parsed.Host != "" {
+		location = parsed.RequestURI()
+	}
+
+	return location, nil, nil
+}
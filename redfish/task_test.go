@@ -5,7 +5,11 @@
 package redfish
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -65,3 +69,70 @@ func TestTask(t *testing.T) {
 		t.Errorf("Invalid TaskStatus: %s", result.TaskStatus)
 	}
 }
+
+// acceptedResponse returns a 202 Accepted response with the body and
+// Location header.
+func acceptedResponse(body, location string) *http.Response {
+	resp := &http.Response{
+		Status:     "202 Accepted",
+		StatusCode: http.StatusAccepted,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+	if location != "" {
+		resp.Header.Set("Location", location)
+	}
+	return resp
+}
+
+// TestTaskFromResponse tests reading the task of an asynchronous operation.
+func TestTaskFromResponse(t *testing.T) {
+	taskJSON := `{"@odata.id": "/redfish/v1/TaskService/Tasks/7", "Id": "7", "TaskState": "Running"}`
+
+	testClient := &common.TestClient{}
+	task, err := TaskFromResponse(context.Background(), testClient,
+		acceptedResponse(taskJSON, "https://bmc.example.com/redfish/v1/TaskService/TaskMonitors/7"))
+	if err != nil {
+		t.Fatalf("Error reading task: %s", err)
+	}
+	if task.ID != "7" || task.TaskState != RunningTaskState {
+		t.Errorf("Unexpected task: %v", task)
+	}
+	if task.TaskMonitor != "/redfish/v1/TaskService/TaskMonitors/7" {
+		t.Errorf("Invalid task monitor: %s", task.TaskMonitor)
+	}
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Unexpected calls: %v", testClient.CapturedCalls())
+	}
+
+	testClient = &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {acceptedResponse(taskJSON, "")},
+		},
+	}
+	task, err = TaskFromResponse(context.Background(), testClient,
+		acceptedResponse("", "/redfish/v1/TaskService/Tasks/7"))
+	if err != nil {
+		t.Fatalf("Error reading task: %s", err)
+	}
+	if task.ID != "7" {
+		t.Errorf("Unexpected task: %v", task)
+	}
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/TaskService/Tasks/7" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+
+	task, err = TaskFromResponse(context.Background(), testClient, &http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	})
+	if err != nil || task != nil {
+		t.Errorf("Expected no task for a completed operation, got %v: %v", task, err)
+	}
+
+	_, err = TaskFromResponse(context.Background(), testClient, acceptedResponse("", ""))
+	if err == nil {
+		t.Error("Expected an error for an accepted operation without task")
+	}
+}
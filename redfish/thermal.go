@@ -110,6 +110,13 @@ type Fan struct {
 	// range but is not critical. The units shall be the same units as the
 	// related Reading property.
 	UpperThresholdNonCritical float32
+	// defined holds the properties the service did not send as null, so
+	// missing readings and thresholds can be told apart from zero values.
+	defined map[string]bool
+	// thermalURI is the URI of the thermal resource holding the fan.
+	thermalURI string
+	// index is the position of the fan in the thermal resource.
+	index int
 }
 
 // UnmarshalJSON unmarshals a Fan object from the raw JSON.
@@ -129,6 +136,7 @@ func (fan *Fan) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	*fan = Fan(t.temp)
 	fan.assembly = string(t.Assembly)
+	fan.defined = nonNullProperties(b)
 
 	if t.FanName != "" {
 		fan.Name = t.FanName
@@ -252,6 +260,31 @@ type Temperature struct {
 	// UpperThresholdNonCritical, UpperThresholdCritical, or
 	// UpperThresholdFatal, unless set by a user.
 	UpperThresholdUser float32
+	// defined holds the properties the service did not send as null, so
+	// missing readings and thresholds can be told apart from zero values.
+	defined map[string]bool
+	// thermalURI is the URI of the thermal resource holding the temperature.
+	thermalURI string
+	// index is the position of the temperature in the thermal resource.
+	index int
+}
+
+// UnmarshalJSON unmarshals a Temperature object from the raw JSON.
+func (temperature *Temperature) UnmarshalJSON(b []byte) error {
+	type temp Temperature
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*temperature = Temperature(t.temp)
+	temperature.defined = nonNullProperties(b)
+
+	return nil
 }
 
 // Thermal is used to represent a thermal metrics resource for a Redfish
@@ -300,12 +333,35 @@ func (thermal *Thermal) UnmarshalJSON(b []byte) error {
 
 	*thermal = Thermal(t.temp)
 
+	// Thresholds are changed through the thermal resource, so the sensors
+	// need to know where they are.
+	for i := range thermal.Temperatures {
+		thermal.Temperatures[i].thermalURI = thermal.ODataID
+		thermal.Temperatures[i].index = i
+	}
+	for i := range thermal.Fans {
+		thermal.Fans[i].thermalURI = thermal.ODataID
+		thermal.Fans[i].index = i
+	}
+
 	// This is a read/write object, so we need to save the raw object data for later
 	thermal.rawData = b
 
 	return nil
 }
 
+// SetClient sets the API client connection to use for accessing the thermal
+// resource and its temperatures and fans.
+func (thermal *Thermal) SetClient(c common.Client) {
+	thermal.Entity.SetClient(c)
+	for i := range thermal.Temperatures {
+		thermal.Temperatures[i].SetClient(c)
+	}
+	for i := range thermal.Fans {
+		thermal.Fans[i].SetClient(c)
+	}
+}
+
 // // Update commits updates to this object's properties to the running system.
 // func (thermal *Thermal) Update() error {
 
@@ -0,0 +1,45 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// ThermalMetrics shall represent the thermal metrics of a ThermalSubsystem.
+type ThermalMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// TemperatureReadingsCelsius shall contain the temperatures, in degree
+	// Celsius units, of the thermal subsystem.
+	TemperatureReadingsCelsius []SensorArrayExcerpt
+}
+
+// GetThermalMetrics will get a ThermalMetrics instance from the service.
+func GetThermalMetrics(ctx context.Context, c common.Client, uri string) (*ThermalMetrics, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var thermalmetrics ThermalMetrics
+	err = json.NewDecoder(resp.Body).Decode(&thermalmetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	thermalmetrics.SetClient(c)
+	return &thermalmetrics, nil
+}
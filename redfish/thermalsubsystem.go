@@ -0,0 +1,217 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// ThermalSubsystem shall represent a thermal subsystem for a Redfish
+// implementation. It replaces the Thermal resource as of Redfish 2020.4.
+type ThermalSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// fans is the link to the collection of fans.
+	fans string
+	// thermalMetrics is the link to the thermal metrics.
+	thermalMetrics string
+}
+
+// UnmarshalJSON unmarshals a ThermalSubsystem object from the raw JSON.
+func (thermalsubsystem *ThermalSubsystem) UnmarshalJSON(b []byte) error {
+	type temp ThermalSubsystem
+	var t struct {
+		temp
+		Fans           common.Link
+		ThermalMetrics common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*thermalsubsystem = ThermalSubsystem(t.temp)
+
+	// Extract the links to other entities for later
+	thermalsubsystem.fans = string(t.Fans)
+	thermalsubsystem.thermalMetrics = string(t.ThermalMetrics)
+
+	return nil
+}
+
+// Fans gets the fans of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) Fans(ctx context.Context) ([]*FanUnit, error) {
+	return ListReferencedFanUnits(ctx, thermalsubsystem.Client, thermalsubsystem.fans)
+}
+
+// ThermalMetrics gets the thermal metrics of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) ThermalMetrics(ctx context.Context) (*ThermalMetrics, error) {
+	if thermalsubsystem.thermalMetrics == "" {
+		return nil, nil
+	}
+
+	return GetThermalMetrics(ctx, thermalsubsystem.Client, thermalsubsystem.thermalMetrics)
+}
+
+// GetThermalSubsystem will get a ThermalSubsystem instance from the service.
+func GetThermalSubsystem(ctx context.Context, c common.Client, uri string) (*ThermalSubsystem, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var thermalsubsystem ThermalSubsystem
+	err = json.NewDecoder(resp.Body).Decode(&thermalsubsystem)
+	if err != nil {
+		return nil, err
+	}
+
+	thermalsubsystem.SetClient(c)
+	return &thermalsubsystem, nil
+}
+
+// FanUnit shall represent a cooling fan of a ThermalSubsystem. It is named to
+// not clash with the Fan of the Thermal resource.
+type FanUnit struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its current
+	// operational state.
+	HotPluggable bool
+	// Location shall contain the location information of the fan.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the fan.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this fan.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this fan.
+	PartNumber string
+	// PhysicalContext shall contain a description of the affected device or
+	// region within the chassis with which this fan is associated.
+	PhysicalContext common.PhysicalContext
+	// PowerWatts shall contain the total power, in watts, consumed by this
+	// fan.
+	PowerWatts SensorPowerExcerpt
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this fan.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this fan.
+	SparePartNumber string
+	// SpeedPercent shall contain the fan speed, in percent units, for this
+	// resource.
+	SpeedPercent SensorFanExcerpt
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals a FanUnit object from the raw JSON.
+func (fan *FanUnit) UnmarshalJSON(b []byte) error {
+	type temp FanUnit
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*fan = FanUnit(t.temp)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	fan.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (fan *FanUnit) Update(ctx context.Context) error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(FanUnit)
+	original.UnmarshalJSON(fan.rawData)
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(fan).Elem()
+
+	return fan.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+}
+
+// GetFanUnit will get a FanUnit instance from the service.
+func GetFanUnit(ctx context.Context, c common.Client, uri string) (*FanUnit, error) {
+	resp, err := c.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var fan FanUnit
+	err = json.NewDecoder(resp.Body).Decode(&fan)
+	if err != nil {
+		return nil, err
+	}
+
+	fan.SetClient(c)
+	return &fan, nil
+}
+
+// ListReferencedFanUnits gets the collection of FanUnit from a provided
+// reference.
+func ListReferencedFanUnits(ctx context.Context, c common.Client, link string) ([]*FanUnit, error) {
+	var result []*FanUnit
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(ctx, c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, fanLink := range links.ItemLinks {
+		fan, err := GetFanUnit(ctx, c, fanLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, fan)
+	}
+
+	return result, nil
+}
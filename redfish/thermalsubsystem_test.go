@@ -0,0 +1,142 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var thermalSubsystemBody = `{
+		"@odata.type": "#ThermalSubsystem.v1_0_0.ThermalSubsystem",
+		"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem",
+		"Id": "ThermalSubsystem",
+		"Name": "Thermal Subsystem for Chassis",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Fans": {
+			"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans"
+		}
+	}`
+
+var fanUnitBody = `{
+		"@odata.type": "#Fan.v1_1_0.Fan",
+		"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans/Bay1",
+		"Id": "Bay1",
+		"Name": "Fan Bay 1",
+		"PhysicalContext": "Chassis",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"SpeedPercent": {
+			"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/FanBay1",
+			"Reading": 45,
+			"SpeedRPM": 2200
+		},
+		"PowerWatts": {
+			"Reading": 12.5
+		},
+		"LocationIndicatorActive": false,
+		"HotPluggable": true,
+		"Manufacturer": "Contoso Fans"
+	}`
+
+// TestThermalSubsystem tests the parsing of ThermalSubsystem objects.
+func TestThermalSubsystem(t *testing.T) {
+	var result ThermalSubsystem
+	err := json.NewDecoder(strings.NewReader(thermalSubsystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "ThermalSubsystem" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"Members": [{"@odata.id": "/redfish/v1/Chassis/1/ThermalSubsystem/Fans/Bay1"}],
+					"Members@odata.count": 1
+				}`))},
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(fanUnitBody))},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	fans, err := result.Fans(context.Background())
+	if err != nil {
+		t.Errorf("Error getting fans: %s", err)
+	}
+
+	if len(fans) != 1 || fans[0].ID != "Bay1" {
+		t.Errorf("Unexpected fans: %v", fans)
+	}
+}
+
+// TestFanUnit tests the parsing of FanUnit objects.
+func TestFanUnit(t *testing.T) {
+	var result FanUnit
+	err := json.NewDecoder(strings.NewReader(fanUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.SpeedPercent.Reading == nil || *result.SpeedPercent.Reading != 45 {
+		t.Errorf("Invalid speed percent: %v", result.SpeedPercent.Reading)
+	}
+
+	if result.SpeedPercent.SpeedRPM == nil || *result.SpeedPercent.SpeedRPM != 2200 {
+		t.Errorf("Invalid speed RPM: %v", result.SpeedPercent.SpeedRPM)
+	}
+
+	if result.SpeedPercent.DataSourceURI != "/redfish/v1/Chassis/1/Sensors/FanBay1" {
+		t.Errorf("Invalid data source: %s", result.SpeedPercent.DataSourceURI)
+	}
+
+	if result.PowerWatts.ApparentVA != nil {
+		t.Errorf("Missing apparent power should be nil: %v", result.PowerWatts.ApparentVA)
+	}
+}
+
+// TestFanUnitUpdate tests the Update call.
+func TestFanUnitUpdate(t *testing.T) {
+	var result FanUnit
+	err := json.NewDecoder(strings.NewReader(fanUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.LocationIndicatorActive = true
+	err = result.Update(context.Background())
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "LocationIndicatorActive:true") {
+		t.Errorf("Unexpected LocationIndicatorActive update payload: %s", calls[0].Payload)
+	}
+}
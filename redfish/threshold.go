@@ -0,0 +1,303 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+// ThresholdState is the classification of a reading against the thresholds
+// of its sensor.
+type ThresholdState string
+
+const (
+	// UnknownThresholdState indicates the reading is missing or outside the
+	// range the sensor can report, so it could not be classified.
+	UnknownThresholdState ThresholdState = "Unknown"
+	// NormalThresholdState indicates the reading has not crossed any
+	// threshold.
+	NormalThresholdState ThresholdState = "Normal"
+	// WarningThresholdState indicates the reading crossed a caution
+	// (NonCritical) threshold.
+	WarningThresholdState ThresholdState = "Warning"
+	// CriticalThresholdState indicates the reading crossed a critical
+	// threshold.
+	CriticalThresholdState ThresholdState = "Critical"
+	// FatalThresholdState indicates the reading crossed a fatal threshold.
+	FatalThresholdState ThresholdState = "Fatal"
+)
+
+// severity orders the states from the least to the most severe.
+func (state ThresholdState) severity() int {
+	switch state {
+	case NormalThresholdState:
+		return 1
+	case WarningThresholdState:
+		return 2
+	case CriticalThresholdState:
+		return 3
+	case FatalThresholdState:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// ThresholdEvaluation is the classification of a sensor reading.
+type ThresholdEvaluation struct {
+	SensorReading
+	// State is the classification of the reading.
+	State ThresholdState
+	// Threshold is the name of the crossed threshold, such as UpperCritical.
+	// It is empty if no threshold was crossed.
+	Threshold string
+	// OutOfRange is true if the reading is outside the range the sensor can
+	// report, which indicates a faulty sensor rather than a real condition.
+	OutOfRange bool
+}
+
+// Evaluate classifies the reading against the thresholds of the sensor.
+// Thresholds that are not defined are ignored, as are thresholds outside the
+// reading range that some services report as placeholders. Upper thresholds
+// are crossed when the reading is at or above them, lower thresholds when the
+// reading is at or below them.
+func (reading SensorReading) Evaluate() ThresholdEvaluation {
+	evaluation := ThresholdEvaluation{
+		SensorReading: reading,
+		State:         UnknownThresholdState,
+	}
+
+	if reading.Reading == nil {
+		return evaluation
+	}
+
+	value := *reading.Reading
+	if !reading.inRange(value) {
+		evaluation.OutOfRange = true
+		return evaluation
+	}
+
+	levels := []struct {
+		state        ThresholdState
+		name         string
+		lower, upper Threshold
+	}{
+		{FatalThresholdState, "Fatal", reading.Thresholds.LowerFatal, reading.Thresholds.UpperFatal},
+		{CriticalThresholdState, "Critical", reading.Thresholds.LowerCritical, reading.Thresholds.UpperCritical},
+		{WarningThresholdState, "Caution", reading.Thresholds.LowerCaution, reading.Thresholds.UpperCaution},
+	}
+	for _, level := range levels {
+		if level.upper.Reading != nil && reading.inRange(*level.upper.Reading) && value >= *level.upper.Reading {
+			evaluation.State = level.state
+			evaluation.Threshold = "Upper" + level.name
+			return evaluation
+		}
+		if level.lower.Reading != nil && reading.inRange(*level.lower.Reading) && value <= *level.lower.Reading {
+			evaluation.State = level.state
+			evaluation.Threshold = "Lower" + level.name
+			return evaluation
+		}
+	}
+
+	evaluation.State = NormalThresholdState
+	return evaluation
+}
+
+// inRange returns whether a value is within the reading range of the sensor.
+func (reading SensorReading) inRange(value float32) bool {
+	if reading.ReadingRangeMin != nil && value < *reading.ReadingRangeMin {
+		return false
+	}
+	if reading.ReadingRangeMax != nil && value > *reading.ReadingRangeMax {
+		return false
+	}
+	return true
+}
+
+// Evaluate classifies the temperature reading against its thresholds.
+func (temperature *Temperature) Evaluate() ThresholdEvaluation {
+	return temperature.SensorReading().Evaluate()
+}
+
+// Evaluate classifies the fan reading against its thresholds.
+func (fan *Fan) Evaluate() ThresholdEvaluation {
+	return fan.SensorReading().Evaluate()
+}
+
+// Evaluate classifies the voltage reading against its thresholds.
+func (voltage *Voltage) Evaluate() ThresholdEvaluation {
+	return voltage.SensorReading().Evaluate()
+}
+
+// ThresholdSummary is the classification of the readings of a chassis.
+type ThresholdSummary struct {
+	// Chassis is the URI of the chassis.
+	Chassis string
+	// State is the most severe state of the readings. It is Unknown only if
+	// none of the readings could be classified.
+	State ThresholdState
+	// Counts is the number of readings in each state.
+	Counts map[ThresholdState]int
+	// Evaluations are the classifications of every reading.
+	Evaluations []ThresholdEvaluation
+}
+
+// SummarizeThresholds classifies the readings of a chassis.
+func SummarizeThresholds(chassis string, readings []SensorReading) ThresholdSummary {
+	summary := ThresholdSummary{
+		Chassis: chassis,
+		State:   UnknownThresholdState,
+		Counts:  make(map[ThresholdState]int),
+	}
+
+	for _, reading := range readings {
+		evaluation := reading.Evaluate()
+		summary.Evaluations = append(summary.Evaluations, evaluation)
+		summary.Counts[evaluation.State]++
+		if evaluation.State.severity() > summary.State.severity() {
+			summary.State = evaluation.State
+		}
+	}
+
+	return summary
+}
+
+// EvaluateThresholds classifies the sensor readings of the chassis.
+func (chassis *Chassis) EvaluateThresholds(ctx context.Context) (*ThresholdSummary, error) {
+	readings, err := chassis.SensorReadings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := SummarizeThresholds(chassis.ODataID, readings)
+	return &summary, nil
+}
+
+// SetThresholds changes the thresholds of the sensor that have a reading,
+// leaving the others unchanged. Services may not allow changing some or all of
+// the thresholds, in which case they reject the request.
+func (sensor *Sensor) SetThresholds(ctx context.Context, thresholds Thresholds) error {
+	payload := make(map[string]interface{})
+	for name, threshold := range thresholdsByName(&thresholds) {
+		if threshold.Reading != nil {
+			payload[name] = map[string]interface{}{
+				"Reading": *threshold.Reading,
+			}
+		}
+	}
+	if len(payload) == 0 {
+		return fmt.Errorf("no threshold to set")
+	}
+
+	_, err := sensor.Client.Patch(ctx, sensor.ODataID, map[string]interface{}{
+		"Thresholds": payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	current := thresholdsByName(&sensor.Thresholds)
+	for name, threshold := range thresholdsByName(&thresholds) {
+		if threshold.Reading != nil {
+			value := *threshold.Reading
+			current[name].Reading = &value
+		}
+	}
+	return nil
+}
+
+// SetThresholds changes the thresholds of the temperature sensor that have a
+// reading, leaving the others unchanged. The Caution thresholds are set as
+// the NonCritical ones. Most services do not allow changing these thresholds,
+// in which case they reject the request.
+func (temperature *Temperature) SetThresholds(ctx context.Context, thresholds Thresholds) error {
+	if temperature.thermalURI == "" {
+		return fmt.Errorf("the temperature is not part of a thermal resource")
+	}
+
+	return setLegacyThresholds(ctx, temperature.Client, temperature.thermalURI, "Temperatures", temperature.index,
+		thresholds, reflect.ValueOf(temperature).Elem(), temperature.defined)
+}
+
+// SetThresholds changes the thresholds of the fan that have a reading,
+// leaving the others unchanged. The Caution thresholds are set as the
+// NonCritical ones. Most services do not allow changing these thresholds, in
+// which case they reject the request.
+func (fan *Fan) SetThresholds(ctx context.Context, thresholds Thresholds) error {
+	if fan.thermalURI == "" {
+		return fmt.Errorf("the fan is not part of a thermal resource")
+	}
+
+	return setLegacyThresholds(ctx, fan.Client, fan.thermalURI, "Fans", fan.index,
+		thresholds, reflect.ValueOf(fan).Elem(), fan.defined)
+}
+
+// SetThresholds changes the thresholds of the voltage sensor that have a
+// reading, leaving the others unchanged. The Caution thresholds are set as
+// the NonCritical ones. Most services do not allow changing these thresholds,
+// in which case they reject the request.
+func (voltage *Voltage) SetThresholds(ctx context.Context, thresholds Thresholds) error {
+	if voltage.powerURI == "" {
+		return fmt.Errorf("the voltage is not part of a power resource")
+	}
+
+	return setLegacyThresholds(ctx, voltage.Client, voltage.powerURI, "Voltages", voltage.index,
+		thresholds, reflect.ValueOf(voltage).Elem(), voltage.defined)
+}
+
+// legacyThresholdProperties maps the thresholds of the Sensor resource to the
+// properties of the deprecated Thermal and Power resources.
+var legacyThresholdProperties = map[string]string{
+	"LowerCaution":  "LowerThresholdNonCritical",
+	"LowerCritical": "LowerThresholdCritical",
+	"LowerFatal":    "LowerThresholdFatal",
+	"UpperCaution":  "UpperThresholdNonCritical",
+	"UpperCritical": "UpperThresholdCritical",
+	"UpperFatal":    "UpperThresholdFatal",
+}
+
+// setLegacyThresholds patches the thresholds of a member of the Thermal or
+// Power resources, and updates the member on success.
+func setLegacyThresholds(ctx context.Context, c common.Client, uri, property string, index int,
+	thresholds Thresholds, member reflect.Value, defined map[string]bool) error {
+	values := make(map[string]interface{})
+	for name, threshold := range thresholdsByName(&thresholds) {
+		if threshold.Reading != nil {
+			values[legacyThresholdProperties[name]] = *threshold.Reading
+		}
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("no threshold to set")
+	}
+
+	_, err := common.PatchArrayMember(ctx, c, uri, property, index, values)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range values {
+		member.FieldByName(name).SetFloat(float64(value.(float32)))
+		if defined != nil {
+			defined[name] = true
+		}
+	}
+	return nil
+}
+
+// thresholdsByName returns pointers to the thresholds, by property name.
+func thresholdsByName(thresholds *Thresholds) map[string]*Threshold {
+	return map[string]*Threshold{
+		"LowerCaution":  &thresholds.LowerCaution,
+		"LowerCritical": &thresholds.LowerCritical,
+		"LowerFatal":    &thresholds.LowerFatal,
+		"UpperCaution":  &thresholds.UpperCaution,
+		"UpperCritical": &thresholds.UpperCritical,
+		"UpperFatal":    &thresholds.UpperFatal,
+	}
+}
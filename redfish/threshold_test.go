@@ -0,0 +1,179 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+func float32Pointer(value float32) *float32 {
+	return &value
+}
+
+// TestThresholdEvaluate tests classifying readings against their thresholds.
+func TestThresholdEvaluate(t *testing.T) {
+	thresholds := Thresholds{
+		LowerCritical: Threshold{Reading: float32Pointer(5)},
+		UpperCaution:  Threshold{Reading: float32Pointer(80)},
+		UpperCritical: Threshold{Reading: float32Pointer(90)},
+		// Placeholder outside the reading range.
+		UpperFatal: Threshold{Reading: float32Pointer(255)},
+	}
+
+	tests := []struct {
+		reading    *float32
+		state      ThresholdState
+		threshold  string
+		outOfRange bool
+	}{
+		{nil, UnknownThresholdState, "", false},
+		{float32Pointer(50), NormalThresholdState, "", false},
+		{float32Pointer(80), WarningThresholdState, "UpperCaution", false},
+		{float32Pointer(95), CriticalThresholdState, "UpperCritical", false},
+		{float32Pointer(120), CriticalThresholdState, "UpperCritical", false},
+		{float32Pointer(3), CriticalThresholdState, "LowerCritical", false},
+		{float32Pointer(-1), UnknownThresholdState, "", true},
+		{float32Pointer(130), UnknownThresholdState, "", true},
+	}
+
+	for _, test := range tests {
+		reading := SensorReading{
+			Reading:         test.reading,
+			ReadingRangeMin: float32Pointer(0),
+			ReadingRangeMax: float32Pointer(127),
+			Thresholds:      thresholds,
+		}
+		evaluation := reading.Evaluate()
+		if evaluation.State != test.state || evaluation.Threshold != test.threshold || evaluation.OutOfRange != test.outOfRange {
+			t.Errorf("Unexpected evaluation of %v: %s %s %t", test.reading, evaluation.State, evaluation.Threshold, evaluation.OutOfRange)
+		}
+	}
+}
+
+// TestLegacyThresholdEvaluate tests classifying the Thermal readings, where
+// null thresholds must not be taken as zero.
+func TestLegacyThresholdEvaluate(t *testing.T) {
+	var thermal Thermal
+	err := json.NewDecoder(strings.NewReader(legacyThermalBody)).Decode(&thermal)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	evaluation := thermal.Temperatures[0].Evaluate()
+	if evaluation.State != NormalThresholdState {
+		t.Errorf("Unexpected temperature evaluation: %s %s", evaluation.State, evaluation.Threshold)
+	}
+
+	thermal.Temperatures[0].ReadingCelsius = 43
+	evaluation = thermal.Temperatures[0].Evaluate()
+	if evaluation.State != WarningThresholdState || evaluation.Threshold != "UpperCaution" {
+		t.Errorf("Unexpected temperature evaluation: %s %s", evaluation.State, evaluation.Threshold)
+	}
+
+	evaluation = thermal.Fans[0].Evaluate()
+	if evaluation.State != UnknownThresholdState {
+		t.Errorf("Unexpected fan evaluation: %s", evaluation.State)
+	}
+
+	summary := SummarizeThresholds("/redfish/v1/Chassis/1", thermal.SensorReadings())
+	if summary.State != WarningThresholdState || summary.Counts[UnknownThresholdState] != 1 ||
+		summary.Counts[WarningThresholdState] != 1 || len(summary.Evaluations) != 2 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+// TestChassisEvaluateThresholds tests the per chassis summary.
+func TestChassisEvaluateThresholds(t *testing.T) {
+	chassis := &Chassis{
+		Entity:  common.Entity{ODataID: "/redfish/v1/Chassis/1"},
+		thermal: "/redfish/v1/Chassis/1/Thermal",
+		power:   "/redfish/v1/Chassis/1/Power",
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(legacyThermalBody))},
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(legacyPowerBody))},
+			},
+		},
+	}
+	chassis.SetClient(testClient)
+
+	summary, err := chassis.EvaluateThresholds(context.Background())
+	if err != nil {
+		t.Errorf("Error evaluating thresholds: %s", err)
+	}
+
+	if summary.Chassis != "/redfish/v1/Chassis/1" || summary.State != NormalThresholdState ||
+		summary.Counts[NormalThresholdState] != 3 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+// TestSetThresholds tests changing the thresholds of sensors.
+func TestSetThresholds(t *testing.T) {
+	var sensor Sensor
+	err := json.NewDecoder(strings.NewReader(sensorBody)).Decode(&sensor)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	sensor.SetClient(testClient)
+
+	err = sensor.SetThresholds(context.Background(), Thresholds{})
+	if err == nil {
+		t.Error("Setting no threshold should fail")
+	}
+
+	err = sensor.SetThresholds(context.Background(), Thresholds{UpperCaution: Threshold{Reading: float32Pointer(75)}})
+	if err != nil {
+		t.Errorf("Error setting sensor thresholds: %s", err)
+	}
+
+	if *sensor.Thresholds.UpperCaution.Reading != 75 || *sensor.Thresholds.UpperCritical.Reading != 90 {
+		t.Errorf("Unexpected sensor thresholds: %+v", sensor.Thresholds)
+	}
+
+	var thermal Thermal
+	err = json.NewDecoder(strings.NewReader(legacyThermalBody)).Decode(&thermal)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+	thermal.SetClient(testClient)
+
+	err = thermal.Fans[0].SetThresholds(context.Background(), Thresholds{LowerCaution: Threshold{Reading: float32Pointer(15)}})
+	if err != nil {
+		t.Errorf("Error setting fan thresholds: %s", err)
+	}
+
+	if thermal.Fans[0].LowerThresholdNonCritical != 15 ||
+		*thermal.Fans[0].SensorReading().Thresholds.LowerCaution.Reading != 15 {
+		t.Errorf("Unexpected fan threshold: %f", thermal.Fans[0].LowerThresholdNonCritical)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Unexpected calls: %v", calls)
+	}
+
+	if calls[0].URL != "/redfish/v1/Chassis/1/Sensors/CPU1Temp" ||
+		calls[0].Payload != "map[Thresholds:map[UpperCaution:map[Reading:75]]]" {
+		t.Errorf("Unexpected sensor threshold call: %v", calls[0])
+	}
+
+	if calls[1].URL != "/redfish/v1/Chassis/1/Thermal" ||
+		calls[1].Payload != "map[Fans:[map[LowerThresholdNonCritical:15]]]" {
+		t.Errorf("Unexpected fan threshold call: %v", calls[1])
+	}
+}
@@ -7,6 +7,8 @@ package redfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"reflect"
 
 	"github.com/jacobweinstock/gophish/common"
 )
@@ -131,6 +133,37 @@ const (
 	SoftwareAssistedEncryptionTypes EncryptionTypes = "SoftwareAssisted"
 )
 
+// ReadCachePolicyType is the read cache policy of a volume.
+type ReadCachePolicyType string
+
+const (
+	// ReadAheadReadCachePolicyType reads ahead of the requested data, in
+	// anticipation of sequential reads.
+	ReadAheadReadCachePolicyType ReadCachePolicyType = "ReadAhead"
+	// AdaptiveReadAheadReadCachePolicyType reads ahead when the controller
+	// detects sequential reads.
+	AdaptiveReadAheadReadCachePolicyType ReadCachePolicyType = "AdaptiveReadAhead"
+	// OffReadCachePolicyType does not cache reads.
+	OffReadCachePolicyType ReadCachePolicyType = "Off"
+)
+
+// WriteCachePolicyType is the write cache policy of a volume.
+type WriteCachePolicyType string
+
+const (
+	// WriteThroughWriteCachePolicyType completes writes once they reach the
+	// drives.
+	WriteThroughWriteCachePolicyType WriteCachePolicyType = "WriteThrough"
+	// ProtectedWriteBackWriteCachePolicyType completes writes once they
+	// reach the cache, if the cache is protected against power loss.
+	ProtectedWriteBackWriteCachePolicyType WriteCachePolicyType = "ProtectedWriteBack"
+	// UnprotectedWriteBackWriteCachePolicyType completes writes once they
+	// reach the cache, even if the cache is not protected against power loss.
+	UnprotectedWriteBackWriteCachePolicyType WriteCachePolicyType = "UnprotectedWriteBack"
+	// OffWriteCachePolicyType does not cache writes.
+	OffWriteCachePolicyType WriteCachePolicyType = "Off"
+)
+
 // VolumeType is the type of volume.
 type VolumeType string
 
@@ -187,10 +220,26 @@ type Volume struct {
 	// performing IO on this volume. For logical disks, this is the stripe size.
 	// For physical disks, this describes the physical sector size.
 	OptimumIOSizeBytes int
+	// RAIDType shall contain the RAID type of the associated Volume.
+	RAIDType RAIDType
+	// ReadCachePolicy shall contain the read cache policy the storage
+	// controller applies to the volume.
+	ReadCachePolicy ReadCachePolicyType
+	// WriteCachePolicy shall contain the write cache policy the storage
+	// controller applies to the volume.
+	WriteCachePolicy WriteCachePolicyType
+	// StripSizeBytes shall contain the number of consecutively addressed
+	// virtual disk blocks mapped to consecutively addressed blocks on a
+	// single member extent of a disk array.
+	StripSizeBytes int
 	// DrivesCount is the number of associated drives.
 	DrivesCount int
 	// drives contains references to associated drives.
 	drives []string
+	// settings is the @Redfish.Settings annotation of this resource.
+	settings common.Settings
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Volume object from the raw JSON.
@@ -202,7 +251,8 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 	}
 	var t struct {
 		temp
-		Links links
+		Settings common.Settings `json:"@Redfish.Settings"`
+		Links    links
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -211,14 +261,37 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 	}
 
 	*volume = Volume(t.temp)
+	volume.settings = t.Settings
 
 	// Extract the links to other entities for later
 	volume.DrivesCount = t.DrivesCount
 	volume.drives = t.Links.Drives.ToStrings()
 
+	// This is a read/write object, so we need to save the raw object data for later
+	volume.rawData = b
+
 	return nil
 }
 
+// Update commits updates to this object's properties to the running system.
+func (volume *Volume) Update(ctx context.Context) error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Volume)
+	original.UnmarshalJSON(volume.rawData)
+
+	readWriteFields := []string{
+		"ReadCachePolicy",
+		"WriteCachePolicy",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(volume).Elem()
+
+	return volume.Entity.Update(ctx, originalElement, currentElement, readWriteFields)
+}
+
 // GetVolume will get a Volume instance from the service.
 func GetVolume(ctx context.Context, c common.Client, uri string) (*Volume, error) {
 	resp, err := c.Get(ctx, uri)
@@ -260,6 +333,17 @@ func ListReferencedVolumes(ctx context.Context, c common.Client, link string) ([
 	return result, nil
 }
 
+// Delete deletes the volume. It returns the task deleting the volume if the
+// controller deletes it asynchronously, or nil once it is deleted.
+func (volume *Volume) Delete(ctx context.Context) (*Task, error) {
+	resp, err := volume.Client.Delete(ctx, volume.ODataID)
+	if err != nil {
+		return nil, err
+	}
+
+	return TaskFromResponse(ctx, volume.Client, resp)
+}
+
 // Drives references the Drives that this volume is associated with.
 func (volume *Volume) Drives(ctx context.Context) ([]*Drive, error) {
 	var result []*Drive
@@ -297,3 +381,124 @@ func AllowedVolumesUpdateApplyTimes(ctx context.Context, c common.Client, link s
 	}
 	return applyTimes, nil
 }
+
+// PendingSettings gives access to the settings that will be applied to this
+// volume, following the @Redfish.Settings pattern.
+func (volume *Volume) PendingSettings() *common.PendingSettings {
+	return common.NewPendingSettings(volume.Client, volume.ODataID, volume.settings, volume.rawData)
+}
+
+// minimumRAIDDrives is the minimum number of drives of the common RAID types.
+var minimumRAIDDrives = map[RAIDType]int{
+	RAID0RAIDType:  1,
+	RAID1RAIDType:  2,
+	RAID5RAIDType:  3,
+	RAID6RAIDType:  4,
+	RAID10RAIDType: 4,
+	RAID50RAIDType: 6,
+	RAID60RAIDType: 8,
+}
+
+// VolumeSpec describes a volume to create with Storage.CreateVolume.
+type VolumeSpec struct {
+	// Name is the name of the volume.
+	Name string
+	// RAIDType is the RAID type of the volume.
+	RAIDType RAIDType
+	// VolumeType is the type of the volume, for services that do not
+	// support RAIDType.
+	VolumeType VolumeType
+	// CapacityBytes is the size of the volume, the controller uses all the
+	// space of the drives if zero.
+	CapacityBytes int64
+	// Drives are the URIs of the drives to create the volume on.
+	Drives []string
+	// StripSizeBytes is the stripe size of the volume, the controller
+	// default if zero.
+	StripSizeBytes int64
+	// ApplyTime is when the volume is created, such as OnReset for
+	// controllers only configured during boot. The service default if not
+	// set.
+	ApplyTime common.OperationApplyTime
+}
+
+// validate checks the spec before it is sent to the service.
+func (spec *VolumeSpec) validate() error {
+	if spec.CapacityBytes < 0 {
+		return fmt.Errorf("volume capacity should not be negative")
+	}
+	if spec.StripSizeBytes < 0 {
+		return fmt.Errorf("volume stripe size should not be negative")
+	}
+
+	if minimum, ok := minimumRAIDDrives[spec.RAIDType]; ok && len(spec.Drives) > 0 && len(spec.Drives) < minimum {
+		return fmt.Errorf("%s volumes need at least %d drives, %d provided", spec.RAIDType, minimum, len(spec.Drives))
+	}
+	if spec.RAIDType == RAID10RAIDType && len(spec.Drives)%2 != 0 {
+		return fmt.Errorf("RAID10 volumes need an even number of drives, %d provided", len(spec.Drives))
+	}
+
+	return nil
+}
+
+// createVolume validates the spec and POSTs it to a volume collection. It
+// returns the URI of the new volume, or the task creating it.
+func createVolume(ctx context.Context, c common.Client, volumes string, spec VolumeSpec) (string, *Task, error) {
+	if volumes == "" {
+		return "", nil, fmt.Errorf("volume creation is not supported by this service")
+	}
+
+	err := spec.validate()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if spec.ApplyTime != "" {
+		applyTimes, err := AllowedVolumesUpdateApplyTimes(ctx, c, volumes)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(applyTimes) > 0 {
+			supported := false
+			for _, applyTime := range applyTimes {
+				if applyTime == spec.ApplyTime {
+					supported = true
+					break
+				}
+			}
+			if !supported {
+				return "", nil, fmt.Errorf("apply time '%s' is not supported by this service", spec.ApplyTime)
+			}
+		}
+	}
+
+	type links struct {
+		Drives []common.ODataLink
+	}
+	t := struct {
+		Name           string                    `json:",omitempty"`
+		RAIDType       RAIDType                  `json:",omitempty"`
+		VolumeType     VolumeType                `json:",omitempty"`
+		CapacityBytes  int64                     `json:",omitempty"`
+		StripSizeBytes int64                     `json:",omitempty"`
+		Links          *links                    `json:",omitempty"`
+		ApplyTime      common.OperationApplyTime `json:"@Redfish.OperationApplyTime,omitempty"`
+	}{
+		Name:           spec.Name,
+		RAIDType:       spec.RAIDType,
+		VolumeType:     spec.VolumeType,
+		CapacityBytes:  spec.CapacityBytes,
+		StripSizeBytes: spec.StripSizeBytes,
+		ApplyTime:      spec.ApplyTime,
+	}
+	if len(spec.Drives) > 0 {
+		t.Links = &links{Drives: common.ToODataLinks(spec.Drives)}
+	}
+
+	resp, err := c.Post(ctx, volumes, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return LocationOrTask(ctx, c, resp)
+}
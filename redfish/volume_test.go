@@ -0,0 +1,114 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
+)
+
+var volumeBody = `{
+		"@odata.type": "#Volume.v1_5_0.Volume",
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1/Volumes/1",
+		"Id": "1",
+		"Name": "os",
+		"CapacityBytes": 480000000000,
+		"RAIDType": "RAID1",
+		"StripSizeBytes": 65536,
+		"ReadCachePolicy": "ReadAhead",
+		"WriteCachePolicy": "WriteThrough",
+		"Links": {
+			"Drives": [
+				{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/0"},
+				{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/1"}
+			]
+		}
+	}`
+
+// TestVolume tests the parsing of Volume objects.
+func TestVolume(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.RAIDType != RAID1RAIDType {
+		t.Errorf("Invalid RAID type: %s", result.RAIDType)
+	}
+
+	if result.StripSizeBytes != 65536 {
+		t.Errorf("Invalid stripe size: %d", result.StripSizeBytes)
+	}
+
+	if len(result.drives) != 2 {
+		t.Errorf("Invalid drives: %v", result.drives)
+	}
+}
+
+// TestVolumeDelete tests the Delete call.
+func TestVolumeDelete(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodDelete: {
+				&http.Response{StatusCode: http.StatusNoContent, Body: ioutil.NopCloser(bytes.NewBufferString(""))},
+				acceptedResponse(`{"@odata.id": "/redfish/v1/TaskService/Tasks/4", "Id": "4"}`, ""),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	task, err := result.Delete(context.Background())
+	if err != nil || task != nil {
+		t.Errorf("Expected a synchronous delete, got %v: %v", task, err)
+	}
+
+	task, err = result.Delete(context.Background())
+	if err != nil || task == nil || task.ID != "4" {
+		t.Errorf("Expected a delete task, got %v: %v", task, err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Systems/1/Storage/1/Volumes/1" {
+		t.Errorf("Invalid Delete target: %s", calls[0].URL)
+	}
+}
+
+// TestVolumeUpdate tests the Update call.
+func TestVolumeUpdate(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.WriteCachePolicy = ProtectedWriteBackWriteCachePolicyType
+	err = result.Update(context.Background())
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].Payload != "map[WriteCachePolicy:ProtectedWriteBack]" {
+		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
+	}
+}
@@ -75,6 +75,9 @@ type Service struct {
 	// AccountService shall only contain a reference to a resource that complies
 	// to the AccountService schema.
 	accountService string
+	// AggregationService shall contain a link to a resource of type
+	// AggregationService.
+	aggregationService string
 	// CertificateService shall be a link to the CertificateService.
 	certificateService string
 	// Chassis shall only contain a reference to a collection of resources that
@@ -162,6 +165,7 @@ func (serviceroot *Service) UnmarshalJSON(b []byte) error {
 		StorageServices    common.Link
 		StorageSystems     common.Link
 		AccountService     common.Link
+		AggregationService common.Link
 		EventService       common.Link
 		Registries         common.Link
 		Systems            common.Link
@@ -193,6 +197,7 @@ func (serviceroot *Service) UnmarshalJSON(b []byte) error {
 	serviceroot.storageServices = string(t.StorageServices)
 	serviceroot.storageSystems = string(t.StorageSystems)
 	serviceroot.accountService = string(t.AccountService)
+	serviceroot.aggregationService = string(t.AggregationService)
 	serviceroot.eventService = string(t.EventService)
 	serviceroot.registries = string(t.Registries)
 	serviceroot.systems = string(t.Systems)
@@ -271,6 +276,14 @@ func (serviceroot *Service) AccountService(ctx context.Context) (*redfish.Accoun
 	return redfish.GetAccountService(ctx, serviceroot.Client, serviceroot.accountService)
 }
 
+// AggregationService gets the Redfish AggregationService
+func (serviceroot *Service) AggregationService(ctx context.Context) (*redfish.AggregationService, error) {
+	if serviceroot.aggregationService == "" {
+		return nil, nil
+	}
+	return redfish.GetAggregationService(ctx, serviceroot.Client, serviceroot.aggregationService)
+}
+
 // EventService gets the Redfish EventService
 func (serviceroot *Service) EventService(ctx context.Context) (*redfish.EventService, error) {
 	return redfish.GetEventService(ctx, serviceroot.Client, serviceroot.eventService)
@@ -281,6 +294,23 @@ func (serviceroot *Service) Systems(ctx context.Context) ([]*redfish.ComputerSys
 	return redfish.ListReferencedComputerSystems(ctx, serviceroot.Client, serviceroot.systems)
 }
 
+// Registries gets the registry files available from the service.
+func (serviceroot *Service) Registries(ctx context.Context) ([]*redfish.MessageRegistryFile, error) {
+	return redfish.ListReferencedMessageRegistryFiles(ctx, serviceroot.Client, serviceroot.registries)
+}
+
+// AttributeRegistry finds and downloads the attribute registry with the
+// provided name, such as the AttributeRegistry property of a Bios.
+func (serviceroot *Service) AttributeRegistry(ctx context.Context, name string) (*redfish.AttributeRegistry, error) {
+	return redfish.FindAttributeRegistry(ctx, serviceroot.Client, serviceroot.registries, name)
+}
+
+// MessageRegistries downloads the message registries of the service in the
+// provided language, such as "en", to resolve message IDs to text.
+func (serviceroot *Service) MessageRegistries(ctx context.Context, language string) (redfish.MessageRegistries, error) {
+	return redfish.ListReferencedMessageRegistries(ctx, serviceroot.Client, serviceroot.registries, language)
+}
+
 // CompositionService gets the composition service instance
 func (serviceroot *Service) CompositionService(ctx context.Context) (*redfish.CompositionService, error) {
 	return redfish.GetCompositionService(ctx, serviceroot.Client, serviceroot.compositionService)
@@ -21,6 +21,9 @@ var serviceRootBody = strings.NewReader(
 		"AccountService": {
 			"@odata.id": "/redfish/v1/Accounts"
 		},
+		"AggregationService": {
+			"@odata.id": "/redfish/v1/AggregationService"
+		},
 		"CertificateService": {
 			"@odata.id": "/redfish/v1/Certificates"
 		},
@@ -129,6 +132,10 @@ func TestServiceRoot(t *testing.T) {
 		t.Errorf("Invalid CompositionService link: %s", result.compositionService)
 	}
 
+	if result.aggregationService != "/redfish/v1/AggregationService" {
+		t.Errorf("Invalid AggregationService link: %s", result.aggregationService)
+	}
+
 	if result.eventService != "/redfish/v1/Events" {
 		t.Errorf("Invalid EventService link: %s", result.eventService)
 	}
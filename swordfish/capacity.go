@@ -7,6 +7,7 @@ package swordfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/jacobweinstock/gophish/common"
 	"github.com/jacobweinstock/gophish/redfish"
@@ -46,6 +47,147 @@ type CapacityInfo struct {
 	ProvisionedBytes int64
 }
 
+// CapacitySummary is the total capacity of a data store across user data,
+// metadata and snapshots.
+type CapacitySummary struct {
+	// AllocatedBytes is the number of bytes allocated by the storage system.
+	AllocatedBytes int64
+	// ConsumedBytes is the number of logical bytes consumed.
+	ConsumedBytes int64
+	// GuaranteedBytes is the number of bytes the storage system guarantees
+	// can be allocated.
+	GuaranteedBytes int64
+	// ProvisionedBytes is the maximum number of bytes that can be allocated.
+	ProvisionedBytes int64
+	// RemainingBytes is the number of allocated bytes not consumed yet.
+	RemainingBytes int64
+	// IsThinProvisioned is whether the capacity may be over allocated.
+	IsThinProvisioned bool
+}
+
+// Summary totals the capacity of the data, metadata and snapshots.
+func (capacity *Capacity) Summary() CapacitySummary {
+	summary := CapacitySummary{IsThinProvisioned: capacity.IsThinProvisioned}
+	for _, info := range []CapacityInfo{capacity.Data, capacity.Metadata, capacity.Snapshot} {
+		summary.AllocatedBytes += info.AllocatedBytes
+		summary.ConsumedBytes += info.ConsumedBytes
+		summary.GuaranteedBytes += info.GuaranteedBytes
+		summary.ProvisionedBytes += info.ProvisionedBytes
+	}
+
+	if summary.AllocatedBytes > summary.ConsumedBytes {
+		summary.RemainingBytes = summary.AllocatedBytes - summary.ConsumedBytes
+	}
+
+	return summary
+}
+
+// CapacitySourceSpec describes capacity provided to a storage pool by drives
+// or by other storage pools.
+type CapacitySourceSpec struct {
+	// CapacityBytes is the number of bytes to allocate from the sources, all
+	// of their capacity if zero.
+	CapacityBytes int64
+	// Drives are the URIs of the drives providing the capacity.
+	Drives []string
+	// Pools are the URIs of the storage pools providing the capacity.
+	Pools []string
+}
+
+// validate checks the spec before it is sent to the service.
+func (spec *CapacitySourceSpec) validate() error {
+	if spec.CapacityBytes < 0 {
+		return fmt.Errorf("capacity should not be negative")
+	}
+	if len(spec.Drives) == 0 && len(spec.Pools) == 0 {
+		return fmt.Errorf("capacity should be provided by drives or storage pools")
+	}
+	if len(spec.Drives) > 0 && len(spec.Pools) > 0 {
+		return fmt.Errorf("capacity should be provided by either drives or storage pools")
+	}
+	return nil
+}
+
+// checkPools checks that the storage pools of the spec have enough capacity
+// remaining, when they all report it.
+func (spec *CapacitySourceSpec) checkPools(ctx context.Context, c common.Client) error {
+	if spec.CapacityBytes == 0 || len(spec.Pools) == 0 {
+		return nil
+	}
+
+	pools, err := getStoragePools(ctx, c, spec.Pools)
+	if err != nil {
+		return err
+	}
+	return checkRemaining(pools, spec.CapacityBytes)
+}
+
+// getStoragePools gets the storage pools with the given URIs.
+func getStoragePools(ctx context.Context, c common.Client, uris []string) ([]*StoragePool, error) {
+	result := make([]*StoragePool, 0, len(uris))
+	for _, uri := range uris {
+		pool, err := GetStoragePool(ctx, c, uri)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pool)
+	}
+	return result, nil
+}
+
+// checkRemaining checks that the storage pools together have capacityBytes
+// remaining, when they all report it. The capacity is taken from the pools as
+// a whole, so it does not have to fit in any one of them.
+func checkRemaining(pools []*StoragePool, capacityBytes int64) error {
+	if len(pools) == 0 {
+		return nil
+	}
+
+	var remaining int64
+	for _, pool := range pools {
+		poolRemaining, ok := pool.remainingBytes()
+		if !ok {
+			return nil
+		}
+		remaining += poolRemaining
+	}
+
+	if capacityBytes > remaining {
+		return fmt.Errorf("storage pools have %d bytes remaining, %d requested", remaining, capacityBytes)
+	}
+	return nil
+}
+
+// capacitySourcePayload is a capacity source as sent to the service.
+type capacitySourcePayload struct {
+	ProvidedCapacity *capacityPayload   `json:",omitempty"`
+	ProvidingDrives  []common.ODataLink `json:",omitempty"`
+	ProvidingPools   []common.ODataLink `json:",omitempty"`
+}
+
+// capacityPayload is the capacity allocated to a resource.
+type capacityPayload struct {
+	Data struct {
+		AllocatedBytes int64
+	}
+}
+
+// payload returns the capacity source to send to the service.
+func (spec *CapacitySourceSpec) payload() capacitySourcePayload {
+	var t capacitySourcePayload
+	if spec.CapacityBytes > 0 {
+		t.ProvidedCapacity = &capacityPayload{}
+		t.ProvidedCapacity.Data.AllocatedBytes = spec.CapacityBytes
+	}
+	if len(spec.Drives) > 0 {
+		t.ProvidingDrives = common.ToODataLinks(spec.Drives)
+	}
+	if len(spec.Pools) > 0 {
+		t.ProvidingPools = common.ToODataLinks(spec.Pools)
+	}
+	return t
+}
+
 // CapacitySource is used to represent the source and type of storage
 // capacity. At most one of the ProvidingDrives, ProvidingVolumes,
 // ProvidingMemoryChunks, ProvidingMemory or ProvidingPools properties
@@ -77,3 +77,32 @@ func TestCapacity(t *testing.T) {
 		t.Errorf("Invalid providing pools link: %s", result.providingPools)
 	}
 }
+
+// TestCapacitySummary tests totaling the capacity of a data store.
+func TestCapacitySummary(t *testing.T) {
+	capacity := Capacity{
+		Data:              CapacityInfo{AllocatedBytes: 1000, ConsumedBytes: 600, GuaranteedBytes: 800, ProvisionedBytes: 2000},
+		Metadata:          CapacityInfo{AllocatedBytes: 100, ConsumedBytes: 10, GuaranteedBytes: 100, ProvisionedBytes: 100},
+		Snapshot:          CapacityInfo{AllocatedBytes: 200, ConsumedBytes: 40},
+		IsThinProvisioned: true,
+	}
+
+	summary := capacity.Summary()
+	expected := CapacitySummary{
+		AllocatedBytes:    1300,
+		ConsumedBytes:     650,
+		GuaranteedBytes:   900,
+		ProvisionedBytes:  2100,
+		RemainingBytes:    650,
+		IsThinProvisioned: true,
+	}
+	if summary != expected {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+
+	// Over consumed thin provisioned capacity has nothing remaining.
+	capacity = Capacity{Data: CapacityInfo{AllocatedBytes: 100, ConsumedBytes: 150}}
+	if summary := capacity.Summary(); summary.RemainingBytes != 0 {
+		t.Errorf("Unexpected remaining capacity: %d", summary.RemainingBytes)
+	}
+}
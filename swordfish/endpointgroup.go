@@ -7,6 +7,7 @@ package swordfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/jacobweinstock/gophish/common"
@@ -53,8 +54,8 @@ type EndpointGroup struct {
 	AccessState AccessState
 	// Description provides a description of this resource.
 	Description string
-	// endpoints shall reference an Endpoint resource.
-	endpoints string
+	// endpoints shall reference the Endpoint resources of the group.
+	endpoints []string
 	// EndpointsCount is the number of Endpoints
 	EndpointsCount int
 	// GroupType contains only endpoints of a given type
@@ -82,10 +83,15 @@ type EndpointGroup struct {
 // UnmarshalJSON unmarshals a EndpointGroup object from the raw JSON.
 func (endpointgroup *EndpointGroup) UnmarshalJSON(b []byte) error {
 	type temp EndpointGroup
+	type links struct {
+		Endpoints      common.Links
+		EndpointsCount int `json:"Endpoints@odata.count"`
+	}
 	var t struct {
 		temp
-		Endpoints      common.Link
+		Endpoints      common.Links
 		EndpointsCount int `json:"Endpoints@odata.count"`
+		Links          links
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -95,9 +101,17 @@ func (endpointgroup *EndpointGroup) UnmarshalJSON(b []byte) error {
 
 	*endpointgroup = EndpointGroup(t.temp)
 
-	// Extract the links to other entities for later
-	endpointgroup.endpoints = string(t.Endpoints)
+	// Extract the links to other entities for later. Newer services list the
+	// endpoints under Links, the top level property is deprecated.
+	endpointgroup.endpoints = t.Endpoints.ToStrings()
 	endpointgroup.EndpointsCount = t.EndpointsCount
+	if len(t.Endpoints) == 0 {
+		endpointgroup.endpoints = t.Links.Endpoints.ToStrings()
+		endpointgroup.EndpointsCount = t.Links.EndpointsCount
+	}
+	if endpointgroup.EndpointsCount == 0 {
+		endpointgroup.EndpointsCount = len(endpointgroup.endpoints)
+	}
 
 	// This is a read/write object, so we need to save the raw object data for later
 	endpointgroup.rawData = b
@@ -171,5 +185,109 @@ func ListReferencedEndpointGroups(ctx context.Context, c common.Client, link str
 
 // Endpoints gets the group's endpoints.
 func (endpointgroup *EndpointGroup) Endpoints(ctx context.Context) ([]*redfish.Endpoint, error) {
-	return redfish.ListReferencedEndpoints(ctx, endpointgroup.Client, endpointgroup.endpoints)
+	var result []*redfish.Endpoint
+	for _, endpointLink := range endpointgroup.endpoints {
+		endpoint, err := redfish.GetEndpoint(ctx, endpointgroup.Client, endpointLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, endpoint)
+	}
+
+	return result, nil
+}
+
+// EndpointGroupSpec describes an endpoint group to create with
+// StorageService.CreateEndpointGroup.
+type EndpointGroupSpec struct {
+	// Name is the name of the endpoint group.
+	Name string
+	// Description is the description of the endpoint group.
+	Description string
+	// GroupType is whether the group holds client (initiator) or server
+	// (target) endpoints.
+	GroupType GroupType
+	// Endpoints are the URIs of the endpoints of the group.
+	Endpoints []string
+	// AccessState is the access state of the resources through the
+	// endpoints, the service default if empty.
+	AccessState AccessState
+	// Preferred is whether access through the endpoints of the group is
+	// preferred over other endpoints.
+	Preferred bool
+	// TargetEndpointGroupIdentifier is the SCSI target port group of a server
+	// group, the service assigns one if zero.
+	TargetEndpointGroupIdentifier int
+}
+
+// createEndpointGroup validates the spec and POSTs it to an endpoint group
+// collection. It returns the URI of the new group, or the task creating it.
+func createEndpointGroup(ctx context.Context, c common.Client, groups string, spec EndpointGroupSpec) (string, *redfish.Task, error) {
+	if groups == "" {
+		return "", nil, fmt.Errorf("endpoint group creation is not supported by this service")
+	}
+
+	switch spec.GroupType {
+	case ClientGroupType, ServerGroupType:
+	default:
+		return "", nil, fmt.Errorf("invalid endpoint group type '%s'", spec.GroupType)
+	}
+	if spec.TargetEndpointGroupIdentifier != 0 && spec.GroupType != ServerGroupType {
+		return "", nil, fmt.Errorf("only server endpoint groups have a target endpoint group identifier")
+	}
+
+	t := struct {
+		Name                          string `json:",omitempty"`
+		Description                   string `json:",omitempty"`
+		GroupType                     GroupType
+		Endpoints                     []common.ODataLink `json:",omitempty"`
+		AccessState                   AccessState        `json:",omitempty"`
+		Preferred                     bool               `json:",omitempty"`
+		TargetEndpointGroupIdentifier int                `json:",omitempty"`
+	}{
+		Name:                          spec.Name,
+		Description:                   spec.Description,
+		GroupType:                     spec.GroupType,
+		AccessState:                   spec.AccessState,
+		Preferred:                     spec.Preferred,
+		TargetEndpointGroupIdentifier: spec.TargetEndpointGroupIdentifier,
+	}
+	if len(spec.Endpoints) > 0 {
+		t.Endpoints = common.ToODataLinks(spec.Endpoints)
+	}
+
+	resp, err := c.Post(ctx, groups, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return redfish.LocationOrTask(ctx, c, resp)
+}
+
+// SetEndpoints replaces the endpoints of the group.
+func (endpointgroup *EndpointGroup) SetEndpoints(ctx context.Context, endpoints []string) error {
+	t := struct {
+		Endpoints []common.ODataLink
+	}{Endpoints: common.ToODataLinks(endpoints)}
+
+	_, err := endpointgroup.Client.Patch(ctx, endpointgroup.ODataID, t)
+	if err != nil {
+		return err
+	}
+
+	endpointgroup.endpoints = append([]string(nil), endpoints...)
+	endpointgroup.EndpointsCount = len(endpoints)
+	return nil
+}
+
+// Delete deletes the endpoint group. The endpoints of the group are not
+// deleted. It returns the task deleting the group if the service deletes it
+// asynchronously, or nil once it is deleted.
+func (endpointgroup *EndpointGroup) Delete(ctx context.Context) (*redfish.Task, error) {
+	resp, err := endpointgroup.Client.Delete(ctx, endpointgroup.ODataID)
+	if err != nil {
+		return nil, err
+	}
+
+	return redfish.TaskFromResponse(ctx, endpointgroup.Client, resp)
 }
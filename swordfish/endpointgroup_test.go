@@ -5,8 +5,11 @@
 package swordfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -21,9 +24,11 @@ var endpointGroupBody = `{
 		"Name": "EndpointGroupOne",
 		"Description": "EndpointGroup One",
 		"AccessState": "Optimized",
-		"Endpoints": {
-			"@odata.id": "/redfish/v1/Endpoints"
-		},
+		"Endpoints": [
+			{"@odata.id": "/redfish/v1/Fabrics/1/Endpoints/T1"},
+			{"@odata.id": "/redfish/v1/Fabrics/1/Endpoints/T2"}
+		],
+		"Endpoints@odata.count": 2,
 		"GroupType": "Server",
 		"Preferred": true,
 		"TargetEndpointGroupIdentifier": 5
@@ -50,8 +55,12 @@ func TestEndpointGroup(t *testing.T) {
 		t.Errorf("Access state is %s", result.AccessState)
 	}
 
-	if result.endpoints != "/redfish/v1/Endpoints" {
-		t.Errorf("Invalid endpoints: %s", result.endpoints)
+	if len(result.endpoints) != 2 || result.endpoints[1] != "/redfish/v1/Fabrics/1/Endpoints/T2" {
+		t.Errorf("Invalid endpoints: %v", result.endpoints)
+	}
+
+	if result.EndpointsCount != 2 {
+		t.Errorf("Invalid endpoints count: %d", result.EndpointsCount)
 	}
 
 	if result.GroupType != ServerGroupType {
@@ -67,6 +76,66 @@ func TestEndpointGroup(t *testing.T) {
 	}
 }
 
+// TestEndpointGroupLinks tests the parsing of the endpoints listed under
+// Links.
+func TestEndpointGroupLinks(t *testing.T) {
+	var result EndpointGroup
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/EndpointGroup",
+		"Id": "EndpointGroup-1",
+		"GroupType": "Client",
+		"Links": {
+			"Endpoints": [{"@odata.id": "/redfish/v1/Fabrics/1/Endpoints/I1"}]
+		}
+	}`)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if len(result.endpoints) != 1 || result.endpoints[0] != "/redfish/v1/Fabrics/1/Endpoints/I1" {
+		t.Errorf("Invalid endpoints: %v", result.endpoints)
+	}
+
+	if result.EndpointsCount != 1 {
+		t.Errorf("Invalid endpoints count: %d", result.EndpointsCount)
+	}
+}
+
+// TestEndpointGroupEndpoints tests the Endpoints call.
+func TestEndpointGroupEndpoints(t *testing.T) {
+	var result EndpointGroup
+	err := json.NewDecoder(strings.NewReader(endpointGroupBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(
+					`{"@odata.id": "/redfish/v1/Fabrics/1/Endpoints/T1", "Id": "T1"}`))},
+				&http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(
+					`{"@odata.id": "/redfish/v1/Fabrics/1/Endpoints/T2", "Id": "T2"}`))},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	endpoints, err := result.Endpoints(context.Background())
+	if err != nil {
+		t.Errorf("Error making Endpoints call: %s", err)
+	}
+
+	if len(endpoints) != 2 || endpoints[1].ID != "T2" {
+		t.Errorf("Unexpected endpoints: %v", endpoints)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[0].URL != "/redfish/v1/Fabrics/1/Endpoints/T1" {
+		t.Errorf("Unexpected Endpoints calls: %v", calls)
+	}
+}
+
 // TestEndpointGroupUpdate tests the Update call.
 func TestEndpointGroupUpdate(t *testing.T) {
 	var result EndpointGroup
@@ -107,3 +176,59 @@ func TestEndpointGroupUpdate(t *testing.T) {
 		t.Errorf("Unexpected TargetEndpointGroupIdentifier update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestEndpointGroupSetEndpoints tests the SetEndpoints call.
+func TestEndpointGroupSetEndpoints(t *testing.T) {
+	var result EndpointGroup
+	err := json.NewDecoder(strings.NewReader(endpointGroupBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetEndpoints(context.Background(), []string{"/redfish/v1/Fabrics/1/Endpoints/I1"})
+	if err != nil {
+		t.Errorf("Error making SetEndpoints call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].Action != http.MethodPatch ||
+		!strings.Contains(calls[0].Payload, "Endpoints:[map[@odata.id:/redfish/v1/Fabrics/1/Endpoints/I1]]") {
+		t.Errorf("Unexpected SetEndpoints call: %v", calls[0])
+	}
+	if result.EndpointsCount != 1 || len(result.endpoints) != 1 ||
+		result.endpoints[0] != "/redfish/v1/Fabrics/1/Endpoints/I1" {
+		t.Errorf("Endpoints not updated: %d %v", result.EndpointsCount, result.endpoints)
+	}
+}
+
+// TestEndpointGroupDelete tests the Delete call.
+func TestEndpointGroupDelete(t *testing.T) {
+	var result EndpointGroup
+	err := json.NewDecoder(strings.NewReader(endpointGroupBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodDelete: {&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	_, err = result.Delete(context.Background())
+	if err != nil {
+		t.Errorf("Error making Delete call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].Action != http.MethodDelete || calls[0].URL != "/redfish/v1/EndpointGroup" {
+		t.Errorf("Unexpected Delete call: %v", calls[0])
+	}
+}
@@ -7,7 +7,9 @@ package swordfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/jacobweinstock/gophish/common"
 	"github.com/jacobweinstock/gophish/redfish"
@@ -219,3 +221,112 @@ func (fileshare *FileShare) FileSystem(ctx context.Context) (*FileSystem, error)
 func (fileshare *FileShare) EthernetInterfaces(ctx context.Context) ([]*redfish.EthernetInterface, error) {
 	return redfish.ListReferencedEthernetInterfaces(ctx, fileshare.Client, fileshare.ethernetInterfaces)
 }
+
+// Delete deletes the file share. The files of the share are not deleted. It
+// returns the task deleting the share if the service deletes it
+// asynchronously, or nil once it is deleted.
+func (fileshare *FileShare) Delete(ctx context.Context) (*redfish.Task, error) {
+	resp, err := fileshare.Client.Delete(ctx, fileshare.ODataID)
+	if err != nil {
+		return nil, err
+	}
+
+	return redfish.TaskFromResponse(ctx, fileshare.Client, resp)
+}
+
+// FileShareSpec describes a file share to create with FileSystem.CreateShare.
+type FileShareSpec struct {
+	// Name is the name of the file share.
+	Name string
+	// FileSharePath is the path of the exported directory, relative to the
+	// root of the file system.
+	FileSharePath string
+	// FileSharingProtocols are the NFS or SMB protocols of the share.
+	FileSharingProtocols []FileProtocol
+	// DefaultAccessCapabilities are the default access capabilities of the
+	// share, such as Read and Write.
+	DefaultAccessCapabilities []StorageAccessCapability
+	// RootAccess is whether root access is allowed.
+	RootAccess bool
+	// ExecuteSupport is whether execute access is supported.
+	ExecuteSupport bool
+	// CASupported is whether continuous availability is supported, only for
+	// SMB shares.
+	CASupported bool
+	// WritePolicy is how writes are replicated to the shared source, the
+	// service default if empty.
+	WritePolicy ReplicaUpdateMode
+	// EthernetInterfaces is the URI of the collection of Ethernet interfaces
+	// providing access to the share.
+	EthernetInterfaces string
+	// FileShareQuotaType is whether the quota is enforced, no quota if empty.
+	FileShareQuotaType QuotaType
+	// FileShareTotalQuotaBytes is the maximum number of bytes the share may
+	// consume.
+	FileShareTotalQuotaBytes int64
+}
+
+// validate checks the spec before it is sent to the service.
+func (spec *FileShareSpec) validate() error {
+	if spec.FileSharePath == "" {
+		return fmt.Errorf("file share path is required")
+	}
+	if len(spec.FileSharingProtocols) == 0 {
+		return fmt.Errorf("file share should have a file sharing protocol")
+	}
+
+	smb := false
+	for _, protocol := range spec.FileSharingProtocols {
+		smb = smb || strings.HasPrefix(string(protocol), "SMB")
+	}
+	if spec.CASupported && !smb {
+		return fmt.Errorf("continuous availability is only supported by SMB shares")
+	}
+
+	switch spec.FileShareQuotaType {
+	case "":
+		if spec.FileShareTotalQuotaBytes != 0 {
+			return fmt.Errorf("file share quota requires a quota type")
+		}
+	case SoftQuotaType, HardQuotaType:
+		if spec.FileShareTotalQuotaBytes <= 0 {
+			return fmt.Errorf("file share quota should be positive")
+		}
+	default:
+		return fmt.Errorf("unknown quota type '%s'", spec.FileShareQuotaType)
+	}
+
+	return nil
+}
+
+// payload returns the share to send to the service.
+func (spec *FileShareSpec) payload() interface{} {
+	t := struct {
+		Name                      string `json:",omitempty"`
+		FileSharePath             string
+		FileSharingProtocols      []FileProtocol
+		DefaultAccessCapabilities []StorageAccessCapability `json:",omitempty"`
+		RootAccess                bool
+		ExecuteSupport            bool
+		CASupported               bool              `json:",omitempty"`
+		WritePolicy               ReplicaUpdateMode `json:",omitempty"`
+		EthernetInterfaces        *common.ODataLink `json:",omitempty"`
+		FileShareQuotaType        QuotaType         `json:",omitempty"`
+		FileShareTotalQuotaBytes  int64             `json:",omitempty"`
+	}{
+		Name:                      spec.Name,
+		FileSharePath:             spec.FileSharePath,
+		FileSharingProtocols:      spec.FileSharingProtocols,
+		DefaultAccessCapabilities: spec.DefaultAccessCapabilities,
+		RootAccess:                spec.RootAccess,
+		ExecuteSupport:            spec.ExecuteSupport,
+		CASupported:               spec.CASupported,
+		WritePolicy:               spec.WritePolicy,
+		FileShareQuotaType:        spec.FileShareQuotaType,
+		FileShareTotalQuotaBytes:  spec.FileShareTotalQuotaBytes,
+	}
+	if spec.EthernetInterfaces != "" {
+		t.EthernetInterfaces = &common.ODataLink{ODataID: spec.EthernetInterfaces}
+	}
+	return t
+}
@@ -5,8 +5,11 @@
 package swordfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -164,3 +167,32 @@ func TestFileShareUpdate(t *testing.T) {
 		t.Errorf("Unexpected FileShareTotalQuotaBytes update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestFileShareDelete tests the Delete call.
+func TestFileShareDelete(t *testing.T) {
+	var result FileShare
+	err := json.NewDecoder(strings.NewReader(fileShareBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodDelete: {&http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	_, err = result.Delete(context.Background())
+	if err != nil {
+		t.Errorf("Error making Delete call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].Action != http.MethodDelete || calls[0].URL != "/redfish/v1/FileShare" {
+		t.Errorf("Unexpected Delete call: %v", calls[0])
+	}
+}
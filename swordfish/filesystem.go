@@ -7,9 +7,11 @@ package swordfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/jacobweinstock/gophish/common"
+	"github.com/jacobweinstock/gophish/redfish"
 )
 
 // CharacterCodeSet shall indicate the character code standards supported by the
@@ -325,3 +327,104 @@ func (filesystem *FileSystem) SpareResourceSets(ctx context.Context) ([]*SpareRe
 
 	return result, nil
 }
+
+// FileSystemSpec describes a file system to create with
+// StorageService.CreateFileSystem.
+type FileSystemSpec struct {
+	// Name is the name of the file system.
+	Name string
+	// CapacityBytes is the size of the file system.
+	CapacityBytes int64
+	// ClassOfService is the URI of the class of service the file system
+	// conforms to, the service default if empty.
+	ClassOfService string
+	// CharacterCodeSet are the character sets of the file names, the service
+	// default if empty.
+	CharacterCodeSet []CharacterCodeSet
+	// AccessCapabilities are the access capabilities of the file system, such
+	// as Read and Write.
+	AccessCapabilities []StorageAccessCapability
+	// CapacitySources are the URIs of the storage pools providing the
+	// capacity of the file system.
+	CapacitySources []string
+}
+
+// source returns the capacity source of the file system.
+func (spec *FileSystemSpec) source() CapacitySourceSpec {
+	return CapacitySourceSpec{
+		CapacityBytes: spec.CapacityBytes,
+		Pools:         spec.CapacitySources,
+	}
+}
+
+// createFileSystem POSTs a validated spec to a file system collection. It
+// returns the URI of the new file system, or the task creating it.
+func createFileSystem(ctx context.Context, c common.Client, fileSystems string, spec FileSystemSpec) (string, *redfish.Task, error) {
+	if fileSystems == "" {
+		return "", nil, fmt.Errorf("file system creation is not supported by this service")
+	}
+
+	type links struct {
+		ClassOfService common.ODataLink
+	}
+	t := struct {
+		Name               string `json:",omitempty"`
+		Capacity           capacityPayload
+		CharacterCodeSet   []CharacterCodeSet        `json:",omitempty"`
+		AccessCapabilities []StorageAccessCapability `json:",omitempty"`
+		CapacitySources    []capacitySourcePayload   `json:",omitempty"`
+		Links              *links                    `json:",omitempty"`
+	}{
+		Name:               spec.Name,
+		CharacterCodeSet:   spec.CharacterCodeSet,
+		AccessCapabilities: spec.AccessCapabilities,
+	}
+	t.Capacity.Data.AllocatedBytes = spec.CapacityBytes
+	if len(spec.CapacitySources) > 0 {
+		source := CapacitySourceSpec{Pools: spec.CapacitySources}
+		t.CapacitySources = []capacitySourcePayload{source.payload()}
+	}
+	if spec.ClassOfService != "" {
+		t.Links = &links{ClassOfService: common.ODataLink{ODataID: spec.ClassOfService}}
+	}
+
+	resp, err := c.Post(ctx, fileSystems, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return redfish.LocationOrTask(ctx, c, resp)
+}
+
+// Delete deletes the file system with its shares. It returns the task
+// deleting the file system if the service deletes it asynchronously, or nil
+// once it is deleted.
+func (filesystem *FileSystem) Delete(ctx context.Context) (*redfish.Task, error) {
+	resp, err := filesystem.Client.Delete(ctx, filesystem.ODataID)
+	if err != nil {
+		return nil, err
+	}
+
+	return redfish.TaskFromResponse(ctx, filesystem.Client, resp)
+}
+
+// CreateShare exports a path of the file system as a file share. It returns
+// the URI of the new share, or the task creating it if the service creates it
+// asynchronously, in which case the URI is empty.
+func (filesystem *FileSystem) CreateShare(ctx context.Context, spec FileShareSpec) (string, *redfish.Task, error) {
+	if filesystem.exportedShares == "" {
+		return "", nil, fmt.Errorf("file share creation is not supported by this file system")
+	}
+
+	err := spec.validate()
+	if err != nil {
+		return "", nil, err
+	}
+
+	resp, err := filesystem.Client.Post(ctx, filesystem.exportedShares, spec.payload())
+	if err != nil {
+		return "", nil, err
+	}
+
+	return redfish.LocationOrTask(ctx, filesystem.Client, resp)
+}
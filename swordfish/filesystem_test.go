@@ -5,8 +5,11 @@
 package swordfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -215,3 +218,116 @@ func TestFileSystemUpdate(t *testing.T) {
 		t.Errorf("Unexpected MaxFileNameLengthBytes update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestFileSystemCreateShare tests exporting a file share.
+func TestFileSystemCreateShare(t *testing.T) {
+	var result FileSystem
+	err := json.NewDecoder(strings.NewReader(fileSystemBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	created := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": []string{"/redfish/v1/Shares/1/home"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, _, err := result.CreateShare(context.Background(), FileShareSpec{
+		Name:                     "home",
+		FileSharePath:            "/home",
+		FileSharingProtocols:     []FileProtocol{NFSv41FileProtocol},
+		WritePolicy:              SynchronousReplicaUpdateMode,
+		EthernetInterfaces:       "/redfish/v1/StorageServices/1/EthernetInterfaces",
+		FileShareQuotaType:       HardQuotaType,
+		FileShareTotalQuotaBytes: 107374182400,
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateShare call: %s", err)
+	}
+	if uri != "/redfish/v1/Shares/1/home" {
+		t.Errorf("Unexpected file share: %s", uri)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Shares/1" {
+		t.Errorf("Invalid CreateShare target: %s", calls[0].URL)
+	}
+	for _, expected := range []string{"FileSharePath:/home", "FileSharingProtocols:[NFSv4_1]",
+		"RootAccess:false", "WritePolicy:Synchronous",
+		"EthernetInterfaces:map[@odata.id:/redfish/v1/StorageServices/1/EthernetInterfaces]",
+		"FileShareQuotaType:Hard", "FileShareTotalQuotaBytes:1.073741824e+11"} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("Missing %s in CreateShare payload: %s", expected, calls[0].Payload)
+		}
+	}
+	if strings.Contains(calls[0].Payload, "CASupported") {
+		t.Errorf("Unexpected CASupported in CreateShare payload: %s", calls[0].Payload)
+	}
+}
+
+// TestFileSystemCreateShareValidation tests rejecting invalid file shares.
+func TestFileSystemCreateShareValidation(t *testing.T) {
+	var result FileSystem
+	err := json.NewDecoder(strings.NewReader(fileSystemBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	nfs := []FileProtocol{NFSv3FileProtocol}
+	specs := []FileShareSpec{
+		{FileSharingProtocols: nfs},
+		{FileSharePath: "/data"},
+		{FileSharePath: "/data", FileSharingProtocols: nfs, CASupported: true},
+		{FileSharePath: "/data", FileSharingProtocols: nfs, FileShareTotalQuotaBytes: 1024},
+		{FileSharePath: "/data", FileSharingProtocols: nfs, FileShareQuotaType: SoftQuotaType},
+		{FileSharePath: "/data", FileSharingProtocols: nfs, FileShareQuotaType: "Strict", FileShareTotalQuotaBytes: 1024},
+	}
+	for _, spec := range specs {
+		if _, _, err := result.CreateShare(context.Background(), spec); err == nil {
+			t.Errorf("Expected an error creating %+v", spec)
+		}
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Unexpected calls: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestFileSystemDelete tests the Delete call.
+func TestFileSystemDelete(t *testing.T) {
+	var result FileSystem
+	err := json.NewDecoder(strings.NewReader(fileSystemBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodDelete: {&http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	task, err := result.Delete(context.Background())
+	if err != nil || task != nil {
+		t.Errorf("Unexpected Delete result: %v %s", task, err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].Action != http.MethodDelete || calls[0].URL != "/redfish/v1/FileSystem" {
+		t.Errorf("Unexpected Delete call: %v", calls[0])
+	}
+}
@@ -7,9 +7,11 @@ package swordfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/jacobweinstock/gophish/common"
+	"github.com/jacobweinstock/gophish/redfish"
 )
 
 // AuthenticationMethod is method used to authenticate.
@@ -174,6 +176,7 @@ func (storagegroup *StorageGroup) UnmarshalJSON(b []byte) error {
 	storagegroup.classOfService = string(t.Links.ClassOfService)
 	storagegroup.parentStorageGroups = t.Links.ParentStorageGroups.ToStrings()
 	storagegroup.ParentStorageGroupsCount = t.Links.ParentStorageGroupsCount
+	storagegroup.serverEndpointGroups = t.ServerEndpointGroups.ToStrings()
 	storagegroup.exposeVolumesTarget = t.Actions.ExposeVolumes.Target
 	storagegroup.hideVolumesTarget = t.Actions.HideVolumes.Target
 
@@ -316,3 +319,234 @@ func (storagegroup *StorageGroup) HideVolumes(ctx context.Context) error {
 	}
 	return err
 }
+
+// mappedVolumePayload is a volume mapping as sent to the service.
+type mappedVolumePayload struct {
+	LogicalUnitNumber int
+	Volume            common.ODataLink
+}
+
+// mappedVolumesPayload validates volume mappings and returns them as sent to
+// the service. A volume is mapped once and a logical unit number used by a
+// single volume.
+func mappedVolumesPayload(mappedVolumes []MappedVolume) ([]mappedVolumePayload, error) {
+	result := make([]mappedVolumePayload, 0, len(mappedVolumes))
+	volumes := make(map[common.Link]bool)
+	luns := make(map[int]bool)
+	for _, mappedVolume := range mappedVolumes {
+		if mappedVolume.Volume == "" {
+			return nil, fmt.Errorf("mapped volume without a volume")
+		}
+		if mappedVolume.LogicalUnitNumber < 0 {
+			return nil, fmt.Errorf("invalid logical unit number %d", mappedVolume.LogicalUnitNumber)
+		}
+		if volumes[mappedVolume.Volume] {
+			return nil, fmt.Errorf("volume %s is already mapped", mappedVolume.Volume)
+		}
+		if luns[mappedVolume.LogicalUnitNumber] {
+			return nil, fmt.Errorf("logical unit number %d is already used", mappedVolume.LogicalUnitNumber)
+		}
+		volumes[mappedVolume.Volume] = true
+		luns[mappedVolume.LogicalUnitNumber] = true
+
+		result = append(result, mappedVolumePayload{
+			LogicalUnitNumber: mappedVolume.LogicalUnitNumber,
+			Volume:            common.ODataLink{ODataID: string(mappedVolume.Volume)},
+		})
+	}
+	return result, nil
+}
+
+// StorageGroupSpec describes a storage group to create with
+// StorageService.CreateStorageGroup.
+type StorageGroupSpec struct {
+	// Name is the name of the storage group.
+	Name string
+	// Description is the description of the storage group.
+	Description string
+	// MappedVolumes are the volumes of the group with their logical unit
+	// numbers.
+	MappedVolumes []MappedVolume
+	// ClientEndpointGroups are the URIs of the endpoint groups of the
+	// initiators the volumes are exposed to.
+	ClientEndpointGroups []string
+	// ServerEndpointGroups are the URIs of the endpoint groups of the
+	// targets the volumes are exposed through.
+	ServerEndpointGroups []string
+	// AccessState is the access state of the volumes through the endpoints,
+	// the service default if empty.
+	AccessState AccessState
+	// AuthenticationMethod is the authentication method of the endpoints,
+	// the service default if empty.
+	AuthenticationMethod AuthenticationMethod
+}
+
+// createStorageGroup validates the spec and POSTs it to a storage group
+// collection. It returns the URI of the new group, or the task creating it.
+func createStorageGroup(ctx context.Context, c common.Client, groups string, spec StorageGroupSpec) (string, *redfish.Task, error) {
+	if groups == "" {
+		return "", nil, fmt.Errorf("storage group creation is not supported by this service")
+	}
+
+	mappedVolumes, err := mappedVolumesPayload(spec.MappedVolumes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	t := struct {
+		Name                 string                `json:",omitempty"`
+		Description          string                `json:",omitempty"`
+		MappedVolumes        []mappedVolumePayload `json:",omitempty"`
+		ClientEndpointGroups []common.ODataLink    `json:",omitempty"`
+		ServerEndpointGroups []common.ODataLink    `json:",omitempty"`
+		AccessState          AccessState           `json:",omitempty"`
+		AuthenticationMethod AuthenticationMethod  `json:",omitempty"`
+	}{
+		Name:                 spec.Name,
+		Description:          spec.Description,
+		MappedVolumes:        mappedVolumes,
+		AccessState:          spec.AccessState,
+		AuthenticationMethod: spec.AuthenticationMethod,
+	}
+	if len(spec.ClientEndpointGroups) > 0 {
+		t.ClientEndpointGroups = common.ToODataLinks(spec.ClientEndpointGroups)
+	}
+	if len(spec.ServerEndpointGroups) > 0 {
+		t.ServerEndpointGroups = common.ToODataLinks(spec.ServerEndpointGroups)
+	}
+
+	resp, err := c.Post(ctx, groups, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return redfish.LocationOrTask(ctx, c, resp)
+}
+
+// Delete deletes the storage group. The volumes of the group are not
+// deleted. It returns the task deleting the group if the service deletes it
+// asynchronously, or nil once it is deleted.
+func (storagegroup *StorageGroup) Delete(ctx context.Context) (*redfish.Task, error) {
+	resp, err := storagegroup.Client.Delete(ctx, storagegroup.ODataID)
+	if err != nil {
+		return nil, err
+	}
+
+	return redfish.TaskFromResponse(ctx, storagegroup.Client, resp)
+}
+
+// NextLogicalUnitNumber returns the lowest logical unit number not used by
+// the volumes of the group.
+func (storagegroup *StorageGroup) NextLogicalUnitNumber() int {
+	used := make(map[int]bool)
+	for _, mappedVolume := range storagegroup.MappedVolumes {
+		used[mappedVolume.LogicalUnitNumber] = true
+	}
+
+	lun := 0
+	for used[lun] {
+		lun++
+	}
+	return lun
+}
+
+// setMappedVolumes replaces the volumes of the group.
+func (storagegroup *StorageGroup) setMappedVolumes(ctx context.Context, mappedVolumes []MappedVolume) error {
+	payload, err := mappedVolumesPayload(mappedVolumes)
+	if err != nil {
+		return err
+	}
+
+	t := struct {
+		MappedVolumes []mappedVolumePayload
+	}{MappedVolumes: payload}
+
+	_, err = storagegroup.Client.Patch(ctx, storagegroup.ODataID, t)
+	if err == nil {
+		storagegroup.MappedVolumes = mappedVolumes
+	}
+	return err
+}
+
+// AddMappedVolume adds a volume to the group with the logical unit number,
+// such as NextLogicalUnitNumber.
+func (storagegroup *StorageGroup) AddMappedVolume(ctx context.Context, volume string, logicalUnitNumber int) error {
+	mappedVolumes := make([]MappedVolume, 0, len(storagegroup.MappedVolumes)+1)
+	mappedVolumes = append(mappedVolumes, storagegroup.MappedVolumes...)
+	mappedVolumes = append(mappedVolumes, MappedVolume{
+		LogicalUnitNumber: logicalUnitNumber,
+		Volume:            common.Link(volume),
+	})
+
+	return storagegroup.setMappedVolumes(ctx, mappedVolumes)
+}
+
+// RemoveMappedVolume removes a volume from the group. The volume is not
+// deleted.
+func (storagegroup *StorageGroup) RemoveMappedVolume(ctx context.Context, volume string) error {
+	mappedVolumes := make([]MappedVolume, 0, len(storagegroup.MappedVolumes))
+	for _, mappedVolume := range storagegroup.MappedVolumes {
+		if string(mappedVolume.Volume) != volume {
+			mappedVolumes = append(mappedVolumes, mappedVolume)
+		}
+	}
+	if len(mappedVolumes) == len(storagegroup.MappedVolumes) {
+		return fmt.Errorf("volume %s is not mapped by this storage group", volume)
+	}
+
+	return storagegroup.setMappedVolumes(ctx, mappedVolumes)
+}
+
+// SetClientEndpointGroups sets the endpoint groups of the initiators the
+// volumes of the group are exposed to.
+func (storagegroup *StorageGroup) SetClientEndpointGroups(ctx context.Context, endpointGroups []string) error {
+	t := struct {
+		ClientEndpointGroups []common.ODataLink
+	}{ClientEndpointGroups: common.ToODataLinks(endpointGroups)}
+
+	_, err := storagegroup.Client.Patch(ctx, storagegroup.ODataID, t)
+	if err != nil {
+		return err
+	}
+
+	storagegroup.ClientEndpointGroups = make([]EndpointGroup, 0, len(endpointGroups))
+	for _, endpointGroup := range endpointGroups {
+		var group EndpointGroup
+		group.ODataID = endpointGroup
+		storagegroup.ClientEndpointGroups = append(storagegroup.ClientEndpointGroups, group)
+	}
+	storagegroup.ClientEndpointGroupsCount = len(endpointGroups)
+	return nil
+}
+
+// SetServerEndpointGroups sets the endpoint groups of the targets the volumes
+// of the group are exposed through.
+func (storagegroup *StorageGroup) SetServerEndpointGroups(ctx context.Context, endpointGroups []string) error {
+	t := struct {
+		ServerEndpointGroups []common.ODataLink
+	}{ServerEndpointGroups: common.ToODataLinks(endpointGroups)}
+
+	_, err := storagegroup.Client.Patch(ctx, storagegroup.ODataID, t)
+	if err != nil {
+		return err
+	}
+
+	storagegroup.serverEndpointGroups = endpointGroups
+	storagegroup.ServerEndpointGroupsCount = len(endpointGroups)
+	return nil
+}
+
+// ServerEndpointGroups gets the endpoint groups of the targets the volumes of
+// the group are exposed through.
+func (storagegroup *StorageGroup) ServerEndpointGroups(ctx context.Context) ([]*EndpointGroup, error) {
+	var result []*EndpointGroup
+	for _, groupLink := range storagegroup.serverEndpointGroups {
+		group, err := GetEndpointGroup(ctx, storagegroup.Client, groupLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, group)
+	}
+
+	return result, nil
+}
@@ -5,8 +5,11 @@
 package swordfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -152,3 +155,127 @@ func TestStorageGroupUpdate(t *testing.T) {
 		t.Errorf("Unexpected VolumeAreExposed update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestStorageGroupMappedVolumes tests adding and removing volumes.
+func TestStorageGroupMappedVolumes(t *testing.T) {
+	var result StorageGroup
+	err := json.NewDecoder(strings.NewReader(storageGroupBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	if lun := result.NextLogicalUnitNumber(); lun != 0 {
+		t.Errorf("Unexpected next logical unit number: %d", lun)
+	}
+
+	err = result.AddMappedVolume(context.Background(), "/redfish/v1/Volume/2", result.NextLogicalUnitNumber())
+	if err != nil {
+		t.Errorf("Error making AddMappedVolume call: %s", err)
+	}
+	if lun := result.NextLogicalUnitNumber(); lun != 2 {
+		t.Errorf("Unexpected next logical unit number: %d", lun)
+	}
+
+	// Mappings must be unique.
+	if err := result.AddMappedVolume(context.Background(), "/redfish/v1/Volume/3", 1); err == nil {
+		t.Error("Expected an error reusing a logical unit number")
+	}
+	if err := result.AddMappedVolume(context.Background(), "/redfish/v1/Volume/2", 5); err == nil {
+		t.Error("Expected an error mapping a volume twice")
+	}
+
+	err = result.RemoveMappedVolume(context.Background(), "/redfish/v1/Volume/1")
+	if err != nil {
+		t.Errorf("Error making RemoveMappedVolume call: %s", err)
+	}
+	if err := result.RemoveMappedVolume(context.Background(), "/redfish/v1/Volume/1"); err == nil {
+		t.Error("Expected an error removing a volume not mapped")
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Unexpected calls: %v", calls)
+	}
+	if calls[0].Action != http.MethodPatch || calls[0].URL != "/redfish/v1/StorageGroup" ||
+		!strings.Contains(calls[0].Payload, "MappedVolumes:[map[LogicalUnitNumber:1 Volume:map[@odata.id:/redfish/v1/Volume/1]] "+
+			"map[LogicalUnitNumber:0 Volume:map[@odata.id:/redfish/v1/Volume/2]]]") {
+		t.Errorf("Unexpected AddMappedVolume call: %v", calls[0])
+	}
+	if !strings.Contains(calls[1].Payload, "MappedVolumes:[map[LogicalUnitNumber:0 Volume:map[@odata.id:/redfish/v1/Volume/2]]]") {
+		t.Errorf("Unexpected RemoveMappedVolume payload: %s", calls[1].Payload)
+	}
+}
+
+// TestStorageGroupSetEndpointGroups tests setting the client and server
+// endpoint groups.
+func TestStorageGroupSetEndpointGroups(t *testing.T) {
+	var result StorageGroup
+	err := json.NewDecoder(strings.NewReader(storageGroupBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.serverEndpointGroups[0] != "/redfish/v1/Server/1/Endpoints" {
+		t.Errorf("Invalid server endpoint groups: %v", result.serverEndpointGroups)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetClientEndpointGroups(context.Background(), []string{"/redfish/v1/EndpointGroups/hosts"})
+	if err != nil {
+		t.Errorf("Error making SetClientEndpointGroups call: %s", err)
+	}
+	if result.ClientEndpointGroups[0].ODataID != "/redfish/v1/EndpointGroups/hosts" {
+		t.Errorf("Client endpoint groups not updated: %v", result.ClientEndpointGroups)
+	}
+
+	err = result.SetServerEndpointGroups(context.Background(), []string{"/redfish/v1/EndpointGroups/a", "/redfish/v1/EndpointGroups/b"})
+	if err != nil {
+		t.Errorf("Error making SetServerEndpointGroups call: %s", err)
+	}
+	if result.ServerEndpointGroupsCount != 2 {
+		t.Errorf("Server endpoint groups not updated: %v", result.serverEndpointGroups)
+	}
+
+	calls := testClient.CapturedCalls()
+	if !strings.Contains(calls[0].Payload, "ClientEndpointGroups:[map[@odata.id:/redfish/v1/EndpointGroups/hosts]]") {
+		t.Errorf("Unexpected SetClientEndpointGroups payload: %s", calls[0].Payload)
+	}
+	if !strings.Contains(calls[1].Payload,
+		"ServerEndpointGroups:[map[@odata.id:/redfish/v1/EndpointGroups/a] map[@odata.id:/redfish/v1/EndpointGroups/b]]") {
+		t.Errorf("Unexpected SetServerEndpointGroups payload: %s", calls[1].Payload)
+	}
+}
+
+// TestStorageGroupDelete tests the Delete call.
+func TestStorageGroupDelete(t *testing.T) {
+	var result StorageGroup
+	err := json.NewDecoder(strings.NewReader(storageGroupBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodDelete: {&http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	task, err := result.Delete(context.Background())
+	if err != nil || task != nil {
+		t.Errorf("Unexpected Delete result: %v %s", task, err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].Action != http.MethodDelete || calls[0].URL != "/redfish/v1/StorageGroup" {
+		t.Errorf("Unexpected Delete call: %v", calls[0])
+	}
+}
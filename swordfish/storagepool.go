@@ -7,6 +7,7 @@ package swordfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/jacobweinstock/gophish/common"
@@ -113,7 +114,7 @@ func (storagepool *StoragePool) UnmarshalJSON(b []byte) error {
 		Links                 links
 		AllocatedPools        common.Link
 		AllocatedVolumes      common.Link
-		CapacitySource        common.Links
+		CapacitySources       common.Links
 		ClassesOfService      common.Link
 		DefaultClassOfService common.Link
 	}
@@ -132,7 +133,7 @@ func (storagepool *StoragePool) UnmarshalJSON(b []byte) error {
 	storagepool.SpareResourceSetsCount = t.Links.SpareResourceSetsCount
 	storagepool.allocatedPools = string(t.AllocatedPools)
 	storagepool.allocatedVolumes = string(t.AllocatedVolumes)
-	storagepool.capacitySources = t.CapacitySource.ToStrings()
+	storagepool.capacitySources = t.CapacitySources.ToStrings()
 	storagepool.classesOfService = string(t.ClassesOfService)
 	storagepool.defaultClassOfService = string(t.DefaultClassOfService)
 
@@ -210,6 +211,146 @@ func ListReferencedStoragePools(ctx context.Context, c common.Client, link strin
 	return result, nil
 }
 
+// StoragePoolSpec describes a storage pool to create with
+// StorageService.CreateStoragePool, from drives or from other storage pools.
+type StoragePoolSpec struct {
+	// Name is the name of the storage pool.
+	Name string
+	// CapacityBytes is the number of bytes to allocate to the pool, all the
+	// capacity of the drives or pools if zero.
+	CapacityBytes int64
+	// Drives are the URIs of the drives providing the capacity of the pool.
+	Drives []string
+	// Pools are the URIs of the storage pools providing the capacity of the
+	// pool.
+	Pools []string
+	// DefaultClassOfService is the URI of the default class of service of
+	// the volumes created in the pool.
+	DefaultClassOfService string
+	// LowSpaceWarningThresholdPercents are the remaining capacity percents
+	// under which the service warns the pool is running out of space.
+	LowSpaceWarningThresholdPercents []int
+}
+
+// source returns the capacity source of the pool.
+func (spec *StoragePoolSpec) source() CapacitySourceSpec {
+	return CapacitySourceSpec{
+		CapacityBytes: spec.CapacityBytes,
+		Drives:        spec.Drives,
+		Pools:         spec.Pools,
+	}
+}
+
+// validateThresholds checks low space warning thresholds are percents.
+func validateThresholds(percents []int) error {
+	for _, percent := range percents {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("low space warning threshold %d is not a percent", percent)
+		}
+	}
+	return nil
+}
+
+// createStoragePool validates the spec and POSTs it to a storage pool
+// collection. It returns the URI of the new pool, or the task creating it.
+func createStoragePool(ctx context.Context, c common.Client, pools string, spec StoragePoolSpec) (string, *redfish.Task, error) {
+	if pools == "" {
+		return "", nil, fmt.Errorf("storage pool creation is not supported by this service")
+	}
+
+	source := spec.source()
+	err := source.validate()
+	if err != nil {
+		return "", nil, err
+	}
+	err = validateThresholds(spec.LowSpaceWarningThresholdPercents)
+	if err != nil {
+		return "", nil, err
+	}
+	err = source.checkPools(ctx, c)
+	if err != nil {
+		return "", nil, err
+	}
+
+	t := struct {
+		Name                             string                  `json:",omitempty"`
+		CapacitySources                  []capacitySourcePayload `json:",omitempty"`
+		DefaultClassOfService            *common.ODataLink       `json:",omitempty"`
+		LowSpaceWarningThresholdPercents []int                   `json:",omitempty"`
+	}{
+		Name:                             spec.Name,
+		CapacitySources:                  []capacitySourcePayload{source.payload()},
+		LowSpaceWarningThresholdPercents: spec.LowSpaceWarningThresholdPercents,
+	}
+	if spec.DefaultClassOfService != "" {
+		t.DefaultClassOfService = &common.ODataLink{ODataID: spec.DefaultClassOfService}
+	}
+
+	resp, err := c.Post(ctx, pools, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return redfish.LocationOrTask(ctx, c, resp)
+}
+
+// Delete deletes the storage pool. It returns the task deleting the pool if
+// the service deletes it asynchronously, or nil once it is deleted.
+func (storagepool *StoragePool) Delete(ctx context.Context) (*redfish.Task, error) {
+	resp, err := storagepool.Client.Delete(ctx, storagepool.ODataID)
+	if err != nil {
+		return nil, err
+	}
+
+	return redfish.TaskFromResponse(ctx, storagepool.Client, resp)
+}
+
+// AddCapacity adds a capacity source to the storage pool. The current capacity
+// sources of the pool are left unchanged. It returns the task adding the
+// capacity if the service adds it asynchronously, or nil once it is added.
+func (storagepool *StoragePool) AddCapacity(ctx context.Context, source CapacitySourceSpec) (*redfish.Task, error) {
+	err := source.validate()
+	if err != nil {
+		return nil, err
+	}
+	err = source.checkPools(ctx, storagepool.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	current := storagepool.CapacitySourcesCount
+	if len(storagepool.capacitySources) > current {
+		current = len(storagepool.capacitySources)
+	}
+
+	resp, err := common.PatchArrayMember(ctx, storagepool.Client, storagepool.ODataID, "CapacitySources", current,
+		source.payload())
+	if err != nil {
+		return nil, err
+	}
+
+	return redfish.TaskFromResponse(ctx, storagepool.Client, resp)
+}
+
+// SetLowSpaceWarningThresholds sets the remaining capacity percents under
+// which the service warns the pool is running out of space.
+func (storagepool *StoragePool) SetLowSpaceWarningThresholds(ctx context.Context, percents []int) error {
+	err := validateThresholds(percents)
+	if err != nil {
+		return err
+	}
+
+	t := struct {
+		LowSpaceWarningThresholdPercents []int
+	}{LowSpaceWarningThresholdPercents: percents}
+
+	_, err = storagepool.Client.Patch(ctx, storagepool.ODataID, t)
+	if err == nil {
+		storagepool.LowSpaceWarningThresholdPercents = percents
+	}
+	return err
+}
+
 // DedicatedSpareDrives gets the Drive entities which are currently assigned as
 // a dedicated spare and are able to support this StoragePool.
 func (storagepool *StoragePool) DedicatedSpareDrives(ctx context.Context) ([]*redfish.Drive, error) {
@@ -275,3 +416,68 @@ func (storagepool *StoragePool) DefaultClassOfService(ctx context.Context) (*Cla
 	}
 	return GetClassOfService(ctx, storagepool.Client, storagepool.defaultClassOfService)
 }
+
+// remainingBytes returns the capacity of the pool not consumed yet, if the
+// service reports the capacity allocated to the pool.
+func (storagepool *StoragePool) remainingBytes() (int64, bool) {
+	data := storagepool.Capacity.Data
+	if data.AllocatedBytes == 0 {
+		return 0, false
+	}
+	return data.AllocatedBytes - data.ConsumedBytes, true
+}
+
+// checkClassOfService checks the class of service is one of the classes of
+// service of the pool, if it lists them.
+func (storagepool *StoragePool) checkClassOfService(ctx context.Context, classOfService string) error {
+	if classOfService == "" || storagepool.classesOfService == "" {
+		return nil
+	}
+
+	supported, err := inCollection(ctx, storagepool.Client, storagepool.classesOfService, classOfService)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("class of service %s is not supported by storage pool %s",
+			classOfService, storagepool.ID)
+	}
+	return nil
+}
+
+// checkVolumePools checks that a volume conforms to one of the classes of
+// service of each of the pools providing it and, unless it is thin
+// provisioned, fits in their combined remaining capacity.
+func checkVolumePools(ctx context.Context, pools []*StoragePool, spec VolumeSpec) error {
+	for _, pool := range pools {
+		err := pool.checkClassOfService(ctx, spec.ClassOfService)
+		if err != nil {
+			return err
+		}
+	}
+
+	if spec.ProvisioningPolicy == ThinProvisioningPolicy {
+		return nil
+	}
+	return checkRemaining(pools, spec.CapacityBytes)
+}
+
+// CreateVolume creates a volume taking its capacity from this storage pool,
+// after checking it fits in the remaining capacity of the pool and conforms to
+// one of its classes of service. The CapacitySources of the spec are replaced
+// by the pool. It returns the URI of the new volume, or the task creating it
+// if the service creates it asynchronously, in which case the URI is empty.
+func (storagepool *StoragePool) CreateVolume(ctx context.Context, spec VolumeSpec) (string, *redfish.Task, error) {
+	err := spec.validate()
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = checkVolumePools(ctx, []*StoragePool{storagepool}, spec)
+	if err != nil {
+		return "", nil, err
+	}
+
+	spec.CapacitySources = []string{storagepool.ODataID}
+	return createVolume(ctx, storagepool.Client, storagepool.allocatedVolumes, spec)
+}
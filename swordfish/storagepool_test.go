@@ -5,8 +5,11 @@
 package swordfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -47,6 +50,10 @@ var storagePoolBody = `{
 				"ProvisionedBytes": 0
 			}
 		},
+		"CapacitySources": [{
+			"@odata.id": "/redfish/v1/StoragePool/CapacitySources/1"
+		}],
+		"CapacitySources@odata.count": 1,
 		"ClassesOfService": {
 			"@odata.id": "/redfish/v1/ClassesOfService"
 		},
@@ -117,6 +124,10 @@ func TestStoragePool(t *testing.T) {
 	if result.MaxBlockSizeBytes != 2199023255600 {
 		t.Errorf("Invalid max block size: %d", result.MaxBlockSizeBytes)
 	}
+
+	if len(result.capacitySources) != 1 || result.capacitySources[0] != "/redfish/v1/StoragePool/CapacitySources/1" {
+		t.Errorf("Invalid capacity sources: %v", result.capacitySources)
+	}
 }
 
 // TestStoragePoolUpdate tests the Update call.
@@ -159,3 +170,199 @@ func TestStoragePoolUpdate(t *testing.T) {
 		t.Errorf("Unexpected RecoverableCapacitySourceCount update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestStoragePoolCreateVolume tests creating a volume in the pool.
+func TestStoragePoolCreateVolume(t *testing.T) {
+	var result StoragePool
+	err := json.NewDecoder(strings.NewReader(storagePoolBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+	result.Capacity.Data.ConsumedBytes = 0
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {&http.Response{
+				StatusCode: http.StatusAccepted,
+				Body: ioutil.NopCloser(bytes.NewBufferString(
+					`{"@odata.id": "/redfish/v1/TaskService/Tasks/3", "Id": "3", "TaskState": "Running"}`)),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, task, err := result.CreateVolume(context.Background(), VolumeSpec{
+		CapacityBytes:   1099511627776,
+		CapacitySources: []string{"/redfish/v1/StoragePools/2"},
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateVolume call: %s", err)
+	}
+	if uri != "" || task == nil || task.ID != "3" {
+		t.Errorf("Unexpected result: %s %v", uri, task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Volume/1" {
+		t.Errorf("Invalid CreateVolume target: %s", calls[0].URL)
+	}
+	if !strings.Contains(calls[0].Payload, "ProvidingPools:[map[@odata.id:/redfish/v1/StoragePool]]") {
+		t.Errorf("The pool should provide the capacity: %s", calls[0].Payload)
+	}
+}
+
+// TestStoragePoolCreateVolumeValidation tests rejecting volumes that do not
+// fit in the pool.
+func TestStoragePoolCreateVolumeValidation(t *testing.T) {
+	var result StoragePool
+	err := json.NewDecoder(strings.NewReader(storagePoolBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {collectionResponse("/redfish/v1/ClassesOfService/1")},
+		},
+	}
+	result.SetClient(testClient)
+
+	// The pool is full.
+	_, _, err = result.CreateVolume(context.Background(), VolumeSpec{CapacityBytes: 1024})
+	if err == nil {
+		t.Error("Expected an error creating a volume in a full pool")
+	}
+
+	result.Capacity.Data.ConsumedBytes = 0
+	_, _, err = result.CreateVolume(context.Background(), VolumeSpec{
+		CapacityBytes:  1024,
+		ClassOfService: "/redfish/v1/ClassesOfService/2",
+	})
+	if err == nil {
+		t.Error("Expected an error creating a volume of an unsupported class of service")
+	}
+
+	for _, call := range testClient.CapturedCalls() {
+		if call.Action == http.MethodPost {
+			t.Errorf("Unexpected call: %v", call)
+		}
+	}
+}
+
+// TestStoragePoolAddCapacity tests adding capacity from drives to the pool.
+func TestStoragePoolAddCapacity(t *testing.T) {
+	var result StoragePool
+	err := json.NewDecoder(strings.NewReader(storagePoolBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPatch: {&http.Response{
+				StatusCode: http.StatusAccepted,
+				Body: ioutil.NopCloser(bytes.NewBufferString(
+					`{"@odata.id": "/redfish/v1/TaskService/Tasks/5", "Id": "5", "TaskState": "Running"}`)),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	task, err := result.AddCapacity(context.Background(), CapacitySourceSpec{
+		CapacityBytes: 1099511627776,
+		Drives:        []string{"/redfish/v1/Drives/3"},
+	})
+	if err != nil {
+		t.Errorf("Error making AddCapacity call: %s", err)
+	}
+	if task == nil || task.ID != "5" {
+		t.Errorf("Unexpected task: %v", task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].Action != http.MethodPatch || calls[0].URL != "/redfish/v1/StoragePool" {
+		t.Errorf("Unexpected AddCapacity call: %v", calls[0])
+	}
+	expected := "CapacitySources:[map[] map[ProvidedCapacity:map[Data:map[AllocatedBytes:1.099511627776e+12]] " +
+		"ProvidingDrives:[map[@odata.id:/redfish/v1/Drives/3]]]]"
+	if !strings.Contains(calls[0].Payload, expected) {
+		t.Errorf("Unexpected AddCapacity payload: %s", calls[0].Payload)
+	}
+
+	// The pool providing the capacity is full.
+	testClient = &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(storagePoolBody)),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+	_, err = result.AddCapacity(context.Background(), CapacitySourceSpec{
+		CapacityBytes: 1024,
+		Pools:         []string{"/redfish/v1/StoragePools/2"},
+	})
+	if err == nil {
+		t.Error("Expected an error adding capacity from a full pool")
+	}
+}
+
+// TestStoragePoolSetLowSpaceWarningThresholds tests the
+// SetLowSpaceWarningThresholds call.
+func TestStoragePoolSetLowSpaceWarningThresholds(t *testing.T) {
+	var result StoragePool
+	err := json.NewDecoder(strings.NewReader(storagePoolBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetLowSpaceWarningThresholds(context.Background(), []int{20, 10})
+	if err != nil {
+		t.Errorf("Error making SetLowSpaceWarningThresholds call: %s", err)
+	}
+	if len(result.LowSpaceWarningThresholdPercents) != 2 {
+		t.Errorf("Thresholds not updated: %v", result.LowSpaceWarningThresholdPercents)
+	}
+
+	err = result.SetLowSpaceWarningThresholds(context.Background(), []int{120})
+	if err == nil {
+		t.Error("Expected an error setting a threshold over 100 percent")
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || !strings.Contains(calls[0].Payload, "LowSpaceWarningThresholdPercents:[20 10]") {
+		t.Errorf("Unexpected SetLowSpaceWarningThresholds calls: %v", calls)
+	}
+}
+
+// TestStoragePoolDelete tests the Delete call.
+func TestStoragePoolDelete(t *testing.T) {
+	var result StoragePool
+	err := json.NewDecoder(strings.NewReader(storagePoolBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodDelete: {&http.Response{
+				StatusCode: http.StatusAccepted,
+				Header:     http.Header{"Location": []string{"/redfish/v1/TaskService/TaskMonitors/4"}},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"@odata.id": "/redfish/v1/TaskService/Tasks/4", "Id": "4"}`)),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	task, err := result.Delete(context.Background())
+	if err != nil {
+		t.Errorf("Error making Delete call: %s", err)
+	}
+	if task == nil || task.TaskMonitor != "/redfish/v1/TaskService/TaskMonitors/4" {
+		t.Errorf("Unexpected task: %v", task)
+	}
+}
@@ -7,6 +7,7 @@ package swordfish
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/jacobweinstock/gophish/common"
 	"github.com/jacobweinstock/gophish/redfish"
@@ -308,16 +309,12 @@ func (storageservice *StorageService) SpareResourceSets(ctx context.Context) ([]
 
 // StorageGroups gets the storage groups that are a part of this storage service.
 func (storageservice *StorageService) StorageGroups(ctx context.Context) ([]*StorageGroup, error) {
-	var result []*StorageGroup
-	for _, sgLink := range storageservice.spareResourceSets {
-		sg, err := GetStorageGroup(ctx, storageservice.Client, sgLink)
-		if err != nil {
-			return result, err
-		}
-		result = append(result, sg)
-	}
+	return ListReferencedStorageGroups(ctx, storageservice.Client, storageservice.storageGroups)
+}
 
-	return result, nil
+// StoragePools gets the storage pools of this storage service.
+func (storageservice *StorageService) StoragePools(ctx context.Context) ([]*StoragePool, error) {
+	return ListReferencedStoragePools(ctx, storageservice.Client, storageservice.storagePools)
 }
 
 // Volumes gets the volumes that are a part of this storage service.
@@ -325,6 +322,98 @@ func (storageservice *StorageService) Volumes(ctx context.Context) ([]*Volume, e
 	return ListReferencedVolumes(ctx, storageservice.Client, storageservice.volumes)
 }
 
+// CreateVolume creates a volume, after checking it conforms to one of the
+// classes of service of the storage service and fits in the combined remaining
+// capacity of the storage pools providing it, which should support its class
+// of service. It returns the URI of the new volume, or the task creating it if
+// the service creates it asynchronously, in which case the URI is empty.
+func (storageservice *StorageService) CreateVolume(ctx context.Context, spec VolumeSpec) (string, *redfish.Task, error) {
+	err := spec.validate()
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = storageservice.checkClassOfService(ctx, spec.ClassOfService)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pools, err := getStoragePools(ctx, storageservice.Client, spec.CapacitySources)
+	if err != nil {
+		return "", nil, err
+	}
+	err = checkVolumePools(ctx, pools, spec)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return createVolume(ctx, storageservice.Client, storageservice.volumes, spec)
+}
+
+// CreateStoragePool creates a storage pool from drives or from other storage
+// pools, after checking the pools have enough capacity remaining. It returns
+// the URI of the new pool, or the task creating it if the service creates it
+// asynchronously, in which case the URI is empty.
+func (storageservice *StorageService) CreateStoragePool(ctx context.Context, spec StoragePoolSpec) (string, *redfish.Task, error) {
+	return createStoragePool(ctx, storageservice.Client, storageservice.storagePools, spec)
+}
+
+// CreateStorageGroup creates a storage group mapping volumes to logical unit
+// numbers, exposed to the client endpoint groups through the server endpoint
+// groups. It returns the URI of the new group, or the task creating it if the
+// service creates it asynchronously, in which case the URI is empty.
+func (storageservice *StorageService) CreateStorageGroup(ctx context.Context, spec StorageGroupSpec) (string, *redfish.Task, error) {
+	return createStorageGroup(ctx, storageservice.Client, storageservice.storageGroups, spec)
+}
+
+// CreateEndpointGroup creates a group of client or server endpoints. It
+// returns the URI of the new group, or the task creating it if the service
+// creates it asynchronously, in which case the URI is empty.
+func (storageservice *StorageService) CreateEndpointGroup(ctx context.Context, spec EndpointGroupSpec) (string, *redfish.Task, error) {
+	return createEndpointGroup(ctx, storageservice.Client, storageservice.endpointGroups, spec)
+}
+
+// CreateFileSystem creates a file system, after checking it conforms to one of
+// the classes of service of the storage service and fits in the remaining
+// capacity of the storage pools providing it. It returns the URI of the new
+// file system, or the task creating it if the service creates it
+// asynchronously, in which case the URI is empty.
+func (storageservice *StorageService) CreateFileSystem(ctx context.Context, spec FileSystemSpec) (string, *redfish.Task, error) {
+	if spec.CapacityBytes <= 0 {
+		return "", nil, fmt.Errorf("file system capacity should be positive")
+	}
+
+	err := storageservice.checkClassOfService(ctx, spec.ClassOfService)
+	if err != nil {
+		return "", nil, err
+	}
+
+	source := spec.source()
+	err = source.checkPools(ctx, storageservice.Client)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return createFileSystem(ctx, storageservice.Client, storageservice.fileSystems, spec)
+}
+
+// checkClassOfService checks the class of service is one of the classes of
+// service of the storage service, if it lists them.
+func (storageservice *StorageService) checkClassOfService(ctx context.Context, classOfService string) error {
+	if classOfService == "" || storageservice.classesOfService == "" {
+		return nil
+	}
+
+	supported, err := inCollection(ctx, storageservice.Client, storageservice.classesOfService, classOfService)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("class of service %s is not supported by this service", classOfService)
+	}
+	return nil
+}
+
 // SetEncryptionKey shall set the encryption key for the storage subsystem.
 func (storageservice *StorageService) SetEncryptionKey(ctx context.Context, key string) error {
 	type temp struct {
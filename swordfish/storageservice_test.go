@@ -5,13 +5,18 @@
 package swordfish
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/jacobweinstock/gophish/common"
 )
 
-var storageServiceBody = strings.NewReader(
-	`{
+var storageServiceBody = `{
 		"@odata.context": "/redfish/v1/$metadata#StorageService.StorageService",
 		"@odata.type": "#StorageService.v1_2_0.StorageService",
 		"@odata.id": "/redfish/v1/StorageService",
@@ -73,12 +78,12 @@ var storageServiceBody = strings.NewReader(
 			"State": "Enabled",
 			"Health": "OK"
 		},
-		"StorageGroups": [{
-			"@odata.id": "/redfish/v1/StorageGroups/1"
-		}],
-		"StoragePools": [{
-			"@odata.id": "/redfish/v1/StoragePools/1"
-		}],
+		"StorageGroups": {
+			"@odata.id": "/redfish/v1/StorageGroups"
+		},
+		"StoragePools": {
+			"@odata.id": "/redfish/v1/StoragePools"
+		},
 		"StorageSubsystems": {
 			"@odata.id": "/redfish/v1/StorageSubsystems/1"
 		},
@@ -90,12 +95,12 @@ var storageServiceBody = strings.NewReader(
 				"target": "/redfish/v1/StorageService/Actions/StorageService.SetEncryptionKey"
 			}
 		}
-	}`)
+	}`
 
 // TestStorageService tests the parsing of StorageService objects.
 func TestStorageService(t *testing.T) {
 	var result StorageService
-	err := json.NewDecoder(storageServiceBody).Decode(&result)
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
 
 	if err != nil {
 		t.Errorf("Error decoding JSON: %s", err)
@@ -133,3 +138,390 @@ func TestStorageService(t *testing.T) {
 		t.Errorf("Invalid SetEncryptionKey link: %s", result.setEncryptionKeyTarget)
 	}
 }
+
+// collectionResponse returns a response holding a collection of the URIs.
+func collectionResponse(uris ...string) *http.Response {
+	body, _ := json.Marshal(map[string]interface{}{
+		"Members":             common.ToODataLinks(uris),
+		"Members@odata.count": len(uris),
+	})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBuffer(body)),
+	}
+}
+
+// TestStorageServiceCreateVolume tests creating a volume from a storage pool.
+func TestStorageServiceCreateVolume(t *testing.T) {
+	var result StorageService
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	pool := strings.Replace(storagePoolBody, `"ConsumedBytes": 2199023255600`, `"ConsumedBytes": 1099511627776`, 1)
+	created := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": []string{"/redfish/v1/Volumes/1/7"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				collectionResponse("/redfish/v1/ClassesOfService/1", "/redfish/v1/ClassesOfService/2"),
+				&http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewBufferString(pool))},
+				collectionResponse("/redfish/v1/ClassesOfService/2"),
+			},
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, task, err := result.CreateVolume(context.Background(), VolumeSpec{
+		Name:               "data",
+		CapacityBytes:      1099511627776,
+		ClassOfService:     "/redfish/v1/ClassesOfService/2",
+		ProvisioningPolicy: FixedProvisioningPolicy,
+		CapacitySources:    []string{"/redfish/v1/StoragePool"},
+		StorageGroups:      []string{"/redfish/v1/StorageGroups/1"},
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateVolume call: %s", err)
+	}
+	if uri != "/redfish/v1/Volumes/1/7" || task != nil {
+		t.Errorf("Unexpected result: %s %v", uri, task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[3].URL != "/redfish/v1/Volumes/1" {
+		t.Errorf("Invalid CreateVolume target: %s", calls[3].URL)
+	}
+	for _, expected := range []string{"CapacityBytes:1.099511627776e+12", "ProvisioningPolicy:Fixed",
+		"CapacitySources:[map[ProvidingPools:[map[@odata.id:/redfish/v1/StoragePool]]]]",
+		"StorageGroups:[map[@odata.id:/redfish/v1/StorageGroups/1]]",
+		"Links:map[ClassOfService:map[@odata.id:/redfish/v1/ClassesOfService/2]]"} {
+		if !strings.Contains(calls[3].Payload, expected) {
+			t.Errorf("Missing %s in CreateVolume payload: %s", expected, calls[3].Payload)
+		}
+	}
+}
+
+// TestStorageServiceCreateVolumePools tests checking the capacity of a volume
+// against the combined remaining capacity of its storage pools.
+func TestStorageServiceCreateVolumePools(t *testing.T) {
+	var result StorageService
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	// Each pool has a little more than 1 TiB remaining.
+	pool := func() *http.Response {
+		body := strings.Replace(storagePoolBody, `"ConsumedBytes": 2199023255600`, `"ConsumedBytes": 1099511627776`, 1)
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewBufferString(body))}
+	}
+	created := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": []string{"/redfish/v1/Volumes/1/7"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet:  {pool(), pool(), pool(), pool()},
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	spec := VolumeSpec{
+		Name:            "data",
+		CapacityBytes:   1649267441664,
+		CapacitySources: []string{"/redfish/v1/StoragePools/1", "/redfish/v1/StoragePools/2"},
+	}
+	_, _, err = result.CreateVolume(context.Background(), spec)
+	if err != nil {
+		t.Errorf("Volume should fit in the pools together: %s", err)
+	}
+
+	spec.CapacityBytes = 2748779069440
+	_, _, err = result.CreateVolume(context.Background(), spec)
+	if err == nil {
+		t.Error("Volume larger than the pools together should be rejected")
+	}
+}
+
+// TestStorageServiceCreateVolumeValidation tests rejecting volumes the
+// service does not support.
+func TestStorageServiceCreateVolumeValidation(t *testing.T) {
+	var result StorageService
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {collectionResponse("/redfish/v1/ClassesOfService/1")},
+		},
+	}
+	result.SetClient(testClient)
+
+	specs := []VolumeSpec{
+		{},
+		{CapacityBytes: 1024, ProvisioningPolicy: "Thick"},
+		{CapacityBytes: 1024, ClassOfService: "/redfish/v1/ClassesOfService/2"},
+	}
+	for _, spec := range specs {
+		if _, _, err := result.CreateVolume(context.Background(), spec); err == nil {
+			t.Errorf("Expected an error creating %+v", spec)
+		}
+	}
+
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Errorf("Unexpected calls: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestStorageServiceCreateStoragePool tests creating a storage pool from
+// drives.
+func TestStorageServiceCreateStoragePool(t *testing.T) {
+	var result StorageService
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	created := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": []string{"/redfish/v1/StoragePools/2"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, _, err := result.CreateStoragePool(context.Background(), StoragePoolSpec{
+		Name:                             "gold",
+		Drives:                           []string{"/redfish/v1/Drives/1", "/redfish/v1/Drives/2"},
+		DefaultClassOfService:            "/redfish/v1/ClassesOfService/1",
+		LowSpaceWarningThresholdPercents: []int{10},
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateStoragePool call: %s", err)
+	}
+	if uri != "/redfish/v1/StoragePools/2" {
+		t.Errorf("Unexpected storage pool: %s", uri)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/StoragePools" {
+		t.Errorf("Invalid CreateStoragePool target: %s", calls[0].URL)
+	}
+	for _, expected := range []string{"Name:gold",
+		"CapacitySources:[map[ProvidingDrives:[map[@odata.id:/redfish/v1/Drives/1] map[@odata.id:/redfish/v1/Drives/2]]]]",
+		"DefaultClassOfService:map[@odata.id:/redfish/v1/ClassesOfService/1]",
+		"LowSpaceWarningThresholdPercents:[10]"} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("Missing %s in CreateStoragePool payload: %s", expected, calls[0].Payload)
+		}
+	}
+}
+
+// TestStorageServiceCreateStoragePoolValidation tests rejecting invalid
+// storage pools.
+func TestStorageServiceCreateStoragePoolValidation(t *testing.T) {
+	var result StorageService
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	specs := []StoragePoolSpec{
+		{},
+		{Drives: []string{"/redfish/v1/Drives/1"}, Pools: []string{"/redfish/v1/StoragePools/1"}},
+		{Drives: []string{"/redfish/v1/Drives/1"}, CapacityBytes: -1},
+		{Drives: []string{"/redfish/v1/Drives/1"}, LowSpaceWarningThresholdPercents: []int{-5}},
+	}
+	for _, spec := range specs {
+		if _, _, err := result.CreateStoragePool(context.Background(), spec); err == nil {
+			t.Errorf("Expected an error creating %+v", spec)
+		}
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Unexpected calls: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestStorageServiceCreateStorageGroup tests creating a storage group exposing
+// volumes to hosts.
+func TestStorageServiceCreateStorageGroup(t *testing.T) {
+	var result StorageService
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	created := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": []string{"/redfish/v1/StorageGroups/db"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, _, err := result.CreateStorageGroup(context.Background(), StorageGroupSpec{
+		Name: "db",
+		MappedVolumes: []MappedVolume{
+			{LogicalUnitNumber: 0, Volume: "/redfish/v1/Volumes/1"},
+			{LogicalUnitNumber: 1, Volume: "/redfish/v1/Volumes/2"},
+		},
+		ClientEndpointGroups: []string{"/redfish/v1/EndpointGroups/hosts"},
+		ServerEndpointGroups: []string{"/redfish/v1/EndpointGroups/targets"},
+		AuthenticationMethod: CHAPAuthenticationMethod,
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateStorageGroup call: %s", err)
+	}
+	if uri != "/redfish/v1/StorageGroups/db" {
+		t.Errorf("Unexpected storage group: %s", uri)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/StorageGroups" {
+		t.Errorf("Invalid CreateStorageGroup target: %s", calls[0].URL)
+	}
+	for _, expected := range []string{"Name:db", "AuthenticationMethod:CHAP",
+		"MappedVolumes:[map[LogicalUnitNumber:0 Volume:map[@odata.id:/redfish/v1/Volumes/1]] " +
+			"map[LogicalUnitNumber:1 Volume:map[@odata.id:/redfish/v1/Volumes/2]]]",
+		"ClientEndpointGroups:[map[@odata.id:/redfish/v1/EndpointGroups/hosts]]",
+		"ServerEndpointGroups:[map[@odata.id:/redfish/v1/EndpointGroups/targets]]"} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("Missing %s in CreateStorageGroup payload: %s", expected, calls[0].Payload)
+		}
+	}
+
+	_, _, err = result.CreateStorageGroup(context.Background(), StorageGroupSpec{
+		MappedVolumes: []MappedVolume{
+			{LogicalUnitNumber: 3, Volume: "/redfish/v1/Volumes/1"},
+			{LogicalUnitNumber: 3, Volume: "/redfish/v1/Volumes/2"},
+		},
+	})
+	if err == nil {
+		t.Error("Expected an error mapping volumes to the same logical unit number")
+	}
+}
+
+// TestStorageServiceCreateEndpointGroup tests creating an endpoint group.
+func TestStorageServiceCreateEndpointGroup(t *testing.T) {
+	var result StorageService
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	created := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": []string{"/redfish/v1/EndpointGroups/hosts"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, _, err := result.CreateEndpointGroup(context.Background(), EndpointGroupSpec{
+		Name:      "hosts",
+		GroupType: ClientGroupType,
+		Endpoints: []string{"/redfish/v1/Fabrics/1/Endpoints/I1", "/redfish/v1/Fabrics/1/Endpoints/I2"},
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateEndpointGroup call: %s", err)
+	}
+	if uri != "/redfish/v1/EndpointGroups/hosts" {
+		t.Errorf("Unexpected endpoint group: %s", uri)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/EndpointGroups" ||
+		!strings.Contains(calls[0].Payload, "GroupType:Client") ||
+		!strings.Contains(calls[0].Payload, "Endpoints:[map[@odata.id:/redfish/v1/Fabrics/1/Endpoints/I1] "+
+			"map[@odata.id:/redfish/v1/Fabrics/1/Endpoints/I2]]") {
+		t.Errorf("Unexpected CreateEndpointGroup call: %v", calls[0])
+	}
+
+	specs := []EndpointGroupSpec{
+		{Name: "untyped"},
+		{GroupType: ClientGroupType, TargetEndpointGroupIdentifier: 4},
+	}
+	for _, spec := range specs {
+		if _, _, err := result.CreateEndpointGroup(context.Background(), spec); err == nil {
+			t.Errorf("Expected an error creating %+v", spec)
+		}
+	}
+}
+
+// TestStorageServiceCreateFileSystem tests creating a file system.
+func TestStorageServiceCreateFileSystem(t *testing.T) {
+	var result StorageService
+	err := json.NewDecoder(strings.NewReader(storageServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	created := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": []string{"/redfish/v1/FileSystems/home"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet:  {collectionResponse("/redfish/v1/ClassesOfService/1")},
+			http.MethodPost: {created},
+		},
+	}
+	result.SetClient(testClient)
+
+	uri, _, err := result.CreateFileSystem(context.Background(), FileSystemSpec{
+		Name:               "home",
+		CapacityBytes:      1099511627776,
+		ClassOfService:     "/redfish/v1/ClassesOfService/1",
+		CharacterCodeSet:   []CharacterCodeSet{UTF8CharacterCodeSet},
+		AccessCapabilities: []StorageAccessCapability{ReadStorageAccessCapability, WriteStorageAccessCapability},
+	})
+	if err != nil {
+		t.Fatalf("Error making CreateFileSystem call: %s", err)
+	}
+	if uri != "/redfish/v1/FileSystems/home" {
+		t.Errorf("Unexpected file system: %s", uri)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[1].URL != "/redfish/v1/FileSystems" {
+		t.Errorf("Invalid CreateFileSystem target: %s", calls[1].URL)
+	}
+	for _, expected := range []string{"Name:home", "Capacity:map[Data:map[AllocatedBytes:1.099511627776e+12]]",
+		"CharacterCodeSet:[UTF_8]", "AccessCapabilities:[Read Write]",
+		"Links:map[ClassOfService:map[@odata.id:/redfish/v1/ClassesOfService/1]]"} {
+		if !strings.Contains(calls[1].Payload, expected) {
+			t.Errorf("Missing %s in CreateFileSystem payload: %s", expected, calls[1].Payload)
+		}
+	}
+
+	_, _, err = result.CreateFileSystem(context.Background(), FileSystemSpec{Name: "empty"})
+	if err == nil {
+		t.Error("Expected an error creating a file system without capacity")
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/jacobweinstock/gophish/common"
 	"github.com/jacobweinstock/gophish/redfish"
@@ -390,6 +393,8 @@ type Volume struct {
 	storageGroups []string
 	// assignReplicaTargetTarget is the URL to send AssignReplicaTarget requests.
 	assignReplicaTargetTarget string
+	// changeRAIDLayoutTarget is the URL to send ChangeRAIDLayout requests.
+	changeRAIDLayoutTarget string
 	// checkConsistencyTarget is the URL to send CheckConsistency requests.
 	checkConsistencyTarget string
 	// createReplicaTargetTarget is the URL to send CreateReplicaTarget requests.
@@ -447,6 +452,9 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 		AssignReplicaTarget struct {
 			Target string
 		} `json:"#Volume.AssignReplicaTarget"`
+		ChangeRAIDLayout struct {
+			Target string
+		} `json:"#Volume.ChangeRAIDLayout"`
 		CheckConsistency struct {
 			Target string
 		} `json:"#Volume.CheckConsistency"`
@@ -498,6 +506,7 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 	volume.DrivesCount = t.Links.DrivesCount
 	volume.SpareResourceSetsCount = t.Links.SpareResourceSetsCount
 	volume.assignReplicaTargetTarget = t.Actions.AssignReplicaTarget.Target
+	volume.changeRAIDLayoutTarget = t.Actions.ChangeRAIDLayout.Target
 	volume.checkConsistencyTarget = t.Actions.CheckConsistency.Target
 	volume.createReplicaTargetTarget = t.Actions.CreateReplicaTarget.Target
 	volume.initializeTarget = t.Actions.Initialize.Target
@@ -586,6 +595,176 @@ func ListReferencedVolumes(ctx context.Context, c common.Client, link string) ([
 	return result, nil
 }
 
+// Delete deletes the volume. It returns the task deleting the volume if the
+// service deletes it asynchronously, or nil once it is deleted.
+func (volume *Volume) Delete(ctx context.Context) (*redfish.Task, error) {
+	resp, err := volume.Client.Delete(ctx, volume.ODataID)
+	if err != nil {
+		return nil, err
+	}
+
+	return redfish.TaskFromResponse(ctx, volume.Client, resp)
+}
+
+// Resize changes the capacity of the volume by updating its CapacityBytes
+// property. If the service rejects the update, with a Redfish error or as a
+// method it does not allow, and supports the ChangeRAIDLayout action, the
+// volume is resized with the action instead. Other errors, such as failing to
+// reach the service, are returned as is. It
+// returns the task resizing the volume if the service resizes it
+// asynchronously, or nil once it is resized.
+func (volume *Volume) Resize(ctx context.Context, capacityBytes int64) (*redfish.Task, error) {
+	if capacityBytes <= 0 {
+		return nil, fmt.Errorf("volume capacity should be positive")
+	}
+
+	resp, err := volume.Client.Patch(ctx, volume.ODataID, &resizePayload{CapacityBytes: capacityBytes})
+	if err != nil {
+		if volume.changeRAIDLayoutTarget == "" || !resizeRejected(err) {
+			return nil, err
+		}
+		return volume.ChangeRAIDLayout(ctx, capacityBytes)
+	}
+
+	return volume.resized(ctx, resp, capacityBytes)
+}
+
+// resizeRejected returns whether the error of a CapacityBytes update is the
+// service rejecting the update: a bad request with a Redfish error, or a
+// method not allowed.
+func resizeRejected(err error) bool {
+	if e, ok := err.(*common.Error); ok {
+		return e.HTTPReturnedStatusCode == http.StatusBadRequest ||
+			e.HTTPReturnedStatusCode == http.StatusMethodNotAllowed
+	}
+	return strings.HasPrefix(err.Error(), strconv.Itoa(http.StatusMethodNotAllowed)+":")
+}
+
+// ChangeRAIDLayout changes the capacity of the volume with the
+// ChangeRAIDLayout action. It returns the task resizing the volume if the
+// service resizes it asynchronously, or nil once it is resized.
+func (volume *Volume) ChangeRAIDLayout(ctx context.Context, capacityBytes int64) (*redfish.Task, error) {
+	if capacityBytes <= 0 {
+		return nil, fmt.Errorf("volume capacity should be positive")
+	}
+	if volume.changeRAIDLayoutTarget == "" {
+		return nil, fmt.Errorf("ChangeRAIDLayout is not supported by this volume")
+	}
+
+	resp, err := volume.Client.Post(ctx, volume.changeRAIDLayoutTarget, &resizePayload{CapacityBytes: capacityBytes})
+	if err != nil {
+		return nil, err
+	}
+
+	return volume.resized(ctx, resp, capacityBytes)
+}
+
+// resizePayload is the new capacity of a volume as sent to the service.
+type resizePayload struct {
+	CapacityBytes int64
+}
+
+// resized returns the task resizing the volume, or records the new capacity
+// if the service resized it synchronously.
+func (volume *Volume) resized(ctx context.Context, resp *http.Response, capacityBytes int64) (*redfish.Task, error) {
+	task, err := redfish.TaskFromResponse(ctx, volume.Client, resp)
+	if err == nil && task == nil {
+		volume.CapacityBytes = int(capacityBytes)
+	}
+	return task, err
+}
+
+// VolumeSpec describes a volume to create with StorageService.CreateVolume or
+// StoragePool.CreateVolume.
+type VolumeSpec struct {
+	// Name is the name of the volume.
+	Name string
+	// CapacityBytes is the size of the volume.
+	CapacityBytes int64
+	// ClassOfService is the URI of the class of service the volume conforms
+	// to, the default class of service of the pool if empty.
+	ClassOfService string
+	// ProvisioningPolicy is whether the capacity of the volume is fully
+	// allocated or may be over allocated, the service default if empty.
+	ProvisioningPolicy ProvisioningPolicy
+	// CapacitySources are the URIs of the storage pools providing the
+	// capacity of the volume.
+	CapacitySources []string
+	// StorageGroups are the URIs of the storage groups to add the volume to.
+	StorageGroups []string
+}
+
+// validate checks the spec before it is sent to the service.
+func (spec *VolumeSpec) validate() error {
+	if spec.CapacityBytes <= 0 {
+		return fmt.Errorf("volume capacity should be positive")
+	}
+
+	switch spec.ProvisioningPolicy {
+	case "", FixedProvisioningPolicy, ThinProvisioningPolicy:
+	default:
+		return fmt.Errorf("unknown provisioning policy '%s'", spec.ProvisioningPolicy)
+	}
+
+	return nil
+}
+
+// createVolume POSTs a validated spec to a volume collection. It returns the
+// URI of the new volume, or the task creating it.
+func createVolume(ctx context.Context, c common.Client, volumes string, spec VolumeSpec) (string, *redfish.Task, error) {
+	if volumes == "" {
+		return "", nil, fmt.Errorf("volume creation is not supported by this service")
+	}
+
+	type links struct {
+		ClassOfService common.ODataLink
+	}
+	t := struct {
+		Name               string                  `json:",omitempty"`
+		CapacityBytes      int64                   `json:",omitempty"`
+		ProvisioningPolicy ProvisioningPolicy      `json:",omitempty"`
+		CapacitySources    []capacitySourcePayload `json:",omitempty"`
+		StorageGroups      []common.ODataLink      `json:",omitempty"`
+		Links              *links                  `json:",omitempty"`
+	}{
+		Name:               spec.Name,
+		CapacityBytes:      spec.CapacityBytes,
+		ProvisioningPolicy: spec.ProvisioningPolicy,
+	}
+	if len(spec.CapacitySources) > 0 {
+		source := CapacitySourceSpec{Pools: spec.CapacitySources}
+		t.CapacitySources = []capacitySourcePayload{source.payload()}
+	}
+	if len(spec.StorageGroups) > 0 {
+		t.StorageGroups = common.ToODataLinks(spec.StorageGroups)
+	}
+	if spec.ClassOfService != "" {
+		t.Links = &links{ClassOfService: common.ODataLink{ODataID: spec.ClassOfService}}
+	}
+
+	resp, err := c.Post(ctx, volumes, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return redfish.LocationOrTask(ctx, c, resp)
+}
+
+// inCollection returns whether a collection has a member with the URI.
+func inCollection(ctx context.Context, c common.Client, collection, uri string) (bool, error) {
+	members, err := common.GetCollection(ctx, c, collection)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range members.ItemLinks {
+		if member == uri {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // ClassOfService gets the class of service that this storage volume conforms to.
 func (volume *Volume) ClassOfService(ctx context.Context) (*ClassOfService, error) {
 	if volume.classOfService == "" {
@@ -5,8 +5,12 @@
 package swordfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -127,6 +131,9 @@ var volumeBody = `{
 			"#Volume.AssignReplicaTarget": {
 				"target": "/redfish/v1/Volume/Actions/Volume.AssignReplicaTarget"
 			},
+			"#Volume.ChangeRAIDLayout": {
+				"target": "/redfish/v1/Volume/Actions/Volume.ChangeRAIDLayout"
+			},
 			"#Volume.CheckConsistency": {
 				"target": "/redfish/v1/Volume/Actions/Volume.CheckConsistency"
 			},
@@ -307,3 +314,132 @@ func TestVolumeUpdate(t *testing.T) {
 		t.Errorf("Unexpected WriteHoleProtectionPolicy update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestVolumeDelete tests the Delete call.
+func TestVolumeDelete(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodDelete: {&http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+
+	task, err := result.Delete(context.Background())
+	if err != nil {
+		t.Errorf("Error making Delete call: %s", err)
+	}
+	if task != nil {
+		t.Errorf("Unexpected task: %v", task)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].Action != http.MethodDelete || calls[0].URL != "/redfish/v1/Volume" {
+		t.Errorf("Unexpected Delete call: %v", calls[0])
+	}
+}
+
+// TestVolumeResize tests resizing volumes by updating their capacity, and
+// with ChangeRAIDLayout when asked or when the update is rejected.
+func TestVolumeResize(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	done := func() *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewBufferString(""))}
+	}
+	rejected := common.ConstructError(http.StatusBadRequest,
+		[]byte(`{"error": {"code": "Base.1.8.PropertyNotWritable", "message": "CapacityBytes is read only"}}`))
+	notAllowed := common.ConstructError(http.StatusMethodNotAllowed, []byte("Method Not Allowed"))
+	failed := common.ConstructError(http.StatusInternalServerError,
+		[]byte(`{"error": {"code": "Base.1.8.InternalError", "message": "Internal error"}}`))
+	unreachable := errors.New("connection refused")
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost:  {done(), done(), done()},
+			http.MethodPatch: {done(), rejected, notAllowed, failed, unreachable, rejected},
+		},
+	}
+	result.SetClient(testClient)
+
+	_, err = result.Resize(context.Background(), 4398046511104)
+	if err != nil {
+		t.Errorf("Error making Resize call: %s", err)
+	}
+	if result.CapacityBytes != 4398046511104 {
+		t.Errorf("Capacity not updated: %d", result.CapacityBytes)
+	}
+
+	_, err = result.Resize(context.Background(), 8796093022208)
+	if err != nil {
+		t.Errorf("Error making Resize call: %s", err)
+	}
+
+	_, err = result.ChangeRAIDLayout(context.Background(), 8796093022208)
+	if err != nil {
+		t.Errorf("Error making ChangeRAIDLayout call: %s", err)
+	}
+
+	_, err = result.Resize(context.Background(), 8796093022208)
+	if err != nil {
+		t.Errorf("Error making Resize call: %s", err)
+	}
+
+	// Only the service rejecting the update falls back to the action.
+	_, err = result.Resize(context.Background(), 4398046511104)
+	if err != failed {
+		t.Errorf("Expected the internal error, got: %v", err)
+	}
+
+	_, err = result.Resize(context.Background(), 4398046511104)
+	if err != unreachable {
+		t.Errorf("Expected the connection error, got: %v", err)
+	}
+
+	result.changeRAIDLayoutTarget = ""
+	_, err = result.Resize(context.Background(), 4398046511104)
+	if err != rejected {
+		t.Errorf("Expected the rejected update error, got: %v", err)
+	}
+	if result.CapacityBytes != 8796093022208 {
+		t.Errorf("Capacity should not change when the resize fails: %d", result.CapacityBytes)
+	}
+
+	_, err = result.Resize(context.Background(), 0)
+	if err == nil {
+		t.Error("Expected an error resizing to no capacity")
+	}
+
+	var actions []string
+	for _, call := range testClient.CapturedCalls() {
+		actions = append(actions, call.Action+" "+call.URL)
+	}
+	expected := []string{
+		"PATCH /redfish/v1/Volume",
+		"PATCH /redfish/v1/Volume",
+		"POST /redfish/v1/Volume/Actions/Volume.ChangeRAIDLayout",
+		"POST /redfish/v1/Volume/Actions/Volume.ChangeRAIDLayout",
+		"PATCH /redfish/v1/Volume",
+		"POST /redfish/v1/Volume/Actions/Volume.ChangeRAIDLayout",
+		"PATCH /redfish/v1/Volume",
+		"PATCH /redfish/v1/Volume",
+		"PATCH /redfish/v1/Volume",
+	}
+	if strings.Join(actions, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected calls:\n%s", strings.Join(actions, "\n"))
+	}
+	if payload := testClient.CapturedCalls()[2].Payload; payload != "map[CapacityBytes:8.796093022208e+12]" {
+		t.Errorf("Unexpected ChangeRAIDLayout payload: %s", payload)
+	}
+}